@@ -3,60 +3,193 @@
 package main
 
 import (
+	"bufio"
+	"container/heap"
 	"fmt"
-	"log"
-	"os"
-	"sort"
+	"io"
 	"strconv"
 	"strings"
+
+	"github.com/erikzak/adventofcode/2022/aoc"
 )
 
 const inputPath = "../input.txt"
 
-func check(err error) {
-	if err != nil {
-		log.Panic(err)
+// Elves ranked by the puzzle parts
+const topCount = 3
+
+// Elf by position in the input, counting from 0, with the calories it carries
+type Elf struct {
+	Index    int `json:"index"`
+	Calories int `json:"calories"`
+}
+
+// Min-heap of elves, the elf ranked lowest on top. Elves carrying the same
+// calories rank by position, earlier first
+type elfHeap []Elf
+
+func (h elfHeap) Len() int {
+	return len(h)
+}
+
+func (h elfHeap) Less(i, j int) bool {
+	return ranksBelow(h[i], h[j])
+}
+
+func (h elfHeap) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+}
+
+func (h *elfHeap) Push(x any) {
+	*h = append(*h, x.(Elf))
+}
+
+func (h *elfHeap) Pop() any {
+	old := *h
+	elf := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return elf
+}
+
+// Checks if elf a ranks below elf b
+func ranksBelow(a Elf, b Elf) bool {
+	if a.Calories != b.Calories {
+		return a.Calories < b.Calories
 	}
+	return a.Index > b.Index
 }
 
-// Parses puzzle input from txt file.
-// Returns a list of sum calories per elf.
-func readInput(inputPath string) (elfCalories []int) {
-	inputBytes, err := os.ReadFile(inputPath)
-	check(err)
-	input := string(inputBytes)
+// Food carried by an elf
+type inventory struct {
+	elf     Elf
+	items   int // Number of food items
+	largest int // Calories of the largest food item
+}
 
-	elfStrings := strings.Split(input, "\n\n")
-	for _, elfString := range elfStrings {
-		elfString = strings.TrimSpace(elfString)
-		if elfString == "" {
+// Streams inventory from reader one line at a time, calling visit with each
+// elf's food items. Elves are separated by blank lines
+func scanInventories(r io.Reader, visit func(inventory inventory)) error {
+	file := ""
+	if named, ok := r.(interface{ Name() string }); ok {
+		file = named.Name()
+	}
+	scanner := bufio.NewScanner(r)
+	current := inventory{}
+	empty, lines := true, 0
+	for scanner.Scan() {
+		lines++
+		line := strings.TrimSuffix(scanner.Text(), "\r")
+		empty = empty && line == ""
+		foodItem := strings.TrimSpace(line)
+		if foodItem == "" {
+			if current.items > 0 {
+				visit(current)
+				current = inventory{elf: Elf{Index: current.elf.Index + 1}}
+			}
 			continue
 		}
-		foodItems := strings.Split(elfString, "\n")
-		sumCalories := 0
-		for _, foodItem := range foodItems {
-			calories, err := strconv.Atoi(foodItem)
-			check(err)
-			sumCalories += calories
+		calories, err := strconv.Atoi(foodItem)
+		if err != nil {
+			cause := fmt.Errorf("invalid number %q", foodItem)
+			return &aoc.ParseError{File: file, Line: lines, Column: strings.Index(line, foodItem) + 1, Text: line, Cause: cause}
+		}
+		current.elf.Calories += calories
+		if current.items == 0 || calories > current.largest {
+			current.largest = calories
+		}
+		current.items++
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+	if current.items > 0 {
+		visit(current)
+	}
+	// Input of nothing but line breaks is empty, as for aoc.ReadFrom
+	if empty {
+		return &aoc.ParseError{File: file, Line: 1, Cause: aoc.ErrEmptyInput}
+	}
+	return nil
+}
+
+// Streams inventory from reader, returning the n elves carrying the most
+// calories, most first. Keeps at most n elves, so inputs of any size are
+// ranked in one pass and constant memory
+func topElves(r io.Reader, n int) ([]Elf, error) {
+	if n < 1 {
+		return nil, fmt.Errorf("can't rank top %d elves", n)
+	}
+	top := make(elfHeap, 0, n)
+	err := scanInventories(r, func(inventory inventory) {
+		// Ranked if beating the lowest ranked elf
+		if len(top) < n {
+			heap.Push(&top, inventory.elf)
+		} else if ranksBelow(top[0], inventory.elf) {
+			top[0] = inventory.elf
+			heap.Fix(&top, 0)
 		}
-		elfCalories = append(elfCalories, sumCalories)
+	})
+	if err != nil {
+		return nil, err
+	}
+	// Popping gives the lowest ranked first
+	ranking := make([]Elf, len(top))
+	for i := len(ranking) - 1; i >= 0; i-- {
+		ranking[i] = heap.Pop(&top).(Elf)
 	}
-	return elfCalories
+	return ranking, nil
 }
 
-// Parses input as txt into list then sorts to find max calories
-func main() {
-	elfCalories := readInput(inputPath)
-	sort.Ints(elfCalories)
+// Parses puzzle input from reader.
+// Returns the top three elves by calories carried, most first.
+func readInput(r io.Reader) (ranking []Elf, err error) {
+	return topElves(r, topCount)
+}
 
-	// Part 1
-	fmt.Println(elfCalories[len(elfCalories)-1])
+// Part 1: how many calories does the elf carrying the most calories carry?
+func solvePart1(ranking []Elf) int {
+	if len(ranking) == 0 {
+		return 0
+	}
+	return ranking[0].Calories
+}
 
-	// Part 2
-	topThreeElves := elfCalories[len(elfCalories)-3:]
+// Part 2: how many calories do the top three elves carry in total?
+func solvePart2(ranking []Elf) int {
 	sum := 0
-	for _, calories := range topThreeElves {
-		sum += calories
+	for i := 0; i < topCount && i < len(ranking); i++ {
+		sum += ranking[i].Calories
+	}
+	return sum
+}
+
+// Solves puzzle parts, split out for benchmarking
+func solvePuzzle() (int, int, error) {
+	ranking, err := aoc.ReadFile(inputPath, readInput)
+	if err != nil {
+		return 0, 0, err
 	}
-	fmt.Println(sum)
+	return solvePart1(ranking), solvePart2(ranking), nil
+}
+
+// Registers puzzle parts with the aoc runner
+var puzzle = aoc.Puzzle[[]Elf, int, int]{
+	Year: 2022, Day: 1,
+	ReadInput:  readInput,
+	SolvePart1: aoc.NoError(solvePart1),
+	SolvePart2: aoc.NoError(solvePart2),
+	Labels: [2]string{
+		"Calories carried by the top elf",
+		"Calories carried by the top three elves",
+	},
+	Gen: &aoc.Generator{
+		Size: 250, SizeHelp: "elves",
+		Generate: generateInput,
+	},
+	Commands: map[string]aoc.Command{"stats": runStats, "top": runTop},
+}
+
+// Reads input, solves puzzle parts and logs answers
+func main() {
+	aoc.Main(puzzle)
 }
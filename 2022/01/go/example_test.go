@@ -0,0 +1,158 @@
+// Tests puzzle example data
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/erikzak/adventofcode/2022/aoc"
+)
+
+const testPath = "../test.txt"
+
+// Tests part 1 against example data
+func TestPart1Example(t *testing.T) {
+	want := 24000
+	ranking, err := aoc.ReadFile(testPath, readInput)
+	if err != nil {
+		t.Fatal(err)
+	}
+	answer1 := solvePart1(ranking)
+	if answer1 != want {
+		t.Fatalf(`solvePart1() = %v, want %v`, answer1, want)
+	}
+}
+
+// Tests part 2 against example data
+func TestPart2Example(t *testing.T) {
+	want := 45000
+	ranking, err := aoc.ReadFile(testPath, readInput)
+	if err != nil {
+		t.Fatal(err)
+	}
+	answer2 := solvePart2(ranking)
+	if answer2 != want {
+		t.Fatalf(`solvePart2() = %v, want %v`, answer2, want)
+	}
+}
+
+// Tests ranking elves of the example, with their positions in the input
+func TestTopElvesExample(t *testing.T) {
+	tests := []struct {
+		n    int
+		want []Elf
+	}{
+		{1, []Elf{{3, 24000}}},
+		{3, []Elf{{3, 24000}, {2, 11000}, {4, 10000}}},
+		{9, []Elf{{3, 24000}, {2, 11000}, {4, 10000}, {0, 6000}, {1, 4000}}},
+	}
+	for _, test := range tests {
+		ranking, err := aoc.ReadFile(testPath, func(r io.Reader) ([]Elf, error) {
+			return topElves(r, test.n)
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(ranking, test.want) {
+			t.Fatalf(`topElves(%d) = %v, want %v`, test.n, ranking, test.want)
+		}
+	}
+	// Ties go to the elf first in the input, and extra blank lines are ignored
+	ranking, err := topElves(strings.NewReader("5\r\n\r\n\r\n3\n2\n\n1\n4"), 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []Elf{{0, 5}, {1, 5}}; !reflect.DeepEqual(ranking, want) {
+		t.Fatalf(`topElves() with ties = %v, want %v`, ranking, want)
+	}
+	for _, input := range []string{"", "\n\n", "1\nx"} {
+		if _, err := topElves(strings.NewReader(input), 3); err == nil {
+			t.Fatalf(`topElves(%q) did not fail`, input)
+		}
+	}
+}
+
+// Tests inventory statistics of example data
+func TestStatsExample(t *testing.T) {
+	source, err := aoc.LoadSource(testPath, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	stats, err := readStats(source.Reader(), 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stats.Elves != 5 || stats.Items != 10 {
+		t.Fatalf(`readStats() = %d elves and %d items, want 5 and 10`, stats.Elves, stats.Items)
+	}
+	if want := (LargestItem{Elf: 4, Calories: 10000}); stats.LargestItem != want {
+		t.Fatalf(`readStats().LargestItem = %+v, want %+v`, stats.LargestItem, want)
+	}
+	want := Distribution{
+		Min: 4000, Max: 24000, Mean: 11000, Median: 10000,
+		Percentiles: []Percentile{{10, 4000}, {25, 6000}, {75, 11000}, {90, 24000}, {99, 24000}},
+	}
+	if !reflect.DeepEqual(stats.Calories, want) {
+		t.Fatalf(`readStats().Calories = %+v, want %+v`, stats.Calories, want)
+	}
+	if stats.ItemsPerElf.Mean != 2 || stats.ItemsPerElf.Median != 2 {
+		t.Fatalf(`readStats().ItemsPerElf = %+v, want mean and median 2`, stats.ItemsPerElf)
+	}
+	if want := []Bin{{4000, 14000, 4}, {14001, 24001, 1}}; !reflect.DeepEqual(stats.Histogram, want) {
+		t.Fatalf(`readStats().Histogram = %+v, want %+v`, stats.Histogram, want)
+	}
+}
+
+// Tests stats subcommand JSON output, and rejection of malformed input
+func TestRunStats(t *testing.T) {
+	input := "1000\n2000\n\n3000\n"
+	stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+	if code := runStats([]string{"-input", "-", "-format", "json"}, strings.NewReader(input), stdout, stderr); code != 0 {
+		t.Fatalf(`runStats() = %v, want 0: %s`, code, stderr)
+	}
+	var stats Stats
+	if err := json.Unmarshal(stdout.Bytes(), &stats); err != nil {
+		t.Fatal(err)
+	}
+	if stats.Elves != 2 || stats.Calories.Median != 3000 {
+		t.Fatalf(`runStats() = %+v, want 2 elves with median 3000`, stats)
+	}
+	stderr.Reset()
+	if code := runStats([]string{"-input", "-"}, strings.NewReader("1000\nabc\n"), io.Discard, stderr); code != 1 {
+		t.Fatalf(`runStats() of malformed input = %v, want 1`, code)
+	}
+	if !strings.Contains(stderr.String(), ":2:") {
+		t.Fatalf(`runStats() error = %q, want line 2`, stderr)
+	}
+}
+
+// Tests top subcommand output of example data, ranking more elves than the
+// puzzle
+func TestRunTop(t *testing.T) {
+	stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+	if code := runTop([]string{"-input", testPath, "-n", "4", "-format", "json"}, nil, stdout, stderr); code != 0 {
+		t.Fatalf(`runTop() = %v, want 0: %s`, code, stderr)
+	}
+	var ranking []Elf
+	if err := json.Unmarshal(stdout.Bytes(), &ranking); err != nil {
+		t.Fatal(err)
+	}
+	if want := []Elf{{3, 24000}, {2, 11000}, {4, 10000}, {0, 6000}}; !reflect.DeepEqual(ranking, want) {
+		t.Fatalf(`runTop() = %v, want %v`, ranking, want)
+	}
+	stdout.Reset()
+	if code := runTop([]string{"-input", testPath, "-n", "2"}, nil, stdout, stderr); code != 0 {
+		t.Fatalf(`runTop() = %v, want 0: %s`, code, stderr)
+	}
+	lines := strings.Split(strings.TrimSpace(stdout.String()), "\n")
+	if len(lines) != 3 || strings.Join(strings.Fields(lines[2]), " ") != "2 2 11000" {
+		t.Fatalf(`runTop() text = %q, want header and 2 elves, elf 2 second`, stdout)
+	}
+	if code := runTop([]string{"-n", "0"}, nil, io.Discard, io.Discard); code != 2 {
+		t.Fatalf(`runTop() with n 0 = %v, want 2`, code)
+	}
+}
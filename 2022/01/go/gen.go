@@ -0,0 +1,19 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"math/rand"
+)
+
+// Generates random input of size elves, each carrying 1 to 10 food items
+func generateInput(rng *rand.Rand, size int, w *bufio.Writer) {
+	for elf := 0; elf < size; elf++ {
+		if elf > 0 {
+			w.WriteString("\n")
+		}
+		for i := rng.Intn(10); i >= 0; i-- {
+			fmt.Fprintf(w, "%d\n", 1000+rng.Intn(59000))
+		}
+	}
+}
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"bytes"
+	"sort"
+	"testing"
+
+	"github.com/erikzak/adventofcode/2022/aoc"
+	"github.com/erikzak/adventofcode/2022/aoc/aoctest"
+)
+
+// Tests that generated input parses and solves
+func TestGeneratedInput(t *testing.T) {
+	aoctest.GeneratedInput(t, puzzle, readInput)
+}
+
+// Tests the streamed ranking against sorting all elves, on generated input
+func TestTopElvesGenerated(t *testing.T) {
+	buffer := &bytes.Buffer{}
+	if err := puzzle.Gen.Write(buffer, 1, 1000); err != nil {
+		t.Fatal(err)
+	}
+	input := aoc.NewInput("", buffer.String())
+	all := []Elf{}
+	for i, block := range input.Blocks() {
+		elf := Elf{Index: i}
+		for j, foodItem := range block.Lines {
+			calories, err := input.Atoi(block.Start+j, foodItem)
+			if err != nil {
+				t.Fatal(err)
+			}
+			elf.Calories += calories
+		}
+		all = append(all, elf)
+	}
+	sort.SliceStable(all, func(i, j int) bool {
+		return all[i].Calories > all[j].Calories
+	})
+	ranking, err := topElves(bytes.NewReader(buffer.Bytes()), 50)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, elf := range ranking {
+		if elf != all[i] {
+			t.Fatalf(`topElves() rank %d = %v, want %v`, i, elf, all[i])
+		}
+	}
+}
@@ -0,0 +1,211 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/erikzak/adventofcode/2022/aoc"
+)
+
+// Percentiles of per-elf totals in the stats report
+var statsPercentiles = []int{10, 25, 75, 90, 99}
+
+// Widest histogram bar, in characters
+const histogramWidth = 40
+
+// Statistics of a calorie inventory
+type Stats struct {
+	Elves       int          `json:"elves"`
+	Items       int          `json:"items"`
+	Calories    Distribution `json:"calories"`      // Total calories per elf
+	ItemsPerElf Distribution `json:"items_per_elf"` // Food items per elf
+	LargestItem LargestItem  `json:"largest_item"`
+	Histogram   []Bin        `json:"histogram"` // Elves by total calories
+}
+
+// Distribution of a value over the elves
+type Distribution struct {
+	Min         int          `json:"min"`
+	Max         int          `json:"max"`
+	Mean        float64      `json:"mean"`
+	Median      float64      `json:"median"`
+	Percentiles []Percentile `json:"percentiles"`
+}
+
+// Value below or at which the given percent of elves fall, by nearest rank
+type Percentile struct {
+	Percent int `json:"percent"`
+	Value   int `json:"value"`
+}
+
+// Single food item carrying the most calories, and the elf carrying it
+type LargestItem struct {
+	Elf      int `json:"elf"`
+	Calories int `json:"calories"`
+}
+
+// Histogram bin of elves carrying From to To calories, both included
+type Bin struct {
+	From  int `json:"from"`
+	To    int `json:"to"`
+	Elves int `json:"elves"`
+}
+
+// Reads inventory and returns its statistics, with elves binned into the
+// given number of equally wide histogram bins
+func readStats(r io.Reader, bins int) (Stats, error) {
+	stats := Stats{}
+	totals, itemCounts := []int{}, []int{}
+	err := scanInventories(r, func(inventory inventory) {
+		totals = append(totals, inventory.elf.Calories)
+		itemCounts = append(itemCounts, inventory.items)
+		stats.Items += inventory.items
+		if stats.Elves == 0 || inventory.largest > stats.LargestItem.Calories {
+			stats.LargestItem = LargestItem{Elf: inventory.elf.Index, Calories: inventory.largest}
+		}
+		stats.Elves++
+	})
+	if err != nil {
+		return stats, err
+	}
+	stats.Calories = distribution(totals)
+	stats.ItemsPerElf = distribution(itemCounts)
+	stats.Histogram = histogram(totals, stats.Calories.Min, stats.Calories.Max, bins)
+	return stats, nil
+}
+
+// Returns distribution of values. Values are sorted in place
+func distribution(values []int) Distribution {
+	result := Distribution{Percentiles: []Percentile{}}
+	if len(values) == 0 {
+		return result
+	}
+	sort.Ints(values)
+	n := len(values)
+	result.Min, result.Max = values[0], values[n-1]
+	sum := 0
+	for _, value := range values {
+		sum += value
+	}
+	result.Mean = float64(sum) / float64(n)
+	result.Median = float64(values[n/2])
+	if n%2 == 0 {
+		result.Median = float64(values[n/2-1]+values[n/2]) / 2
+	}
+	for _, percent := range statsPercentiles {
+		// Nearest rank, rounded up
+		rank := (percent*n + 99) / 100
+		if rank < 1 {
+			rank = 1
+		}
+		result.Percentiles = append(result.Percentiles, Percentile{percent, values[rank-1]})
+	}
+	return result
+}
+
+// Returns values counted in bins of equal width from lowest to highest
+func histogram(values []int, lowest int, highest int, bins int) []Bin {
+	if len(values) == 0 || bins < 1 {
+		return []Bin{}
+	}
+	// Ceiling of range over bins, so the last bin reaches highest
+	width := (highest - lowest + bins) / bins
+	if width < 1 {
+		width = 1
+	}
+	result := []Bin{}
+	for from := lowest; from <= highest; from += width {
+		result = append(result, Bin{From: from, To: from + width - 1})
+	}
+	for _, value := range values {
+		result[(value-lowest)/width].Elves++
+	}
+	return result
+}
+
+// Writes stats as a human-readable report
+func writeStats(w io.Writer, stats Stats) error {
+	builder := strings.Builder{}
+	fmt.Fprintf(&builder, "Elves:          %d\n", stats.Elves)
+	fmt.Fprintf(&builder, "Food items:     %d\n", stats.Items)
+	fmt.Fprintf(&builder, "Largest item:   %d calories, carried by elf %d\n", stats.LargestItem.Calories, stats.LargestItem.Elf)
+	for _, section := range []struct {
+		title        string
+		distribution Distribution
+	}{
+		{"Calories per elf", stats.Calories},
+		{"Items per elf", stats.ItemsPerElf},
+	} {
+		d := section.distribution
+		fmt.Fprintf(&builder, "\n%s:\n", section.title)
+		fmt.Fprintf(&builder, "  min %d, max %d, mean %.1f, median %.1f\n", d.Min, d.Max, d.Mean, d.Median)
+		percentiles := []string{}
+		for _, p := range d.Percentiles {
+			percentiles = append(percentiles, fmt.Sprintf("p%d %d", p.Percent, p.Value))
+		}
+		fmt.Fprintf(&builder, "  %s\n", strings.Join(percentiles, ", "))
+	}
+	if len(stats.Histogram) > 0 {
+		builder.WriteString("\nElves by calories carried:\n")
+		most := 0
+		for _, bin := range stats.Histogram {
+			if bin.Elves > most {
+				most = bin.Elves
+			}
+		}
+		labelWidth := len(fmt.Sprint(stats.Histogram[len(stats.Histogram)-1].To))
+		for _, bin := range stats.Histogram {
+			bar := strings.Repeat("#", (bin.Elves*histogramWidth+most-1)/most)
+			fmt.Fprintf(&builder, "  %*d-%-*d %-*s %d\n", labelWidth, bin.From, labelWidth, bin.To, histogramWidth, bar, bin.Elves)
+		}
+	}
+	_, err := io.WriteString(w, builder.String())
+	return err
+}
+
+// Handles the stats subcommand, reporting on the inventory as text or JSON.
+// Returns exit code
+func runStats(args []string, stdin io.Reader, stdout io.Writer, stderr io.Writer) int {
+	flags := flag.NewFlagSet("stats", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	inputPath := flags.String("input", aoc.DefaultInputPath, `puzzle input file, "-" for stdin`)
+	format := flags.String("format", aoc.FormatText, "output format: text or json")
+	bins := flags.Int("bins", 10, "number of histogram bins")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if *format != aoc.FormatText && *format != aoc.FormatJSON {
+		fmt.Fprintf(stderr, "invalid format %q, want %s or %s\n", *format, aoc.FormatText, aoc.FormatJSON)
+		return 2
+	}
+	if *bins < 1 {
+		fmt.Fprintf(stderr, "invalid bins %d, want at least 1\n", *bins)
+		return 2
+	}
+	source, err := aoc.LoadSource(*inputPath, stdin)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return 1
+	}
+	stats, err := readStats(source.Reader(), *bins)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return 1
+	}
+	if *format == aoc.FormatJSON {
+		encoder := json.NewEncoder(stdout)
+		encoder.SetIndent("", "  ")
+		err = encoder.Encode(stats)
+	} else {
+		err = writeStats(stdout, stats)
+	}
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return 1
+	}
+	return 0
+}
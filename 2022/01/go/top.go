@@ -0,0 +1,64 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	"github.com/erikzak/adventofcode/2022/aoc"
+)
+
+// Writes ranking as a table of rank, elf position in the input and calories
+func writeRanking(w io.Writer, ranking []Elf) error {
+	table := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(table, "Rank\tElf\tCalories")
+	for i, elf := range ranking {
+		fmt.Fprintf(table, "%d\t%d\t%d\n", i+1, elf.Index, elf.Calories)
+	}
+	return table.Flush()
+}
+
+// Handles the top subcommand, ranking the n elves carrying the most calories
+// as text or JSON. Returns exit code
+func runTop(args []string, stdin io.Reader, stdout io.Writer, stderr io.Writer) int {
+	flags := flag.NewFlagSet("top", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	inputPath := flags.String("input", aoc.DefaultInputPath, `puzzle input file, "-" for stdin`)
+	format := flags.String("format", aoc.FormatText, "output format: text or json")
+	n := flags.Int("n", topCount, "number of elves to rank")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if *format != aoc.FormatText && *format != aoc.FormatJSON {
+		fmt.Fprintf(stderr, "invalid format %q, want %s or %s\n", *format, aoc.FormatText, aoc.FormatJSON)
+		return 2
+	}
+	if *n < 1 {
+		fmt.Fprintf(stderr, "invalid n %d, want at least 1\n", *n)
+		return 2
+	}
+	source, err := aoc.LoadSource(*inputPath, stdin)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return 1
+	}
+	ranking, err := topElves(source.Reader(), *n)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return 1
+	}
+	if *format == aoc.FormatJSON {
+		encoder := json.NewEncoder(stdout)
+		encoder.SetIndent("", "  ")
+		err = encoder.Encode(ranking)
+	} else {
+		err = writeRanking(stdout, ranking)
+	}
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return 1
+	}
+	return 0
+}
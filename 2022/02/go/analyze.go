@@ -0,0 +1,271 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/erikzak/adventofcode/2022/aoc"
+)
+
+// Ways to read the second column of the guide
+const (
+	mappingShape   = "shape"
+	mappingOutcome = "outcome"
+)
+
+// Scores of every reading of a strategy guide
+type Analysis struct {
+	Rules    string    `json:"rules"`
+	Rounds   int       `json:"rounds"`
+	Best     int       `json:"best"`     // Best shape played every round
+	Worst    int       `json:"worst"`    // Worst shape played every round
+	Mappings []Mapping `json:"mappings"` // By score, best first
+}
+
+// Most mappings analyzed. Each id of the second column multiplies the
+// mappings by the number of shapes, so rules with many shapes are refused
+const maxMappings = 100000
+
+// Reading of the second column of the guide, as shapes or as outcomes
+type Mapping struct {
+	Kind  string            `json:"kind"` // "shape" or "outcome"
+	Ids   map[string]string `json:"ids"`  // Shape or outcome name by id
+	Score int               `json:"score"`
+	Guide bool              `json:"guide,omitempty"` // Reading of the puzzle, part 1 or 2
+}
+
+// Returns ids and names of mapping, like "X=rock Y=paper Z=scissors"
+func (mapping Mapping) String() string {
+	ids := make([]string, 0, len(mapping.Ids))
+	for id := range mapping.Ids {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	pairs := make([]string, len(ids))
+	for i, id := range ids {
+		pairs[i] = id + "=" + mapping.Ids[id]
+	}
+	return strings.Join(pairs, " ")
+}
+
+// Scores the guide for the best and worst play against the opponent column,
+// and for every mapping of the ids in the second column to shapes and to
+// outcomes. Mappings are not required to be one-to-one
+func analyzeGuide(rules *Rules, rounds []string) (Analysis, error) {
+	analysis := Analysis{Rules: rules.name, Rounds: len(rounds)}
+	// Rounds counted by opponent shape and second column id, as the score of
+	// a mapping only depends on those
+	counts := make(map[*Shape]map[string]int)
+	seen := make(map[string]struct{})
+	ids := []string{}
+	for _, roundIds := range rounds {
+		shapeIds := strings.Split(roundIds, " ")
+		opponentShape, err := getShape(shapeIds[0], rules.opponentShapes)
+		if err != nil {
+			return analysis, err
+		}
+		if counts[opponentShape] == nil {
+			counts[opponentShape] = make(map[string]int)
+		}
+		if _, ok := seen[shapeIds[1]]; !ok {
+			seen[shapeIds[1]] = struct{}{}
+			ids = append(ids, shapeIds[1])
+		}
+		counts[opponentShape][shapeIds[1]]++
+	}
+	sort.Strings(ids)
+
+	// Scores of each shape and outcome against each opponent shape
+	shapeScores := make(map[*Shape]map[string]int)
+	outcomeScores := make(map[*Shape]map[string]int)
+	for opponentShape, idCounts := range counts {
+		shapeScores[opponentShape] = make(map[string]int)
+		best, worst := 0, 0
+		for i, shape := range rules.shapes {
+			score := newRound(*shape, *opponentShape, rules).score
+			shapeScores[opponentShape][shape.name] = score
+			if i == 0 || score > best {
+				best = score
+			}
+			if i == 0 || score < worst {
+				worst = score
+			}
+		}
+		outcomeScores[opponentShape] = make(map[string]int)
+		for _, outcome := range outcomes {
+			shape, err := rules.shapeFor(opponentShape, outcome)
+			if err != nil {
+				return analysis, err
+			}
+			outcomeScores[opponentShape][outcome] = newRound(*shape, *opponentShape, rules).score
+		}
+		for _, count := range idCounts {
+			analysis.Best += best * count
+			analysis.Worst += worst * count
+		}
+	}
+
+	shapeNames := make([]string, len(rules.shapes))
+	for i, shape := range rules.shapes {
+		shapeNames[i] = shape.name
+	}
+	if !withinMappings(len(ids), len(shapeNames), len(outcomes)) {
+		return analysis, fmt.Errorf("%d ids in the second column with %d shapes give over %d mappings",
+			len(ids), len(shapeNames), maxMappings)
+	}
+	for _, reading := range []struct {
+		kind   string
+		names  []string
+		scores map[*Shape]map[string]int
+		guide  func(id string) string
+	}{
+		{mappingShape, shapeNames, shapeScores, func(id string) string {
+			if shape, ok := rules.playerShapes[id]; ok {
+				return shape.name
+			}
+			return ""
+		}},
+		{mappingOutcome, outcomes, outcomeScores, func(id string) string { return rules.outcomeIds[id] }},
+	} {
+		eachMapping(ids, reading.names, func(mapped map[string]string) {
+			mapping := Mapping{Kind: reading.kind, Ids: mapped, Guide: true}
+			for opponentShape, idCounts := range counts {
+				for id, count := range idCounts {
+					mapping.Score += reading.scores[opponentShape][mapped[id]] * count
+				}
+			}
+			for id, name := range mapped {
+				if reading.guide(id) != name {
+					mapping.Guide = false
+				}
+			}
+			analysis.Mappings = append(analysis.Mappings, mapping)
+		})
+	}
+	sort.SliceStable(analysis.Mappings, func(i, j int) bool {
+		return analysis.Mappings[i].Score > analysis.Mappings[j].Score
+	})
+	return analysis, nil
+}
+
+// Checks if mapping ids to each number of names gives at most maxMappings
+// in total. Stops counting once over, before the count can overflow
+func withinMappings(ids int, names ...int) bool {
+	total := 0
+	for _, n := range names {
+		count := 1
+		for i := 0; i < ids; i++ {
+			count *= n
+			if total+count > maxMappings {
+				return false
+			}
+		}
+		total += count
+	}
+	return true
+}
+
+// Calls visit with every mapping of ids to names, in order of names like an
+// odometer. The map passed to visit is its own
+func eachMapping(ids []string, names []string, visit func(mapped map[string]string)) {
+	choices := make([]int, len(ids))
+	for {
+		mapped := make(map[string]string, len(ids))
+		for i, id := range ids {
+			mapped[id] = names[choices[i]]
+		}
+		visit(mapped)
+		// Advance the last id first, carrying over to the ones before it
+		i := len(ids) - 1
+		for ; i >= 0; i-- {
+			choices[i]++
+			if choices[i] < len(names) {
+				break
+			}
+			choices[i] = 0
+		}
+		if i < 0 {
+			return
+		}
+	}
+}
+
+// Writes analysis as a ranked table of mappings, at most top rows if not 0.
+// Mappings read by the puzzle are marked with *
+func writeAnalysis(w io.Writer, analysis Analysis, top int) error {
+	fmt.Fprintf(w, "%s, %d rounds\n", analysis.Rules, analysis.Rounds)
+	fmt.Fprintf(w, "Best possible score:  %d\n", analysis.Best)
+	fmt.Fprintf(w, "Worst possible score: %d\n\n", analysis.Worst)
+	table := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(table, "Rank\tAs\tMapping\tScore\tOf best")
+	for i, mapping := range analysis.Mappings {
+		if top > 0 && i >= top {
+			break
+		}
+		rank := fmt.Sprint(i + 1)
+		if mapping.Guide {
+			rank += "*"
+		}
+		ofBest := 0.0
+		if analysis.Best != 0 {
+			ofBest = 100 * float64(mapping.Score) / float64(analysis.Best)
+		}
+		fmt.Fprintf(table, "%s\t%s\t%v\t%d\t%.1f%%\n", rank, mapping.Kind, mapping, mapping.Score, ofBest)
+	}
+	return table.Flush()
+}
+
+// Handles the analyze subcommand, scoring every reading of the guide as text
+// or JSON. Returns exit code
+func runAnalyze(args []string, stdin io.Reader, stdout io.Writer, stderr io.Writer) int {
+	flags := flag.NewFlagSet("analyze", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	rulesPath := flags.String("rules", "", "JSON rules config, default rock, paper, scissors")
+	inputPath := flags.String("input", aoc.DefaultInputPath, `puzzle input file, "-" for stdin`)
+	format := flags.String("format", aoc.FormatText, "output format: text or json")
+	top := flags.Int("top", 0, "number of mappings in the text table, 0 for all")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if *format != aoc.FormatText && *format != aoc.FormatJSON {
+		fmt.Fprintf(stderr, "invalid format %q, want %s or %s\n", *format, aoc.FormatText, aoc.FormatJSON)
+		return 2
+	}
+	rules, err := readRules(*rulesPath)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return 1
+	}
+	source, err := aoc.LoadSource(*inputPath, stdin)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return 1
+	}
+	rounds, err := rules.readInput(source.Reader())
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return 1
+	}
+	analysis, err := analyzeGuide(rules, rounds)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return 1
+	}
+	if *format == aoc.FormatJSON {
+		encoder := json.NewEncoder(stdout)
+		encoder.SetIndent("", "  ")
+		err = encoder.Encode(analysis)
+	} else {
+		err = writeAnalysis(stdout, analysis, *top)
+	}
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/erikzak/adventofcode/2022/aoc"
+)
+
+// Tests analysis of example data against the puzzle answers
+func TestAnalyzeGuideExample(t *testing.T) {
+	rounds, err := aoc.ReadFile(testPath, readInput)
+	if err != nil {
+		t.Fatal(err)
+	}
+	analysis, err := analyzeGuide(defaultRules, rounds)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if analysis.Best != 24 || analysis.Worst != 6 {
+		t.Fatalf(`analyzeGuide() best, worst = %v, %v, want 24, 6`, analysis.Best, analysis.Worst)
+	}
+	// Three ids mapped to three shapes or three outcomes
+	if len(analysis.Mappings) != 2*27 {
+		t.Fatalf(`analyzeGuide() has %v mappings, want 54`, len(analysis.Mappings))
+	}
+	if best := analysis.Mappings[0]; best.Score != 24 || best.String() != "X=scissors Y=paper Z=rock" {
+		t.Fatalf(`analyzeGuide() best mapping = %v scoring %v, want X=scissors Y=paper Z=rock scoring 24`, best, best.Score)
+	}
+	guides := map[string]int{}
+	for i, mapping := range analysis.Mappings {
+		if i > 0 && mapping.Score > analysis.Mappings[i-1].Score {
+			t.Fatalf(`analyzeGuide() mappings not ranked by score at %d`, i)
+		}
+		if mapping.Guide {
+			guides[mapping.Kind] = mapping.Score
+		}
+	}
+	if want := map[string]int{mappingShape: 15, mappingOutcome: 12}; !reflect.DeepEqual(guides, want) {
+		t.Fatalf(`analyzeGuide() guide scores = %v, want %v`, guides, want)
+	}
+}
+
+// Tests analyze subcommand JSON output with a rules config
+func TestRunAnalyze(t *testing.T) {
+	stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+	args := []string{"-rules", "rules/rpsls.json", "-input", "-", "-format", "json"}
+	if code := runAnalyze(args, strings.NewReader("A Y\nE X\n"), stdout, stderr); code != 0 {
+		t.Fatalf(`runAnalyze() = %v, want 0: %s`, code, stderr)
+	}
+	var analysis Analysis
+	if err := json.Unmarshal(stdout.Bytes(), &analysis); err != nil {
+		t.Fatal(err)
+	}
+	// Spock beats rock for 11 points, and lizard beats spock for 10
+	if analysis.Best != 11+10 || len(analysis.Mappings) != 5*5+3*3 {
+		t.Fatalf(`runAnalyze() best = %v with %v mappings, want 21 with 34`, analysis.Best, len(analysis.Mappings))
+	}
+}
+
+// Tests that guides with too many mappings to analyze are refused
+func TestAnalyzeGuideTooManyMappings(t *testing.T) {
+	rounds := []string{}
+	for id := 0; id < 20; id++ {
+		rounds = append(rounds, fmt.Sprintf("A %d", id))
+	}
+	_, err := analyzeGuide(defaultRules, rounds)
+	if err == nil || !strings.Contains(err.Error(), "20 ids") {
+		t.Fatalf(`analyzeGuide() error = %v, want too many mappings`, err)
+	}
+	if !withinMappings(9, 3, 3) || withinMappings(10, 3, 3) {
+		t.Fatalf(`withinMappings() for 3 names, want 9 ids within %d and 10 over`, maxMappings)
+	}
+}
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/erikzak/adventofcode/2022/aoc"
+)
+
+// Benchmark full solve
+func BenchmarkSolvePuzzle(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		if _, _, err := solvePuzzle(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// Benchmark input parsing
+func BenchmarkReadInput(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		if _, err := aoc.ReadFile(inputPath, readInput); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// Benchmark part 1
+func BenchmarkSolvePart1(b *testing.B) {
+	rounds, err := aoc.ReadFile(inputPath, readInput)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := solvePart1(rounds); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// Benchmark part 2
+func BenchmarkSolvePart2(b *testing.B) {
+	rounds, err := aoc.ReadFile(inputPath, readInput)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := solvePart2(rounds); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
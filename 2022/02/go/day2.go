@@ -4,30 +4,26 @@ package main
 
 import (
 	"fmt"
-	"log"
-	"os"
+	"io"
 	"strings"
+
+	"github.com/erikzak/adventofcode/2022/aoc"
 )
 
 const inputPath = "../input.txt"
 
-func check(err error) {
-	if err != nil {
-		log.Panic(err)
-	}
-}
-
 // Super for shapes, subclassed into rock, paper, scissors etc.
 type Shape struct {
-	ids    []string
-	name   string
-	points int
-	beats  map[string]struct{}
+	opponentId string // Id of shape in the first column of the guide
+	playerId   string // Id of shape in the second column of the guide
+	name       string
+	points     int
+	beats      map[string]struct{}
 }
 
 // Shape constructor
-func newShape(ids []string, name string, points int) *Shape {
-	shape := Shape{ids: ids, name: name, points: points}
+func newShape(opponentId string, playerId string, name string, points int) *Shape {
+	shape := Shape{opponentId: opponentId, playerId: playerId, name: name, points: points}
 	shape.beats = make(map[string]struct{})
 	return &shape
 }
@@ -36,13 +32,14 @@ func newShape(ids []string, name string, points int) *Shape {
 type Round struct {
 	playerShape   Shape
 	opponentShape Shape
+	rules         *Rules
 	outcome       string // "win"/"draw"/"loss"
 	score         int
 }
 
 // Constructor for rounds. Calculates outcome and points based on shapes
-func newRound(playerShape Shape, opponentShape Shape) *Round {
-	round := Round{playerShape: playerShape, opponentShape: opponentShape}
+func newRound(playerShape Shape, opponentShape Shape, rules *Rules) *Round {
+	round := Round{playerShape: playerShape, opponentShape: opponentShape, rules: rules}
 	round.outcome = round.getOutcome()
 	round.score = round.calculateScore()
 	return &round
@@ -51,136 +48,155 @@ func newRound(playerShape Shape, opponentShape Shape) *Round {
 // Calculates outcome based on player and opponent shapes
 func (round Round) getOutcome() string {
 	if _, ok := round.playerShape.beats[round.opponentShape.name]; ok {
-		return "win"
+		return outcomeWin
 	}
 	if _, ok := round.opponentShape.beats[round.playerShape.name]; ok {
-		return "loss"
+		return outcomeLoss
 	}
-	return "draw"
+	return outcomeDraw
 }
 
 // Calculates round score based on outcome and player shape
 func (round Round) calculateScore() (score int) {
-	score = round.playerShape.points
-	if round.outcome == "win" {
-		score += 6
-	} else if round.outcome == "draw" {
-		score += 3
-	}
-	return score
+	return round.playerShape.points + round.rules.outcomePoints[round.outcome]
 }
 
-// Parses puzzle input from txt file.
+// Parses puzzle input from reader with the given rules.
 // Returns a list of strings representing shapes played
-func readInput(inputPath string) (rounds []string) {
-	inputBytes, err := os.ReadFile(inputPath)
-	check(err)
-	input := string(inputBytes)
-
-	for _, line := range strings.Split(input, "\n") {
-		rounds = append(rounds, strings.TrimSpace(line))
+func (rules *Rules) readInput(r io.Reader) (rounds []string, err error) {
+	input, err := aoc.ReadFrom(r)
+	if err != nil {
+		return nil, err
+	}
+	for i, line := range input.Lines {
+		shapeIds := strings.Fields(line)
+		if len(shapeIds) != 2 {
+			return nil, input.Errorf(i, "", "expected opponent and player shape ids")
+		}
+		if _, ok := rules.opponentShapes[shapeIds[0]]; !ok {
+			return nil, input.Errorf(i, shapeIds[0], "invalid opponent shape id %q", shapeIds[0])
+		}
+		// Second column is a shape in part 1 and an outcome in part 2
+		_, isShape := rules.playerShapes[shapeIds[1]]
+		_, isOutcome := rules.outcomeIds[shapeIds[1]]
+		if !isShape && !isOutcome {
+			return nil, input.Errorf(i, shapeIds[1], "invalid player shape id %q", shapeIds[1])
+		}
+		rounds = append(rounds, strings.Join(shapeIds, " "))
 	}
-	return rounds
+	return rounds, nil
 }
 
-// Inits shape types and rules. Returns a map of shape names pointing to shape structs
-// Needs rework to init based on some kind of config if shapes unknown
-func initShapes() (shapes map[string]*Shape) {
-	shapes = make(map[string]*Shape)
-
-	rockIds := []string{"A", "X"}
-	rock := newShape(rockIds, "rock", 1)
-	paperIds := []string{"B", "Y"}
-	paper := newShape(paperIds, "paper", 2)
-	scissorIds := []string{"C", "Z"}
-	scissors := newShape(scissorIds, "scissors", 3)
-
-	// The holy trinity
-	rock.beats[scissors.name] = struct{}{}
-	paper.beats[rock.name] = struct{}{}
-	scissors.beats[paper.name] = struct{}{}
-
-	shapes[rock.name] = rock
-	shapes[paper.name] = paper
-	shapes[scissors.name] = scissors
-	return shapes
+// Parses puzzle input from reader with the default rules
+func readInput(r io.Reader) ([]string, error) {
+	return defaultRules.readInput(r)
 }
 
 // Simulates a series of rounds and returns the score
-func simulateRounds(rounds []string, shapes map[string]*Shape) (totalScore int) {
+func (rules *Rules) simulateRounds(rounds []string) (totalScore int, err error) {
 	totalScore = 0
 	for _, roundIds := range rounds {
 		shapeIds := strings.Split(roundIds, " ")
-		opponentShape, err := getShape(shapeIds[0], shapes)
-		check(err)
-		playerShape, err := getShape(shapeIds[1], shapes)
-		check(err)
-		round := newRound(*playerShape, *opponentShape)
+		opponentShape, err := getShape(shapeIds[0], rules.opponentShapes)
+		if err != nil {
+			return 0, err
+		}
+		playerShape, err := getShape(shapeIds[1], rules.playerShapes)
+		if err != nil {
+			return 0, err
+		}
+		round := newRound(*playerShape, *opponentShape, rules)
 		totalScore += round.score
 	}
-	return totalScore
+	return totalScore, nil
 }
 
-// Gets shape from id and available shapes
+// Gets shape from id and shapes of the id's column
 func getShape(id string, shapes map[string]*Shape) (*Shape, error) {
-	for _, shape := range shapes {
-		// I think I might hate Go
-		for _, shapeId := range shape.ids {
-			if id == shapeId {
-				return shape, nil
-			}
-		}
+	shape, ok := shapes[id]
+	if !ok {
+		return &Shape{}, fmt.Errorf("invalid shape id: " + id)
 	}
-	return &Shape{}, fmt.Errorf("invalid shape id: " + id)
+	return shape, nil
 }
 
-// Simulates a series of rounds where we choose the winning strategy, then returns the score
-func executeStrategy(rounds []string, shapes map[string]*Shape) (totalScore int) {
+// Simulates a series of rounds where we choose the shape giving the outcome, then returns the score
+func (rules *Rules) executeStrategy(rounds []string) (totalScore int, err error) {
 	totalScore = 0
-	var playerShape *Shape
 	for _, roundIds := range rounds {
 		shapeIds := strings.Split(roundIds, " ")
-		opponentShape, err := getShape(shapeIds[0], shapes)
-		check(err)
-		playerOutcome := shapeIds[1]
-
-		// Too late for this shit, hardcoding
-		if playerOutcome == "X" {
-			if opponentShape.name == "rock" {
-				playerShape = shapes["scissors"]
-			} else if opponentShape.name == "paper" {
-				playerShape = shapes["rock"]
-			} else {
-				playerShape = shapes["paper"]
-			}
-		} else if playerOutcome == "Y" {
-			playerShape = opponentShape
-		} else {
-			if opponentShape.name == "rock" {
-				playerShape = shapes["paper"]
-			} else if opponentShape.name == "paper" {
-				playerShape = shapes["scissors"]
-			} else {
-				playerShape = shapes["rock"]
-			}
+		opponentShape, err := getShape(shapeIds[0], rules.opponentShapes)
+		if err != nil {
+			return 0, err
 		}
-
-		round := newRound(*playerShape, *opponentShape)
+		playerOutcome, ok := rules.outcomeIds[shapeIds[1]]
+		if !ok {
+			return 0, fmt.Errorf("invalid outcome id: " + shapeIds[1])
+		}
+		playerShape, err := rules.shapeFor(opponentShape, playerOutcome)
+		if err != nil {
+			return 0, err
+		}
+		round := newRound(*playerShape, *opponentShape, rules)
 		totalScore += round.score
 	}
-	return totalScore
+	return totalScore, nil
 }
 
-// Parses input as txt into list of rounds, then simulates points
-func main() {
-	rounds := readInput(inputPath)
-	shapes := initShapes()
+// Part 1: total score if the second column is the shape to play
+func solvePart1(rounds []string) (int, error) {
+	return defaultRules.simulateRounds(rounds)
+}
+
+// Part 2: total score if the second column is the outcome to aim for
+func solvePart2(rounds []string) (int, error) {
+	return defaultRules.executeStrategy(rounds)
+}
+
+// Solves puzzle parts, split out for benchmarking
+func solvePuzzle() (int, int, error) {
+	rounds, err := aoc.ReadFile(inputPath, readInput)
+	if err != nil {
+		return 0, 0, err
+	}
+	answer1, err := solvePart1(rounds)
+	if err != nil {
+		return 0, 0, err
+	}
+	answer2, err := solvePart2(rounds)
+	if err != nil {
+		return 0, 0, err
+	}
+	return answer1, answer2, nil
+}
 
-	// Part 1
-	points := simulateRounds(rounds, shapes)
-	fmt.Println(points)
+// Registers puzzle parts with the aoc runner
+var puzzle = newPuzzle(defaultRules)
+
+// Returns puzzle played by the given rules
+func newPuzzle(rules *Rules) aoc.Puzzle[[]string, int, int] {
+	return aoc.Puzzle[[]string, int, int]{
+		Year: 2022, Day: 2,
+		ReadInput:  rules.readInput,
+		SolvePart1: aoc.NoContext(rules.simulateRounds),
+		SolvePart2: aoc.NoContext(rules.executeStrategy),
+		Labels: [2]string{
+			"Total score playing the guide as shapes",
+			"Total score playing the guide as outcomes",
+		},
+		Gen: &aoc.Generator{
+			Size: 2500, SizeHelp: "rounds",
+			Generate: generateInput,
+		},
+		Commands: map[string]aoc.Command{
+			"play":       runPlay,
+			"analyze":    runAnalyze,
+			"tournament": runTournament,
+		},
+	}
+}
 
-	// Part 2
-	points = executeStrategy(rounds, shapes)
-	fmt.Println(points)
+// Reads input, solves puzzle parts and logs answers
+func main() {
+	aoc.Main(puzzle)
 }
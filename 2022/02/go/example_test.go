@@ -0,0 +1,42 @@
+// Tests puzzle example data
+package main
+
+import (
+	"testing"
+
+	"github.com/erikzak/adventofcode/2022/aoc"
+)
+
+const testPath = "../test.txt"
+
+// Tests part 1 against example data
+func TestPart1Example(t *testing.T) {
+	want := 15
+	rounds, err := aoc.ReadFile(testPath, readInput)
+	if err != nil {
+		t.Fatal(err)
+	}
+	answer1, err := solvePart1(rounds)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if answer1 != want {
+		t.Fatalf(`solvePart1() = %v, want %v`, answer1, want)
+	}
+}
+
+// Tests part 2 against example data
+func TestPart2Example(t *testing.T) {
+	want := 12
+	rounds, err := aoc.ReadFile(testPath, readInput)
+	if err != nil {
+		t.Fatal(err)
+	}
+	answer2, err := solvePart2(rounds)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if answer2 != want {
+		t.Fatalf(`solvePart2() = %v, want %v`, answer2, want)
+	}
+}
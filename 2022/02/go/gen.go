@@ -0,0 +1,14 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"math/rand"
+)
+
+// Generates random strategy guide of size rounds
+func generateInput(rng *rand.Rand, size int, w *bufio.Writer) {
+	for i := 0; i < size; i++ {
+		fmt.Fprintf(w, "%c %c\n", 'A'+rng.Intn(3), 'X'+rng.Intn(3))
+	}
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+
+	"github.com/erikzak/adventofcode/2022/aoc"
+)
+
+// Handles the play subcommand, solving the puzzle with the rules of a config
+// file, like rules/rpsls.json. Returns exit code
+func runPlay(args []string, stdin io.Reader, stdout io.Writer, stderr io.Writer) int {
+	flags := flag.NewFlagSet("play", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	rulesPath := flags.String("rules", "", "JSON rules config, default rock, paper, scissors")
+	part := flags.Int("part", 0, "puzzle part to solve, 0 for both")
+	inputPath := flags.String("input", aoc.DefaultInputPath, `puzzle input file, "-" for stdin`)
+	format := flags.String("format", aoc.FormatText, "output format: text, json or csv")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if err := aoc.ValidFormat(*format); err != nil {
+		fmt.Fprintln(stderr, err)
+		return 2
+	}
+	rules, err := readRules(*rulesPath)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return 1
+	}
+	source, err := aoc.LoadSource(*inputPath, stdin)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return 1
+	}
+	parts := []int{1, 2}
+	if *part != 0 {
+		parts = []int{*part}
+	}
+
+	var encoder aoc.ResultEncoder
+	if *format != aoc.FormatText {
+		encoder, _ = aoc.NewResultEncoder(*format, stdout)
+	} else {
+		fmt.Fprintf(stdout, "%s\n", rules.name)
+	}
+	played := newPuzzle(rules)
+	exitCode := 0
+	for _, p := range parts {
+		result := aoc.SolvePart(context.Background(), played, p, source)
+		if result.Error != "" {
+			exitCode = 1
+		}
+		if encoder != nil {
+			err = encoder.Encode(result)
+		} else if result.Error != "" {
+			_, err = fmt.Fprintf(stdout, "%s: error: %s\n", played.Label(p), result.Error)
+		} else {
+			_, err = fmt.Fprintf(stdout, "%s: %s\n", played.Label(p), result.Answer)
+		}
+		if err != nil {
+			fmt.Fprintln(stderr, err)
+			return 1
+		}
+	}
+	if encoder != nil {
+		if err := encoder.Flush(); err != nil {
+			fmt.Fprintln(stderr, err)
+			return 1
+		}
+	}
+	return exitCode
+}
@@ -0,0 +1,227 @@
+package main
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Round outcomes, from the player's point of view
+const (
+	outcomeWin  = "win"
+	outcomeDraw = "draw"
+	outcomeLoss = "loss"
+)
+
+// Outcomes in the order they are listed
+var outcomes = []string{outcomeLoss, outcomeDraw, outcomeWin}
+
+// Rules of the puzzle, the classic rock, paper, scissors
+//
+//go:embed rules/rps.json
+var defaultRulesJSON []byte
+
+var defaultRules = mustParseRules(defaultRulesJSON)
+
+// Shapes, input ids and scoring of a rock-paper-scissors variant
+type Rules struct {
+	name           string
+	shapes         []*Shape          // In config order
+	opponentShapes map[string]*Shape // By id in the first column
+	playerShapes   map[string]*Shape // By id in the second column
+	outcomeIds     map[string]string // Outcome by id in the second column
+	outcomePoints  map[string]int    // Points by outcome
+}
+
+// Rules as written in config files
+type rulesConfig struct {
+	Name     string                   `json:"name"`
+	Shapes   []shapeConfig            `json:"shapes"`
+	Outcomes map[string]outcomeConfig `json:"outcomes"` // By "win", "draw" and "loss"
+}
+
+// Shape as written in config files
+type shapeConfig struct {
+	Name       string   `json:"name"`
+	OpponentId string   `json:"opponent"`
+	PlayerId   string   `json:"player"`
+	Points     int      `json:"points"`
+	Beats      []string `json:"beats"` // Names of shapes this shape beats
+}
+
+// Outcome as written in config files
+type outcomeConfig struct {
+	Id     string `json:"id"`
+	Points int    `json:"points"`
+}
+
+// Reads rules from JSON config file
+func loadRules(path string) (*Rules, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	rules, err := parseRules(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return rules, nil
+}
+
+// Reads rules from JSON config file, or returns the default rules if path is empty
+func readRules(path string) (*Rules, error) {
+	if path == "" {
+		return defaultRules, nil
+	}
+	return loadRules(path)
+}
+
+// Parses and validates JSON rules config
+func parseRules(data []byte) (*Rules, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	config := rulesConfig{}
+	if err := decoder.Decode(&config); err != nil {
+		return nil, err
+	}
+	return newRules(config)
+}
+
+// Parses rules known to be valid, like the embedded defaults
+func mustParseRules(data []byte) *Rules {
+	rules, err := parseRules(data)
+	if err != nil {
+		panic(err)
+	}
+	return rules
+}
+
+// Rules constructor. Validates ids and outcomes, and that the beats relation
+// is consistent: no shape beats itself, no two shapes beat each other, and
+// every shape both beats and is beaten by another, so every outcome can be
+// aimed for
+func newRules(config rulesConfig) (*Rules, error) {
+	rules := Rules{
+		name:           config.Name,
+		opponentShapes: make(map[string]*Shape),
+		playerShapes:   make(map[string]*Shape),
+		outcomeIds:     make(map[string]string),
+		outcomePoints:  make(map[string]int),
+	}
+	if len(config.Shapes) == 0 {
+		return nil, fmt.Errorf("no shapes")
+	}
+	byName := make(map[string]*Shape)
+	for _, shapeConfig := range config.Shapes {
+		if shapeConfig.Name == "" {
+			return nil, fmt.Errorf("shape without name")
+		}
+		if _, ok := byName[shapeConfig.Name]; ok {
+			return nil, fmt.Errorf("duplicate shape %q", shapeConfig.Name)
+		}
+		shape := newShape(shapeConfig.OpponentId, shapeConfig.PlayerId, shapeConfig.Name, shapeConfig.Points)
+		if err := addId(rules.opponentShapes, shape.opponentId, shape, "opponent"); err != nil {
+			return nil, fmt.Errorf("shape %q: %w", shape.name, err)
+		}
+		if err := addId(rules.playerShapes, shape.playerId, shape, "player"); err != nil {
+			return nil, fmt.Errorf("shape %q: %w", shape.name, err)
+		}
+		byName[shape.name] = shape
+		rules.shapes = append(rules.shapes, shape)
+	}
+
+	beaten := make(map[string]bool)
+	for _, shapeConfig := range config.Shapes {
+		shape := byName[shapeConfig.Name]
+		for _, name := range shapeConfig.Beats {
+			other, ok := byName[name]
+			if !ok {
+				return nil, fmt.Errorf("shape %q beats unknown shape %q", shape.name, name)
+			}
+			if other == shape {
+				return nil, fmt.Errorf("shape %q beats itself", shape.name)
+			}
+			if _, ok := shape.beats[name]; ok {
+				return nil, fmt.Errorf("shape %q beats %q twice", shape.name, name)
+			}
+			shape.beats[name] = struct{}{}
+			beaten[name] = true
+		}
+	}
+	for _, shape := range rules.shapes {
+		for name := range shape.beats {
+			if _, ok := byName[name].beats[shape.name]; ok {
+				return nil, fmt.Errorf("shapes %q and %q beat each other", shape.name, name)
+			}
+		}
+		if len(shape.beats) == 0 {
+			return nil, fmt.Errorf("shape %q beats no other shape", shape.name)
+		}
+		if !beaten[shape.name] {
+			return nil, fmt.Errorf("shape %q is beaten by no other shape", shape.name)
+		}
+	}
+
+	for name := range config.Outcomes {
+		if name != outcomeWin && name != outcomeDraw && name != outcomeLoss {
+			return nil, fmt.Errorf("unknown outcome %q, want %s", name, strings.Join(outcomes, ", "))
+		}
+	}
+	for _, name := range outcomes {
+		outcome, ok := config.Outcomes[name]
+		if !ok {
+			return nil, fmt.Errorf("missing outcome %q", name)
+		}
+		if err := validId(outcome.Id); err != nil {
+			return nil, fmt.Errorf("outcome %q: %w", name, err)
+		}
+		if other, ok := rules.outcomeIds[outcome.Id]; ok {
+			return nil, fmt.Errorf("outcomes %q and %q share id %q", other, name, outcome.Id)
+		}
+		rules.outcomeIds[outcome.Id] = name
+		rules.outcomePoints[name] = outcome.Points
+	}
+	return &rules, nil
+}
+
+// Adds shape to ids of an input column, unless the id is invalid or taken
+func addId(ids map[string]*Shape, id string, shape *Shape, column string) error {
+	if err := validId(id); err != nil {
+		return fmt.Errorf("%s id: %w", column, err)
+	}
+	if other, ok := ids[id]; ok {
+		return fmt.Errorf("%s id %q already used by %q", column, id, other.name)
+	}
+	ids[id] = shape
+	return nil
+}
+
+// Checks that id can be read as a column of the strategy guide
+func validId(id string) error {
+	if id == "" {
+		return fmt.Errorf("empty id")
+	}
+	if len(strings.Fields(id)) != 1 || strings.TrimSpace(id) != id {
+		return fmt.Errorf("id %q contains whitespace", id)
+	}
+	return nil
+}
+
+// Returns the shape to play against opponent for the outcome. If several
+// shapes give the outcome, the one scoring the most points is played
+func (rules *Rules) shapeFor(opponentShape *Shape, outcome string) (*Shape, error) {
+	var best *Shape
+	for _, shape := range rules.shapes {
+		round := newRound(*shape, *opponentShape, rules)
+		if round.outcome == outcome && (best == nil || shape.points > best.points) {
+			best = shape
+		}
+	}
+	if best == nil {
+		return nil, fmt.Errorf("no shape gives %s against %s", outcome, opponentShape.name)
+	}
+	return best, nil
+}
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+// Tests Rock Paper Scissors Lizard Spock played from its config
+func TestRPSLSRules(t *testing.T) {
+	rules, err := loadRules("rules/rpsls.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	rounds, err := rules.readInput(strings.NewReader("A Y\nB X\nC Z\nD Z\nE X\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for part, solve := range map[int]func([]string) (int, error){
+		1: rules.simulateRounds,
+		2: rules.executeStrategy,
+	} {
+		score, err := solve(rounds)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if score != 32 {
+			t.Fatalf(`part %d score = %v, want 32`, part, score)
+		}
+	}
+	if _, err := rules.readInput(strings.NewReader("F X\n")); err == nil {
+		t.Fatal(`readInput() of unknown opponent shape did not fail`)
+	}
+}
+
+// Tests rejection of invalid rules
+func TestParseRulesInvalid(t *testing.T) {
+	valid := func() rulesConfig {
+		config := rulesConfig{}
+		if err := json.Unmarshal(defaultRulesJSON, &config); err != nil {
+			t.Fatal(err)
+		}
+		return config
+	}
+	tests := []struct {
+		name   string
+		modify func(config *rulesConfig)
+		want   string
+	}{
+		{"no shapes", func(c *rulesConfig) { c.Shapes = nil }, "no shapes"},
+		{"duplicate shape", func(c *rulesConfig) { c.Shapes[1].Name = "rock" }, `duplicate shape "rock"`},
+		{"duplicate id", func(c *rulesConfig) { c.Shapes[1].OpponentId = "A" }, `opponent id "A" already used`},
+		{"whitespace id", func(c *rulesConfig) { c.Shapes[0].PlayerId = "X 1" }, "whitespace"},
+		{"unknown shape", func(c *rulesConfig) { c.Shapes[0].Beats = []string{"lizard"} }, `unknown shape "lizard"`},
+		{"beats itself", func(c *rulesConfig) { c.Shapes[0].Beats = []string{"rock"} }, "beats itself"},
+		{"mutual", func(c *rulesConfig) { c.Shapes[0].Beats = []string{"scissors", "paper"} }, "beat each other"},
+		{"unbeaten", func(c *rulesConfig) { c.Shapes[1].Beats = []string{"scissors"} }, `"rock" is beaten by no other shape`},
+		{"beats nothing", func(c *rulesConfig) { c.Shapes[0].Beats = nil }, `"rock" beats no other shape`},
+		{"missing outcome", func(c *rulesConfig) { delete(c.Outcomes, "draw") }, `missing outcome "draw"`},
+		{"unknown outcome", func(c *rulesConfig) { c.Outcomes["tie"] = outcomeConfig{Id: "T"} }, `unknown outcome "tie"`},
+		{"shared outcome id", func(c *rulesConfig) { c.Outcomes["win"] = outcomeConfig{Id: "X"} }, `share id "X"`},
+	}
+	for _, test := range tests {
+		config := valid()
+		test.modify(&config)
+		_, err := newRules(config)
+		if err == nil || !strings.Contains(err.Error(), test.want) {
+			t.Fatalf(`%s: newRules() error = %v, want %q`, test.name, err, test.want)
+		}
+	}
+	if _, err := parseRules([]byte(`{"shapes": [], "colors": []}`)); err == nil {
+		t.Fatal(`parseRules() with unknown field did not fail`)
+	}
+}
+
+// Tests play subcommand with a rules config
+func TestRunPlay(t *testing.T) {
+	stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+	args := []string{"-rules", "rules/rpsls.json", "-input", "-", "-part", "1"}
+	if code := runPlay(args, strings.NewReader("A Y\nE X\n"), stdout, stderr); code != 0 {
+		t.Fatalf(`runPlay() = %v, want 0: %s`, code, stderr)
+	}
+	if want := "Total score playing the guide as shapes: 7\n"; !strings.HasSuffix(stdout.String(), want) {
+		t.Fatalf(`runPlay() output = %q, want suffix %q`, stdout, want)
+	}
+	if code := runPlay([]string{"-rules", "missing.json"}, nil, stdout, stderr); code != 1 {
+		t.Fatalf(`runPlay() with missing rules = %v, want 1`, code)
+	}
+}
@@ -0,0 +1,171 @@
+package main
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+)
+
+// Strategies players can use in tournaments
+const (
+	strategySequence  = "sequence"  // Plays shapes in order, over and over
+	strategyRandom    = "random"    // Plays random shapes from a seed
+	strategyFrequency = "frequency" // Beats the opponent's most played shape
+	strategyBeatLast  = "beat-last" // Beats the opponent's last shape
+)
+
+// Strategies in the order they are listed
+var strategies = []string{strategySequence, strategyRandom, strategyFrequency, strategyBeatLast}
+
+// Chooses the shape to play in each round of a match
+type Strategy interface {
+	// Returns shape to play, given the opponent's shapes in earlier rounds of the match
+	play(opponentShapes []*Shape) *Shape
+}
+
+// Plays shapes in order, starting over each match
+type sequenceStrategy struct {
+	shapes []*Shape
+}
+
+// Returns next shape of the sequence
+func (strategy sequenceStrategy) play(opponentShapes []*Shape) *Shape {
+	return strategy.shapes[len(opponentShapes)%len(strategy.shapes)]
+}
+
+// Plays random shapes. The generator carries on between matches, so a
+// tournament is the same for the same seed
+type randomStrategy struct {
+	rng    *rand.Rand
+	shapes []*Shape
+}
+
+// Returns a random shape
+func (strategy randomStrategy) play(opponentShapes []*Shape) *Shape {
+	return strategy.shapes[strategy.rng.Intn(len(strategy.shapes))]
+}
+
+// Plays the shape beating the opponent's most played shape, ties going to the
+// shape first in the rules. Opens with the first shape of the rules. Counts
+// are kept between rounds, so only the opponent's new shapes are counted
+type frequencyStrategy struct {
+	rules  *Rules
+	counts map[*Shape]int
+	seen   int // Opponent shapes counted
+}
+
+// Returns shape beating the most played shape so far
+func (strategy *frequencyStrategy) play(opponentShapes []*Shape) *Shape {
+	// A shorter history is a new match
+	if len(opponentShapes) < strategy.seen {
+		strategy.counts, strategy.seen = nil, 0
+	}
+	if len(opponentShapes) == 0 {
+		return strategy.rules.shapes[0]
+	}
+	if strategy.counts == nil {
+		strategy.counts = make(map[*Shape]int)
+	}
+	for _, shape := range opponentShapes[strategy.seen:] {
+		strategy.counts[shape]++
+	}
+	strategy.seen = len(opponentShapes)
+	mostPlayed := strategy.rules.shapes[0]
+	for _, shape := range strategy.rules.shapes {
+		if strategy.counts[shape] > strategy.counts[mostPlayed] {
+			mostPlayed = shape
+		}
+	}
+	return strategy.rules.beating(mostPlayed)
+}
+
+// Plays the shape beating the opponent's last shape. Opens with the first
+// shape of the rules
+type beatLastStrategy struct {
+	rules *Rules
+}
+
+// Returns shape beating the last shape played
+func (strategy beatLastStrategy) play(opponentShapes []*Shape) *Shape {
+	if len(opponentShapes) == 0 {
+		return strategy.rules.shapes[0]
+	}
+	return strategy.rules.beating(opponentShapes[len(opponentShapes)-1])
+}
+
+// Returns the shape beating shape that scores the most points. Valid rules
+// always have one
+func (rules *Rules) beating(shape *Shape) *Shape {
+	var best *Shape
+	for _, other := range rules.shapes {
+		if _, ok := other.beats[shape.name]; ok && (best == nil || other.points > best.points) {
+			best = other
+		}
+	}
+	return best
+}
+
+// Player as written in tournament configs
+type playerConfig struct {
+	Name     string   `json:"name"`
+	Strategy string   `json:"strategy"`
+	Shapes   []string `json:"shapes,omitempty"` // Shapes played by sequence, or drawn from by random
+	Seed     int64    `json:"seed,omitempty"`   // Seed of random
+}
+
+// Tournament player
+type Player struct {
+	name     string
+	kind     string // Name of strategy
+	strategy Strategy
+}
+
+// Player constructor. Validates the strategy and its shapes against rules
+func newPlayer(config playerConfig, rules *Rules) (*Player, error) {
+	if config.Name == "" {
+		return nil, fmt.Errorf("player without name")
+	}
+	shapes := []*Shape{}
+	for _, name := range config.Shapes {
+		shape := rules.shapeNamed(name)
+		if shape == nil {
+			return nil, fmt.Errorf("player %q: unknown shape %q", config.Name, name)
+		}
+		shapes = append(shapes, shape)
+	}
+	player := Player{name: config.Name, kind: config.Strategy}
+	switch config.Strategy {
+	case strategySequence:
+		if len(shapes) == 0 {
+			return nil, fmt.Errorf("player %q: sequence without shapes", config.Name)
+		}
+		player.strategy = sequenceStrategy{shapes}
+	case strategyRandom:
+		if len(shapes) == 0 {
+			shapes = rules.shapes
+		}
+		player.strategy = randomStrategy{rand.New(rand.NewSource(config.Seed)), shapes}
+	case strategyFrequency, strategyBeatLast:
+		if len(shapes) > 0 {
+			return nil, fmt.Errorf("player %q: %s takes no shapes", config.Name, config.Strategy)
+		}
+		if config.Strategy == strategyFrequency {
+			player.strategy = &frequencyStrategy{rules: rules}
+		} else {
+			player.strategy = beatLastStrategy{rules}
+		}
+	default:
+		return nil, fmt.Errorf("player %q: unknown strategy %q, want %s", config.Name, config.Strategy, strings.Join(strategies, ", "))
+	}
+	return &player, nil
+}
+
+// Returns shape by name, or nil if the rules have no such shape
+func (rules *Rules) shapeNamed(name string) *Shape {
+	for _, shape := range rules.shapes {
+		if shape.name == name {
+			return shape
+		}
+	}
+	return nil
+}
@@ -0,0 +1,286 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"text/tabwriter"
+
+	"github.com/erikzak/adventofcode/2022/aoc"
+)
+
+// Tournament as written in config files
+type tournamentConfig struct {
+	Rounds  int            `json:"rounds"` // Rounds per match
+	Players []playerConfig `json:"players"`
+}
+
+// Results of a round-robin tournament
+type Tournament struct {
+	Rules     string     `json:"rules"`
+	Rounds    int        `json:"rounds"`  // Rounds per match
+	Players   []string   `json:"players"` // In config order
+	Standings []Standing `json:"standings"`
+	Matches   []Match    `json:"matches"` // In the order played
+}
+
+// Tournament results of a player. Matches are scored like rounds, by the
+// outcome points of the rules
+type Standing struct {
+	Player   string `json:"player"`
+	Strategy string `json:"strategy"`
+	Played   int    `json:"played"`
+	Won      int    `json:"won"`
+	Drawn    int    `json:"drawn"`
+	Lost     int    `json:"lost"`
+	Points   int    `json:"points"` // Outcome points of matches
+	Score    int    `json:"score"`  // Total score of all rounds, breaks ties
+}
+
+// Match between two players, decided by rounds won
+type Match struct {
+	Players [2]string  `json:"players"`
+	Scores  [2]int     `json:"scores"`
+	Wins    [2]int     `json:"wins"` // Rounds won
+	Draws   int        `json:"draws"`
+	Log     []RoundLog `json:"log,omitempty"`
+}
+
+// Returns outcome of match for the player at index
+func (match Match) outcome(player int) string {
+	switch {
+	case match.Wins[player] > match.Wins[1-player]:
+		return outcomeWin
+	case match.Wins[player] < match.Wins[1-player]:
+		return outcomeLoss
+	}
+	return outcomeDraw
+}
+
+// Round of a match, from the first player's point of view
+type RoundLog struct {
+	Round   int       `json:"round"`
+	Shapes  [2]string `json:"shapes"`
+	Outcome string    `json:"outcome"`
+	Scores  [2]int    `json:"scores"`
+}
+
+// Reads tournament config with players validated against rules
+func loadTournament(path string, rules *Rules) ([]*Player, int, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, 0, err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	config := tournamentConfig{}
+	if err := decoder.Decode(&config); err != nil {
+		return nil, 0, fmt.Errorf("%s: %w", path, err)
+	}
+	if config.Rounds < 1 {
+		return nil, 0, fmt.Errorf("%s: rounds %d, want at least 1", path, config.Rounds)
+	}
+	if len(config.Players) < 2 {
+		return nil, 0, fmt.Errorf("%s: %d players, want at least 2", path, len(config.Players))
+	}
+	players := []*Player{}
+	names := make(map[string]struct{})
+	for _, playerConfig := range config.Players {
+		if _, ok := names[playerConfig.Name]; ok {
+			return nil, 0, fmt.Errorf("%s: duplicate player %q", path, playerConfig.Name)
+		}
+		names[playerConfig.Name] = struct{}{}
+		player, err := newPlayer(playerConfig, rules)
+		if err != nil {
+			return nil, 0, fmt.Errorf("%s: %w", path, err)
+		}
+		players = append(players, player)
+	}
+	return players, config.Rounds, nil
+}
+
+// Plays every player against every other, in config order, for the given
+// number of rounds per match. Rounds are logged if log is set
+func playTournament(rules *Rules, players []*Player, rounds int, log bool) Tournament {
+	tournament := Tournament{Rules: rules.name, Rounds: rounds}
+	standings := make([]Standing, len(players))
+	for i, player := range players {
+		tournament.Players = append(tournament.Players, player.name)
+		standings[i] = Standing{Player: player.name, Strategy: player.kind}
+	}
+	for i := 0; i < len(players); i++ {
+		for j := i + 1; j < len(players); j++ {
+			match := playMatch(rules, [2]*Player{players[i], players[j]}, rounds, log)
+			for side, index := range [2]int{i, j} {
+				standing := &standings[index]
+				standing.Played++
+				standing.Score += match.Scores[side]
+				outcome := match.outcome(side)
+				standing.Points += rules.outcomePoints[outcome]
+				switch outcome {
+				case outcomeWin:
+					standing.Won++
+				case outcomeDraw:
+					standing.Drawn++
+				default:
+					standing.Lost++
+				}
+			}
+			tournament.Matches = append(tournament.Matches, match)
+		}
+	}
+	sort.SliceStable(standings, func(i, j int) bool {
+		if standings[i].Points != standings[j].Points {
+			return standings[i].Points > standings[j].Points
+		}
+		return standings[i].Score > standings[j].Score
+	})
+	tournament.Standings = standings
+	return tournament
+}
+
+// Plays match of rounds between two players, scoring each round like the puzzle
+func playMatch(rules *Rules, players [2]*Player, rounds int, log bool) Match {
+	match := Match{Players: [2]string{players[0].name, players[1].name}}
+	history := [2][]*Shape{}
+	for r := 0; r < rounds; r++ {
+		shapes := [2]*Shape{
+			players[0].strategy.play(history[1]),
+			players[1].strategy.play(history[0]),
+		}
+		round := newRound(*shapes[0], *shapes[1], rules)
+		opponentRound := newRound(*shapes[1], *shapes[0], rules)
+		match.Scores[0] += round.score
+		match.Scores[1] += opponentRound.score
+		switch round.outcome {
+		case outcomeWin:
+			match.Wins[0]++
+		case outcomeLoss:
+			match.Wins[1]++
+		default:
+			match.Draws++
+		}
+		if log {
+			match.Log = append(match.Log, RoundLog{
+				Round:   r + 1,
+				Shapes:  [2]string{shapes[0].name, shapes[1].name},
+				Outcome: round.outcome,
+				Scores:  [2]int{round.score, opponentRound.score},
+			})
+		}
+		history[0] = append(history[0], shapes[0])
+		history[1] = append(history[1], shapes[1])
+	}
+	return match
+}
+
+// Writes standings, a head-to-head table of rounds won, drawn and lost by
+// each row player against each column player, and the round logs if any
+func writeTournament(w io.Writer, tournament Tournament) error {
+	fmt.Fprintf(w, "%s, %d players, %d rounds per match\n\n", tournament.Rules, len(tournament.Players), tournament.Rounds)
+	table := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(table, "Rank\tPlayer\tStrategy\tPlayed\tWon\tDrawn\tLost\tPoints\tScore")
+	for i, standing := range tournament.Standings {
+		fmt.Fprintf(table, "%d\t%s\t%s\t%d\t%d\t%d\t%d\t%d\t%d\n", i+1, standing.Player, standing.Strategy,
+			standing.Played, standing.Won, standing.Drawn, standing.Lost, standing.Points, standing.Score)
+	}
+	if err := table.Flush(); err != nil {
+		return err
+	}
+
+	// Rounds won, drawn and lost, by row and column player
+	headToHead := make(map[[2]string]string)
+	for _, match := range tournament.Matches {
+		headToHead[match.Players] = fmt.Sprintf("%d-%d-%d", match.Wins[0], match.Draws, match.Wins[1])
+		headToHead[[2]string{match.Players[1], match.Players[0]}] = fmt.Sprintf("%d-%d-%d", match.Wins[1], match.Draws, match.Wins[0])
+	}
+	fmt.Fprintln(w, "\nHead to head, rounds won-drawn-lost:")
+	table = tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	for _, player := range tournament.Players {
+		fmt.Fprintf(table, "\t%s", player)
+	}
+	fmt.Fprintln(table)
+	for _, row := range tournament.Players {
+		fmt.Fprint(table, row)
+		for _, column := range tournament.Players {
+			cell, ok := headToHead[[2]string{row, column}]
+			if !ok {
+				cell = "-"
+			}
+			fmt.Fprintf(table, "\t%s", cell)
+		}
+		fmt.Fprintln(table)
+	}
+	if err := table.Flush(); err != nil {
+		return err
+	}
+
+	for _, match := range tournament.Matches {
+		if len(match.Log) == 0 {
+			continue
+		}
+		fmt.Fprintf(w, "\n%s vs %s:\n", match.Players[0], match.Players[1])
+		table = tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+		for _, round := range match.Log {
+			fmt.Fprintf(table, "  %d\t%s\t%s\t%s\t%d-%d\n", round.Round, round.Shapes[0], round.Shapes[1],
+				round.Outcome, round.Scores[0], round.Scores[1])
+		}
+		if err := table.Flush(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Handles the tournament subcommand, playing the players of a config file
+// against each other. Returns exit code
+func runTournament(args []string, stdin io.Reader, stdout io.Writer, stderr io.Writer) int {
+	flags := flag.NewFlagSet("tournament", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	rulesPath := flags.String("rules", "", "JSON rules config, default rock, paper, scissors")
+	playersPath := flags.String("players", "tournaments/classic.json", "JSON tournament config with rounds per match and players")
+	rounds := flags.Int("rounds", 0, "rounds per match, 0 for the config's")
+	log := flags.Bool("log", false, "include every round of every match")
+	format := flags.String("format", aoc.FormatText, "output format: text or json")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if *format != aoc.FormatText && *format != aoc.FormatJSON {
+		fmt.Fprintf(stderr, "invalid format %q, want %s or %s\n", *format, aoc.FormatText, aoc.FormatJSON)
+		return 2
+	}
+	if *rounds < 0 {
+		fmt.Fprintf(stderr, "invalid rounds %d, want at least 1\n", *rounds)
+		return 2
+	}
+	rules, err := readRules(*rulesPath)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return 1
+	}
+	players, configRounds, err := loadTournament(*playersPath, rules)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return 1
+	}
+	if *rounds == 0 {
+		*rounds = configRounds
+	}
+	tournament := playTournament(rules, players, *rounds, *log)
+	if *format == aoc.FormatJSON {
+		encoder := json.NewEncoder(stdout)
+		encoder.SetIndent("", "  ")
+		err = encoder.Encode(tournament)
+	} else {
+		err = writeTournament(stdout, tournament)
+	}
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,134 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// Tests shapes chosen by each strategy
+func TestStrategies(t *testing.T) {
+	rock, paper, scissors := defaultRules.shapes[0], defaultRules.shapes[1], defaultRules.shapes[2]
+	tests := []struct {
+		name     string
+		config   playerConfig
+		opponent []*Shape
+		want     *Shape
+	}{
+		{"sequence", playerConfig{Strategy: strategySequence, Shapes: []string{"paper", "scissors"}}, []*Shape{rock, rock, rock}, scissors},
+		{"random from one", playerConfig{Strategy: strategyRandom, Shapes: []string{"paper"}, Seed: 1}, nil, paper},
+		{"frequency opening", playerConfig{Strategy: strategyFrequency}, nil, rock},
+		{"frequency", playerConfig{Strategy: strategyFrequency}, []*Shape{paper, scissors, scissors, rock}, rock},
+		{"beat last", playerConfig{Strategy: strategyBeatLast}, []*Shape{scissors, paper}, scissors},
+	}
+	for _, test := range tests {
+		test.config.Name = test.name
+		player, err := newPlayer(test.config, defaultRules)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := player.strategy.play(test.opponent); got != test.want {
+			t.Fatalf(`%s play() = %v, want %v`, test.name, got.name, test.want.name)
+		}
+	}
+}
+
+// Tests that frequency counts carry on between rounds and start over for each
+// match
+func TestFrequencyStrategyMatches(t *testing.T) {
+	rock, paper, scissors := defaultRules.shapes[0], defaultRules.shapes[1], defaultRules.shapes[2]
+	player, err := newPlayer(playerConfig{Name: "counter", Strategy: strategyFrequency}, defaultRules)
+	if err != nil {
+		t.Fatal(err)
+	}
+	matches := [][]*Shape{{scissors, scissors, rock, rock, rock}, {paper, paper}}
+	for _, match := range matches {
+		for round := 0; round <= len(match); round++ {
+			fresh := &frequencyStrategy{rules: defaultRules}
+			if got, want := player.strategy.play(match[:round]), fresh.play(match[:round]); got != want {
+				t.Fatalf(`play(%d rounds) = %v, want %v`, round, got.name, want.name)
+			}
+		}
+	}
+}
+
+// Tests standings and match results of a short tournament
+func TestPlayTournament(t *testing.T) {
+	players := []*Player{}
+	for _, config := range []playerConfig{
+		{Name: "rocky", Strategy: strategySequence, Shapes: []string{"rock"}},
+		{Name: "chaser", Strategy: strategyBeatLast},
+	} {
+		player, err := newPlayer(config, defaultRules)
+		if err != nil {
+			t.Fatal(err)
+		}
+		players = append(players, player)
+	}
+	tournament := playTournament(defaultRules, players, 3, true)
+	// Rock against rock, then paper beating rock twice
+	match := tournament.Matches[0]
+	if match.Scores != [2]int{6, 20} || match.Wins != [2]int{0, 2} || match.Draws != 1 {
+		t.Fatalf(`playTournament() match = %+v, want scores 6-20, wins 0-2 and 1 draw`, match)
+	}
+	if want := (RoundLog{Round: 2, Shapes: [2]string{"rock", "paper"}, Outcome: outcomeLoss, Scores: [2]int{1, 8}}); !reflect.DeepEqual(match.Log[1], want) {
+		t.Fatalf(`playTournament() round 2 = %+v, want %+v`, match.Log[1], want)
+	}
+	want := []Standing{
+		{Player: "chaser", Strategy: strategyBeatLast, Played: 1, Won: 1, Points: 6, Score: 20},
+		{Player: "rocky", Strategy: strategySequence, Played: 1, Lost: 1, Points: 0, Score: 6},
+	}
+	if !reflect.DeepEqual(tournament.Standings, want) {
+		t.Fatalf(`playTournament() standings = %+v, want %+v`, tournament.Standings, want)
+	}
+}
+
+// Tests rejection of invalid tournament configs
+func TestLoadTournamentInvalid(t *testing.T) {
+	tests := []struct {
+		config string
+		want   string
+	}{
+		{`{"rounds": 0, "players": []}`, "rounds 0"},
+		{`{"rounds": 5, "players": [{"name": "solo", "strategy": "frequency"}]}`, "1 players"},
+		{`{"rounds": 5, "players": [{"name": "a", "strategy": "frequency"}, {"name": "a", "strategy": "beat-last"}]}`, `duplicate player "a"`},
+		{`{"rounds": 5, "players": [{"name": "a", "strategy": "psychic"}, {"name": "b", "strategy": "beat-last"}]}`, `unknown strategy "psychic"`},
+		{`{"rounds": 5, "players": [{"name": "a", "strategy": "sequence"}, {"name": "b", "strategy": "beat-last"}]}`, "sequence without shapes"},
+		{`{"rounds": 5, "players": [{"name": "a", "strategy": "sequence", "shapes": ["spock"]}, {"name": "b", "strategy": "beat-last"}]}`, `unknown shape "spock"`},
+	}
+	path := filepath.Join(t.TempDir(), "tournament.json")
+	for _, test := range tests {
+		if err := os.WriteFile(path, []byte(test.config), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		_, _, err := loadTournament(path, defaultRules)
+		if err == nil || !strings.Contains(err.Error(), test.want) {
+			t.Fatalf(`loadTournament(%s) error = %v, want %q`, test.config, err, test.want)
+		}
+	}
+}
+
+// Tests tournament subcommand JSON output of the bundled config
+func TestRunTournament(t *testing.T) {
+	stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+	args := []string{"-rules", "rules/rpsls.json", "-rounds", "10", "-format", "json"}
+	if code := runTournament(args, nil, stdout, stderr); code != 0 {
+		t.Fatalf(`runTournament() = %v, want 0: %s`, code, stderr)
+	}
+	var tournament Tournament
+	if err := json.Unmarshal(stdout.Bytes(), &tournament); err != nil {
+		t.Fatal(err)
+	}
+	players := len(tournament.Players)
+	if len(tournament.Standings) != players || len(tournament.Matches) != players*(players-1)/2 {
+		t.Fatalf(`runTournament() = %d standings and %d matches, want %d and %d`,
+			len(tournament.Standings), len(tournament.Matches), players, players*(players-1)/2)
+	}
+	if rounds := tournament.Matches[0].Wins[0] + tournament.Matches[0].Wins[1] + tournament.Matches[0].Draws; rounds != 10 {
+		t.Fatalf(`runTournament() first match has %d rounds, want 10`, rounds)
+	}
+}
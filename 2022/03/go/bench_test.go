@@ -0,0 +1,99 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/erikzak/adventofcode/2022/aoc"
+)
+
+// Benchmark full solve
+func BenchmarkSolvePuzzle(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		if _, _, err := solvePuzzle(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// Benchmark input parsing
+func BenchmarkReadInput(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		if _, err := aoc.ReadFile(inputPath, readInput); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// Benchmark part 1
+func BenchmarkSolvePart1(b *testing.B) {
+	sacks, err := aoc.ReadFile(inputPath, readInput)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		solvePart1(sacks)
+	}
+}
+
+// Benchmark part 2
+func BenchmarkSolvePart2(b *testing.B) {
+	sacks, err := aoc.ReadFile(inputPath, readInput)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := solvePart2(sacks); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// Benchmark finding duplicates with item sets
+func BenchmarkDuplicatesItemSet(b *testing.B) {
+	lines := generatedItems(b, 300)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, items := range lines {
+			half := len(items) / 2
+			newItemSet(items[:half]).intersect(newItemSet(items[half:])).sumPriority()
+		}
+	}
+}
+
+// Benchmark finding duplicates with maps of runes, for comparison
+func BenchmarkDuplicatesMap(b *testing.B) {
+	lines := generatedItems(b, 300)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, items := range lines {
+			half := len(items) / 2
+			for r := range mapDuplicates(items[:half], items[half:]) {
+				getItemPriority(r)
+			}
+		}
+	}
+}
+
+// Benchmark finding badges with item sets
+func BenchmarkBadgeItemSet(b *testing.B) {
+	lines := generatedItems(b, 300)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for j := 0; j < len(lines); j += 3 {
+			newItemSet(lines[j]).intersect(newItemSet(lines[j+1])).intersect(newItemSet(lines[j+2])).first()
+		}
+	}
+}
+
+// Benchmark finding badges with maps of runes, for comparison
+func BenchmarkBadgeMap(b *testing.B) {
+	lines := generatedItems(b, 300)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for j := 0; j < len(lines); j += 3 {
+			mapBadge(lines[j : j+3])
+		}
+	}
+}
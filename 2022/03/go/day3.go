@@ -3,28 +3,24 @@
 package main
 
 import (
+	"errors"
 	"fmt"
-	"log"
-	"os"
+	"io"
 	"strings"
 	"unicode"
+
+	"github.com/erikzak/adventofcode/2022/aoc"
 )
 
 const inputPath = "../input.txt"
 
-func check(err error) {
-	if err != nil {
-		log.Panic(err)
-	}
-}
-
 // Rucksack structure for keeping track of content, compartments, duplicates
 // and calculating priority score.
 type Rucksack struct {
 	items        string
-	itemMap      map[rune]struct{}
+	itemSet      ItemSet
 	compartments []string
-	duplicates   map[rune]struct{}
+	duplicates   ItemSet
 	sumPriority  int
 }
 
@@ -32,13 +28,7 @@ type Rucksack struct {
 // calculates sum priority
 func newRucksack(items string) Rucksack {
 	sack := Rucksack{items: items}
-	sack.itemMap = make(map[rune]struct{})
-	sack.duplicates = make(map[rune]struct{})
-
-	// Generate item rune map
-	for _, r := range items {
-		sack.itemMap[r] = struct{}{}
-	}
+	sack.itemSet = newItemSet(items)
 
 	// Process inventory
 	sack.splitItemsIntoCompartments()
@@ -56,39 +46,35 @@ func (sack *Rucksack) splitItemsIntoCompartments() {
 
 // Searches compartments for duplicate values
 func (sack *Rucksack) findDuplicates() {
-	// Create rune map of second compartment for performance
-	secondCompartment := make(map[rune]struct{})
-	for _, r := range sack.compartments[1] {
-		secondCompartment[r] = struct{}{}
-	}
-	// Look for duplicates
-	for _, r := range sack.compartments[0] {
-		if _, ok := secondCompartment[r]; ok {
-			sack.duplicates[r] = struct{}{}
-		}
-	}
+	sack.duplicates = newItemSet(sack.compartments[0]).intersect(newItemSet(sack.compartments[1]))
 }
 
 // Calculates priority of rucksack based on duplicates
 func (sack *Rucksack) calculatePriority() {
-	for r := range sack.duplicates {
-		sack.sumPriority += getItemPriority(r)
-	}
+	sack.sumPriority = sack.duplicates.sumPriority()
 }
 
-// Parses puzzle input from txt file.
+// Parses puzzle input from reader.
 // Returns a slice of rucksacks.
-func readInput(inputPath string) (sacks []Rucksack) {
-	inputBytes, err := os.ReadFile(inputPath)
-	check(err)
-	input := string(inputBytes)
-
-	for _, line := range strings.Split(input, "\n") {
+func readInput(r io.Reader) (sacks []Rucksack, err error) {
+	input, err := aoc.ReadFrom(r)
+	if err != nil {
+		return nil, err
+	}
+	for i, line := range input.Lines {
 		items := strings.TrimSpace(line)
+		if len(items)%2 != 0 {
+			return nil, input.Errorf(i, "", "odd number of items, compartments must be equal size")
+		}
+		for _, r := range items {
+			if r > unicode.MaxASCII || !unicode.IsLetter(r) {
+				return nil, input.Errorf(i, string(r), "invalid item %q", r)
+			}
+		}
 		sack := newRucksack(items)
 		sacks = append(sacks, sack)
 	}
-	return sacks
+	return sacks, nil
 }
 
 // Returns the priority value of a given item
@@ -100,38 +86,76 @@ func getItemPriority(item rune) int {
 	return int(item) - 38 // -64 + 26
 }
 
-// Finds the item present in all rucksacks
-func findBadge(sacks []Rucksack) rune {
-	for r := range sacks[0].itemMap {
-		for i := 1; i < len(sacks); i++ {
-			if _, ok := sacks[i].itemMap[r]; !ok {
-				break
-			}
-			if i == len(sacks)-1 {
-				return r
-			}
-		}
+// Finds the item present in all rucksacks. If there are several, the one
+// with the lowest priority is returned
+func findBadge(sacks []Rucksack) (rune, error) {
+	common := sacks[0].itemSet
+	for _, sack := range sacks[1:] {
+		common = common.intersect(sack.itemSet)
+	}
+	badge, ok := common.first()
+	if !ok {
+		return 0, errors.New("no badge found")
 	}
-	panic("no badge found")
+	return badge, nil
 }
 
-// Parses input as txt into slice of rucksacks with calculated priority scores
-func main() {
-	sacks := readInput(inputPath)
-
-	// Part 1
+// Part 1: sum of priorities of items found in both compartments
+func solvePart1(sacks []Rucksack) int {
 	sumPriority := 0
 	for _, sack := range sacks {
 		sumPriority += sack.sumPriority
 	}
-	fmt.Printf("Sum of rucksack priorities: %d\n", sumPriority)
+	return sumPriority
+}
 
-	// Part 2
+// Part 2: sum of priorities of the badge items of each group of three elves
+func solvePart2(sacks []Rucksack) (int, error) {
+	if len(sacks)%3 != 0 {
+		return 0, fmt.Errorf("%d rucksacks can't be split into groups of three", len(sacks))
+	}
 	sumBadgePriority := 0
 	// Process 3 and 3 rucksacks, find badge and add priority to sum
 	for i := 0; i < len(sacks); i += 3 {
-		badge := findBadge(sacks[i : i+3])
+		badge, err := findBadge(sacks[i : i+3])
+		if err != nil {
+			return 0, fmt.Errorf("group %d: %v", i/3+1, err)
+		}
 		sumBadgePriority += getItemPriority(badge)
 	}
-	fmt.Printf("Sum of badge priorities: %d\n", sumBadgePriority)
+	return sumBadgePriority, nil
+}
+
+// Solves puzzle parts, split out for benchmarking
+func solvePuzzle() (int, int, error) {
+	sacks, err := aoc.ReadFile(inputPath, readInput)
+	if err != nil {
+		return 0, 0, err
+	}
+	answer2, err := solvePart2(sacks)
+	if err != nil {
+		return 0, 0, err
+	}
+	return solvePart1(sacks), answer2, nil
+}
+
+// Registers puzzle parts with the aoc runner
+var puzzle = aoc.Puzzle[[]Rucksack, int, int]{
+	Year: 2022, Day: 3,
+	ReadInput:  readInput,
+	SolvePart1: aoc.NoError(solvePart1),
+	SolvePart2: aoc.NoContext(solvePart2),
+	Labels: [2]string{
+		"Sum of rucksack priorities",
+		"Sum of badge priorities",
+	},
+	Gen: &aoc.Generator{
+		Size: 300, SizeHelp: "rucksacks",
+		Generate: generateInput,
+	},
+}
+
+// Reads input, solves puzzle parts and logs answers
+func main() {
+	aoc.Main(puzzle)
 }
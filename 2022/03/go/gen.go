@@ -0,0 +1,42 @@
+package main
+
+import (
+	"bufio"
+	"math/rand"
+)
+
+// Generates random rucksacks, size rounded up to whole groups of three. The
+// badge is the only item type all three elves in a group carry, and every
+// rucksack has at least one item type in both compartments
+func generateInput(rng *rand.Rand, size int, w *bufio.Writer) {
+	for group := 0; group < (size+2)/3; group++ {
+		// Badge first, then one pool of other item types per elf
+		items := []byte(itemTypes)
+		rng.Shuffle(len(items), func(i, j int) {
+			items[i], items[j] = items[j], items[i]
+		})
+		badge := items[0]
+		poolSize := (len(items) - 1) / 3
+		for elf := 0; elf < 3; elf++ {
+			pool := items[1+elf*poolSize : 1+(elf+1)*poolSize]
+			half := 4 + rng.Intn(12)
+			compartments := [2][]byte{make([]byte, half), make([]byte, half)}
+			for _, compartment := range compartments {
+				for i := range compartment {
+					compartment[i] = pool[rng.Intn(len(pool))]
+				}
+			}
+			shared := pool[rng.Intn(len(pool))]
+			compartments[0][0] = shared
+			compartments[1][0] = shared
+			compartments[1][1] = badge
+			for _, compartment := range compartments {
+				rng.Shuffle(len(compartment), func(i, j int) {
+					compartment[i], compartment[j] = compartment[j], compartment[i]
+				})
+				w.Write(compartment)
+			}
+			w.WriteString("\n")
+		}
+	}
+}
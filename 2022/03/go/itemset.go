@@ -0,0 +1,74 @@
+package main
+
+import (
+	"math/bits"
+	"strings"
+)
+
+// Item types, in priority order
+const itemTypes = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ"
+
+// Set of item types, one bit per type at the position of its priority, so
+// sets are combined and counted with single instructions instead of maps.
+// Only letters can be added, which readInput makes sure of
+type ItemSet uint64
+
+// Returns set of the items in string
+func newItemSet(items string) ItemSet {
+	set := ItemSet(0)
+	for _, r := range items {
+		set = set.add(r)
+	}
+	return set
+}
+
+// Returns set with item added
+func (set ItemSet) add(item rune) ItemSet {
+	return set | 1<<getItemPriority(item)
+}
+
+// Checks if set contains item
+func (set ItemSet) contains(item rune) bool {
+	return set&(1<<getItemPriority(item)) != 0
+}
+
+// Returns items in either set
+func (set ItemSet) union(other ItemSet) ItemSet {
+	return set | other
+}
+
+// Returns items in both sets
+func (set ItemSet) intersect(other ItemSet) ItemSet {
+	return set & other
+}
+
+// Returns number of items in set
+func (set ItemSet) count() int {
+	return bits.OnesCount64(uint64(set))
+}
+
+// Returns sum of priorities of items in set
+func (set ItemSet) sumPriority() int {
+	sum := 0
+	for remaining := uint64(set); remaining != 0; remaining &= remaining - 1 {
+		sum += bits.TrailingZeros64(remaining)
+	}
+	return sum
+}
+
+// Returns item with the lowest priority in set, or false if set is empty
+func (set ItemSet) first() (rune, bool) {
+	if set == 0 {
+		return 0, false
+	}
+	return rune(itemTypes[bits.TrailingZeros64(uint64(set))-1]), true
+}
+
+// Returns items in set, in priority order
+func (set ItemSet) String() string {
+	builder := strings.Builder{}
+	for remaining := uint64(set); remaining != 0; remaining &= remaining - 1 {
+		builder.WriteByte(itemTypes[bits.TrailingZeros64(remaining)-1])
+	}
+	return builder.String()
+}
@@ -0,0 +1,110 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+// Tests set operations
+func TestItemSet(t *testing.T) {
+	a, b := newItemSet("vJrwpWtwJgWr"), newItemSet("hcsFMMfFFhFp")
+	if got := a.intersect(b).String(); got != "p" {
+		t.Fatalf(`intersect() = %q, want "p"`, got)
+	}
+	if got := a.union(b).String(); got != "cfghprstvwFJMW" {
+		t.Fatalf(`union() = %q, want "cfghprstvwFJMW"`, got)
+	}
+	if got := a.count(); got != 8 {
+		t.Fatalf(`count() = %v, want 8`, got)
+	}
+	if !a.contains('J') || a.contains('j') {
+		t.Fatal(`contains() confused upper and lower case`)
+	}
+	if got := newItemSet("aZ").sumPriority(); got != 1+52 {
+		t.Fatalf(`sumPriority() = %v, want 53`, got)
+	}
+	if _, ok := ItemSet(0).first(); ok {
+		t.Fatal(`first() of empty set is ok`)
+	}
+}
+
+// Tests that item sets find the same duplicates and badges as maps of runes
+func TestItemSetMatchesMaps(t *testing.T) {
+	lines := generatedItems(t, 300)
+	for _, items := range lines {
+		half := len(items) / 2
+		want := mapDuplicates(items[:half], items[half:])
+		sack := newRucksack(items)
+		if sack.duplicates.count() != len(want) {
+			t.Fatalf(`%s duplicates = %v, want %d items`, items, sack.duplicates, len(want))
+		}
+		for r := range want {
+			if !sack.duplicates.contains(r) {
+				t.Fatalf(`%s duplicates = %v, missing %c`, items, sack.duplicates, r)
+			}
+		}
+	}
+	for i := 0; i < len(lines); i += 3 {
+		sacks := []Rucksack{newRucksack(lines[i]), newRucksack(lines[i+1]), newRucksack(lines[i+2])}
+		badge, err := findBadge(sacks)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := mapBadge(lines[i : i+3]); badge != want {
+			t.Fatalf(`findBadge() = %c, want %c`, badge, want)
+		}
+	}
+}
+
+// Returns lines of generated input with size rucksacks
+func generatedItems(tb testing.TB, size int) []string {
+	buffer := &bytes.Buffer{}
+	w := bufio.NewWriter(buffer)
+	generateInput(rand.New(rand.NewSource(3)), size, w)
+	if err := w.Flush(); err != nil {
+		tb.Fatal(err)
+	}
+	return strings.Fields(buffer.String())
+}
+
+// Returns items in both compartments, the way rucksacks did before item sets
+func mapDuplicates(first string, second string) map[rune]struct{} {
+	secondCompartment := make(map[rune]struct{})
+	for _, r := range second {
+		secondCompartment[r] = struct{}{}
+	}
+	duplicates := make(map[rune]struct{})
+	for _, r := range first {
+		if _, ok := secondCompartment[r]; ok {
+			duplicates[r] = struct{}{}
+		}
+	}
+	return duplicates
+}
+
+// Returns item in all rucksacks, the way badges were found before item sets
+func mapBadge(group []string) rune {
+	itemMaps := make([]map[rune]struct{}, len(group))
+	for i, items := range group {
+		itemMaps[i] = make(map[rune]struct{})
+		for _, r := range items {
+			itemMaps[i][r] = struct{}{}
+		}
+	}
+	for r := range itemMaps[0] {
+		found := true
+		for _, itemMap := range itemMaps[1:] {
+			if _, ok := itemMap[r]; !ok {
+				found = false
+				break
+			}
+		}
+		if found {
+			return r
+		}
+	}
+	return 0
+}
@@ -4,20 +4,15 @@ package main
 
 import (
 	"fmt"
-	"log"
-	"os"
+	"io"
 	"strconv"
 	"strings"
+
+	"github.com/erikzak/adventofcode/2022/aoc"
 )
 
 const inputPath = "../input.txt"
 
-func check(err error) {
-	if err != nil {
-		log.Panic(err)
-	}
-}
-
 // A small often mischievous fairy
 type Elf struct {
 	assignment string
@@ -44,39 +39,54 @@ func (elf Elf) overlapsSections(otherElf Elf) bool {
 }
 
 // Elf constructor. Gets min/max assignment section
-func newElf(assignment string) Elf {
+func newElf(assignment string) (Elf, error) {
 	elf := Elf{assignment: assignment}
 	split := strings.Split(assignment, "-")
+	if len(split) != 2 {
+		return elf, fmt.Errorf("invalid section range %q", assignment)
+	}
 	var err error
 	elf.minSection, err = strconv.Atoi(split[0])
-	check(err)
+	if err != nil {
+		return elf, fmt.Errorf("invalid section %q", split[0])
+	}
 	elf.maxSection, err = strconv.Atoi(split[1])
-	check(err)
-	return elf
+	if err != nil {
+		return elf, fmt.Errorf("invalid section %q", split[1])
+	}
+	if elf.minSection > elf.maxSection {
+		return elf, fmt.Errorf("section range %q ends before it starts", assignment)
+	}
+	return elf, nil
 }
 
-// Parses puzzle input from txt file.
+// Parses puzzle input from reader.
 // Returns a slice of elf pair slices
-func readInput(inputPath string) (elfPairs [][]Elf) {
-	inputBytes, err := os.ReadFile(inputPath)
-	check(err)
-	input := string(inputBytes)
-
-	for _, line := range strings.Split(input, "\n") {
+func readInput(r io.Reader) (elfPairs [][]Elf, err error) {
+	input, err := aoc.ReadFrom(r)
+	if err != nil {
+		return nil, err
+	}
+	for i, line := range input.Lines {
 		assignments := strings.Split(strings.TrimSpace(line), ",")
-		elf1 := newElf(assignments[0])
-		elf2 := newElf(assignments[1])
-		elfPair := []Elf{elf1, elf2}
+		if len(assignments) != 2 {
+			return nil, input.Errorf(i, "", "expected two comma separated assignments")
+		}
+		elfPair := []Elf{}
+		for _, assignment := range assignments {
+			elf, err := newElf(assignment)
+			if err != nil {
+				return nil, input.Error(i, assignment, err)
+			}
+			elfPair = append(elfPair, elf)
+		}
 		elfPairs = append(elfPairs, elfPair)
 	}
-	return elfPairs
+	return elfPairs, nil
 }
 
-// Reads input and solves puzzle parts
-func main() {
-	elfPairs := readInput(inputPath)
-
-	// Part 1: in how many assignment pairs does one range fully contain the other?
+// Part 1: in how many assignment pairs does one range fully contain the other?
+func solvePart1(elfPairs [][]Elf) int {
 	nContains := 0
 	for _, elfPair := range elfPairs {
 		if elfPair[0].fullyContainsSections(elfPair[1]) ||
@@ -84,14 +94,46 @@ func main() {
 			nContains += 1
 		}
 	}
-	fmt.Printf("Assignment pairs where one fully contains the other: %d\n", nContains)
+	return nContains
+}
 
-	// Part 2: in how many assignment pairs do the ranges overlap?
+// Part 2: in how many assignment pairs do the ranges overlap?
+func solvePart2(elfPairs [][]Elf) int {
 	nOverlaps := 0
 	for _, elfPair := range elfPairs {
 		if elfPair[0].overlapsSections(elfPair[1]) {
 			nOverlaps += 1
 		}
 	}
-	fmt.Printf("Assignment pairs where sections overlap: %d\n", nOverlaps)
+	return nOverlaps
+}
+
+// Solves puzzle parts, split out for benchmarking
+func solvePuzzle() (int, int, error) {
+	elfPairs, err := aoc.ReadFile(inputPath, readInput)
+	if err != nil {
+		return 0, 0, err
+	}
+	return solvePart1(elfPairs), solvePart2(elfPairs), nil
+}
+
+// Registers puzzle parts with the aoc runner
+var puzzle = aoc.Puzzle[[][]Elf, int, int]{
+	Year: 2022, Day: 4,
+	ReadInput:  readInput,
+	SolvePart1: aoc.NoError(solvePart1),
+	SolvePart2: aoc.NoError(solvePart2),
+	Labels: [2]string{
+		"Assignment pairs where one fully contains the other",
+		"Assignment pairs where sections overlap",
+	},
+	Gen: &aoc.Generator{
+		Size: 1000, SizeHelp: "assignment pairs",
+		Generate: generateInput,
+	},
+}
+
+// Reads input, solves puzzle parts and logs answers
+func main() {
+	aoc.Main(puzzle)
 }
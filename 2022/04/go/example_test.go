@@ -0,0 +1,36 @@
+// Tests puzzle example data
+package main
+
+import (
+	"testing"
+
+	"github.com/erikzak/adventofcode/2022/aoc"
+)
+
+const testPath = "../test.txt"
+
+// Tests part 1 against example data
+func TestPart1Example(t *testing.T) {
+	want := 2
+	elfPairs, err := aoc.ReadFile(testPath, readInput)
+	if err != nil {
+		t.Fatal(err)
+	}
+	answer1 := solvePart1(elfPairs)
+	if answer1 != want {
+		t.Fatalf(`solvePart1() = %v, want %v`, answer1, want)
+	}
+}
+
+// Tests part 2 against example data
+func TestPart2Example(t *testing.T) {
+	want := 4
+	elfPairs, err := aoc.ReadFile(testPath, readInput)
+	if err != nil {
+		t.Fatal(err)
+	}
+	answer2 := solvePart2(elfPairs)
+	if answer2 != want {
+		t.Fatalf(`solvePart2() = %v, want %v`, answer2, want)
+	}
+}
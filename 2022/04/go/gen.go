@@ -0,0 +1,22 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"math/rand"
+)
+
+// Generates size random pairs of section assignments between 1 and 99
+func generateInput(rng *rand.Rand, size int, w *bufio.Writer) {
+	for i := 0; i < size; i++ {
+		for elf := 0; elf < 2; elf++ {
+			if elf > 0 {
+				w.WriteString(",")
+			}
+			minSection := 1 + rng.Intn(99)
+			maxSection := minSection + rng.Intn(100-minSection)
+			fmt.Fprintf(w, "%d-%d", minSection, maxSection)
+		}
+		w.WriteString("\n")
+	}
+}
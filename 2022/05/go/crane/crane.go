@@ -1,45 +1,72 @@
 package crane
 
 import (
-	"log"
+	"fmt"
 	"sort"
 	"strconv"
 	"strings"
 )
 
-func check(err error) {
-	if err != nil {
-		log.Panic(err)
+// Planned move of count crates from one stack to another
+type Move struct {
+	Count int
+	From  rune
+	To    rune
+}
+
+// Parses move like "move 1 from 2 to 1"
+func ParseMove(move string) (Move, error) {
+	fields := strings.Fields(move)
+	if len(fields) != 6 || fields[0] != "move" || fields[2] != "from" || fields[4] != "to" {
+		return Move{}, fmt.Errorf("expected move like \"move 1 from 2 to 1\"")
+	}
+	count, err := strconv.Atoi(fields[1])
+	if err != nil || count < 1 {
+		return Move{}, fmt.Errorf("invalid crate count %q", fields[1])
+	}
+	if len(fields[3]) != 1 || len(fields[5]) != 1 {
+		return Move{}, fmt.Errorf("invalid stack id in %q", move)
 	}
+	return Move{Count: count, From: rune(fields[3][0]), To: rune(fields[5][0])}, nil
 }
 
 // Crane structure. Keeps track of stacks and planned moves.
 // Moves crates between stacks.
 type Crane struct {
 	stacks       map[rune]Stack
-	plannedMoves []string
+	plannedMoves []Move
 }
 
 // Crane constructor. Defines initial stack configuration
-func NewCrane(stacks map[rune]Stack, plannedMoves []string) Crane {
+func NewCrane(stacks map[rune]Stack, plannedMoves []Move) Crane {
 	crane := Crane{stacks: stacks, plannedMoves: plannedMoves}
 	return crane
 }
 
-func (crane *Crane) ExecuteMoves(singleCrate bool) {
-	for _, move := range crane.plannedMoves {
-		fields := strings.Fields(move)
-		count, err := strconv.Atoi(fields[1])
-		check(err)
-		fromStackId := rune(fields[3][0])
-		toStackId := rune(fields[5][0])
-		crane.MoveCrates(count, fromStackId, toStackId, singleCrate)
+// Executes planned moves in order. Fails on moves from or to unknown stacks,
+// or moves of more crates than the stack holds
+func (crane *Crane) ExecuteMoves(singleCrate bool) error {
+	for i, move := range crane.plannedMoves {
+		if err := crane.MoveCrates(move.Count, move.From, move.To, singleCrate); err != nil {
+			return fmt.Errorf("move %d: %w", i+1, err)
+		}
 	}
+	return nil
 }
 
 // Moves crates between stacks based on planned moves
-func (crane *Crane) MoveCrates(count int, fromStackId rune, toStackId rune, singleCrate bool) {
-	fromStack, toStack := crane.stacks[fromStackId], crane.stacks[toStackId]
+func (crane *Crane) MoveCrates(count int, fromStackId rune, toStackId rune, singleCrate bool) error {
+	fromStack, ok := crane.stacks[fromStackId]
+	if !ok {
+		return fmt.Errorf("unknown stack %q", fromStackId)
+	}
+	toStack, ok := crane.stacks[toStackId]
+	if !ok {
+		return fmt.Errorf("unknown stack %q", toStackId)
+	}
+	if count > len(fromStack.crates) {
+		return fmt.Errorf("can't move %d crates from stack %q holding %d", count, fromStackId, len(fromStack.crates))
+	}
 	movedCrates := append([]rune(nil), fromStack.crates[:count]...)
 	if singleCrate {
 		reverse(movedCrates)
@@ -48,6 +75,7 @@ func (crane *Crane) MoveCrates(count int, fromStackId rune, toStackId rune, sing
 	fromStack.crates = fromStack.crates[count:]
 	crane.stacks[fromStackId] = fromStack
 	crane.stacks[toStackId] = toStack
+	return nil
 }
 
 // Returns string of crates on top of stacks
@@ -62,7 +90,10 @@ func (crane *Crane) GetTopCrates() string {
 		return ids[i] < ids[j]
 	})
 	for _, id := range ids {
-		topCrates += string(crane.stacks[id].crates[0])
+		// Empty stacks have nothing on top
+		if crates := crane.stacks[id].crates; len(crates) > 0 {
+			topCrates += string(crates[0])
+		}
 	}
 	return topCrates
 }
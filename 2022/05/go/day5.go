@@ -4,64 +4,116 @@ package main
 
 import (
 	"fmt"
-	"log"
-	"os"
+	"io"
 	"strings"
 	"unicode"
 
 	"github.com/erikzak/adventofcode/2022/5/crane"
+	"github.com/erikzak/adventofcode/2022/aoc"
 )
 
 const inputPath = "../input.txt"
 
-func check(err error) {
+// Parses puzzle input from reader. Stack setup is drawn above a line of
+// stack ids, followed by a blank line and the planned moves.
+// Returns a crane with stack setup and planned moves
+func readInput(r io.Reader) (crane.Crane, error) {
+	input, err := aoc.ReadFrom(r)
 	if err != nil {
-		log.Panic(err)
+		return crane.Crane{}, err
 	}
-}
-
-// Parses puzzle input from txt file.
-// Returns a crane with stack setup and planned moves
-func readInput(path string, crateLines int) crane.Crane {
-	inputBytes, err := os.ReadFile(path)
-	check(err)
-	lines := strings.Split(string(inputBytes), "\n")
+	lines := input.Lines
+	// Stack ids on the line before the blank separator
+	crateLines := 0
+	for crateLines < len(lines) && strings.TrimSpace(lines[crateLines]) != "" {
+		crateLines++
+	}
+	if crateLines == 0 || crateLines == len(lines) {
+		return crane.Crane{}, input.Errorf(crateLines, "", "expected stack setup followed by a blank line")
+	}
+	crateLines--
 
 	// Stack config in first x lines
 	crates := [][]rune{}
-	for _, line := range lines[:crateLines] {
+	for lineIdx, line := range lines[:crateLines] {
 		for i := 1; i < len(line); i += 4 {
 			stackIdx := (i - 1) / 4
 			crate := rune(line[i])
-			if !unicode.IsSpace(crate) {
-				for len(crates) <= stackIdx {
-					crates = append(crates, []rune{})
-				}
-				crates[stackIdx] = append(crates[stackIdx], crate)
+			if unicode.IsSpace(crate) {
+				continue
 			}
+			if line[i-1] != '[' || i+1 >= len(line) || line[i+1] != ']' {
+				return crane.Crane{}, input.Error(lineIdx, line[i-1:i+1], fmt.Errorf("expected crate like \"[A]\""))
+			}
+			for len(crates) <= stackIdx {
+				crates = append(crates, []rune{})
+			}
+			crates[stackIdx] = append(crates[stackIdx], crate)
 		}
 	}
 	// Get stack ids
 	stacks := map[rune]crane.Stack{}
-	for i, field := range strings.Fields(lines[crateLines]) {
+	ids := strings.Fields(lines[crateLines])
+	if len(ids) < len(crates) {
+		return crane.Crane{}, input.Errorf(crateLines, "", "expected %d stack ids, got %d", len(crates), len(ids))
+	}
+	for i, field := range ids {
+		if len(field) != 1 {
+			return crane.Crane{}, input.Errorf(crateLines, field, "invalid stack id %q", field)
+		}
 		id := rune(field[0])
 		// First item in crate slice is top crate
-		stacks[id] = crane.NewStack(id, crates[i])
+		stackCrates := []rune{}
+		if i < len(crates) {
+			stackCrates = crates[i]
+		}
+		stacks[id] = crane.NewStack(id, stackCrates)
 	}
 	// Crate moves in the remaining lines (after 1 blank)
-	crane := crane.NewCrane(stacks, lines[crateLines+2:])
-	return crane
+	moves := []crane.Move{}
+	for i := crateLines + 2; i < len(lines); i++ {
+		move, err := crane.ParseMove(lines[i])
+		if err != nil {
+			return crane.Crane{}, input.Error(i, "", err)
+		}
+		moves = append(moves, move)
+	}
+	return crane.NewCrane(stacks, moves), nil
+}
+
+// Part 1: CrateMover 9000 - what crate ends up on top of each stack?
+func solvePart1(crane crane.Crane) (string, error) {
+	if err := crane.ExecuteMoves(true); err != nil {
+		return "", err
+	}
+	return crane.GetTopCrates(), nil
+}
+
+// Part 2: CrateMover 9001 - what crate ends up on top of each stack?
+func solvePart2(crane crane.Crane) (string, error) {
+	if err := crane.ExecuteMoves(false); err != nil {
+		return "", err
+	}
+	return crane.GetTopCrates(), nil
+}
+
+// Registers puzzle parts with the aoc runner
+var puzzle = aoc.Puzzle[crane.Crane, string, string]{
+	Year: 2022, Day: 5,
+	ReadInput:  readInput,
+	SolvePart1: aoc.NoContext(solvePart1),
+	SolvePart2: aoc.NoContext(solvePart2),
+	Labels: [2]string{
+		"CrateMover 9000 - Crates on top of each stack",
+		"CrateMover 9001 - Crates on top of each stack",
+	},
+	Gen: &aoc.Generator{
+		Size: 500, SizeHelp: "moves",
+		Generate: generateInput,
+	},
 }
 
 // Reads input and solves puzzle parts
 func main() {
-	// Part 1: CrateMover 9000 - what crate ends up on top of each stack?
-	crane := readInput(inputPath, 8)
-	crane.ExecuteMoves(true)
-	fmt.Printf("CrateMover 9000 - Crates on top of each stack: %v\n", crane.GetTopCrates())
-
-	// Part 2: CrateMover 9001 - what crate ends up on top of each stack?
-	crane = readInput(inputPath, 8)
-	crane.ExecuteMoves(false)
-	fmt.Printf("CrateMover 9001 - Crates on top of each stack: %v\n", crane.GetTopCrates())
+	aoc.Main(puzzle)
 }
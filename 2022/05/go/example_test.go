@@ -3,6 +3,8 @@ package main
 
 import (
 	"testing"
+
+	"github.com/erikzak/adventofcode/2022/aoc"
 )
 
 const testPath = "../test.txt"
@@ -10,21 +12,31 @@ const testPath = "../test.txt"
 // Tests part 1 example data
 func TestPart1Example(t *testing.T) {
 	want := "CMZ"
-	crane := readInput(testPath, 3)
-	crane.ExecuteMoves(true)
-	topCrates := crane.GetTopCrates()
+	crane, err := aoc.ReadFile(testPath, readInput)
+	if err != nil {
+		t.Fatal(err)
+	}
+	topCrates, err := solvePart1(crane)
+	if err != nil {
+		t.Fatal(err)
+	}
 	if topCrates != want {
-		t.Fatalf(`crane.GetTopCrates() = "%v", want "%v"`, topCrates, want)
+		t.Fatalf(`solvePart1() = "%v", want "%v"`, topCrates, want)
 	}
 }
 
 // Tests part 2 example data
 func TestPart2Example(t *testing.T) {
 	want := "MCD"
-	crane := readInput(testPath, 3)
-	crane.ExecuteMoves(false)
-	topCrates := crane.GetTopCrates()
+	crane, err := aoc.ReadFile(testPath, readInput)
+	if err != nil {
+		t.Fatal(err)
+	}
+	topCrates, err := solvePart2(crane)
+	if err != nil {
+		t.Fatal(err)
+	}
 	if topCrates != want {
-		t.Fatalf(`crane.GetTopCrates() = "%v", want "%v"`, topCrates, want)
+		t.Fatalf(`solvePart2() = "%v", want "%v"`, topCrates, want)
 	}
 }
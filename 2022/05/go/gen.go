@@ -0,0 +1,59 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"math/rand"
+	"strings"
+)
+
+// Generates nine random crate stacks and size moves between them. Moves never
+// take more crates than the stack holds
+func generateInput(rng *rand.Rand, size int, w *bufio.Writer) {
+	stacks := make([][]byte, 9)
+	height := 0
+	for i := range stacks {
+		for n := 1 + rng.Intn(8); n > 0; n-- {
+			stacks[i] = append(stacks[i], byte('A'+rng.Intn(26)))
+		}
+		if len(stacks[i]) > height {
+			height = len(stacks[i])
+		}
+	}
+	// Drawing starts with the top row, stacks are listed bottom first
+	for row := height - 1; row >= 0; row-- {
+		cells := make([]string, len(stacks))
+		for i, stack := range stacks {
+			cells[i] = "   "
+			if row < len(stack) {
+				cells[i] = "[" + string(stack[row]) + "]"
+			}
+		}
+		w.WriteString(strings.TrimRight(strings.Join(cells, " "), " ") + "\n")
+	}
+	ids := make([]string, len(stacks))
+	heights := make([]int, len(stacks))
+	for i, stack := range stacks {
+		ids[i] = fmt.Sprint(i + 1)
+		heights[i] = len(stack)
+	}
+	w.WriteString(" " + strings.Join(ids, "   ") + " \n\n")
+
+	for i := 0; i < size; i++ {
+		from := rng.Intn(len(heights))
+		for heights[from] == 0 {
+			from = rng.Intn(len(heights))
+		}
+		to := rng.Intn(len(heights) - 1)
+		if to >= from {
+			to++
+		}
+		count := 1 + rng.Intn(heights[from])
+		if count > 10 {
+			count = 1 + rng.Intn(10)
+		}
+		heights[from] -= count
+		heights[to] += count
+		fmt.Fprintf(w, "move %d from %d to %d\n", count, from+1, to+1)
+	}
+}
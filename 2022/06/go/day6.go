@@ -3,18 +3,13 @@
 package main
 
 import (
-	"log"
-	"os"
+	"io"
+
+	"github.com/erikzak/adventofcode/2022/aoc"
 )
 
 const inputPath = "../input.txt"
 
-func check(err error) {
-	if err != nil {
-		log.Panic(err)
-	}
-}
-
 // Keeps track of radio properties. Has method for finding marker in buffer
 type Radio struct {
 	buffer []byte
@@ -27,7 +22,7 @@ func NewRadio(buffer *[]byte) *Radio {
 
 // Returns buffer length before finding marker of given length
 func (radio *Radio) FindMarker(length int) *int {
-	for i := 0; i < len(radio.buffer)-length; i++ {
+	for i := 0; i <= len(radio.buffer)-length; i++ {
 		bufferPart := make(map[byte]struct{})
 		for j := 0; j < length; j++ {
 			bufferPart[radio.buffer[i+j]] = struct{}{}
@@ -40,12 +35,18 @@ func (radio *Radio) FindMarker(length int) *int {
 	panic("no marker found in buffer")
 }
 
-// Parses puzzle input from txt file.
+// Parses puzzle input from reader.
 // Returns []byte.
-func readInput(path string) *[]byte {
-	inputBytes, err := os.ReadFile(path)
-	check(err)
-	return &inputBytes
+func readInput(r io.Reader) (*[]byte, error) {
+	input, err := aoc.ReadFrom(r)
+	if err != nil {
+		return nil, err
+	}
+	if len(input.Lines) > 1 {
+		return nil, input.Errorf(1, "", "expected datastream on a single line")
+	}
+	inputBytes := []byte(input.Text())
+	return &inputBytes, nil
 }
 
 // Part 1: How many characters need to be processed before the first
@@ -64,16 +65,38 @@ func solvePart2(radio *Radio) *int {
 }
 
 // Solves puzzle parts, split out for benchmarking
-func solvePuzzle() (*int, *int) {
-	inputBytes := readInput(inputPath)
+func solvePuzzle() (*int, *int, error) {
+	inputBytes, err := aoc.ReadFile(inputPath, readInput)
+	if err != nil {
+		return nil, nil, err
+	}
 	radio, answer1 := solvePart1(inputBytes)
 	answer2 := solvePart2(radio)
-	return answer1, answer2
+	return answer1, answer2, nil
+}
+
+// Registers puzzle parts with the aoc runner
+var puzzle = aoc.Puzzle[*[]byte, *int, *int]{
+	Year: 2022, Day: 6,
+	ReadInput: readInput,
+	SolvePart1: aoc.NoError(func(inputBytes *[]byte) *int {
+		_, answer1 := solvePart1(inputBytes)
+		return answer1
+	}),
+	SolvePart2: aoc.NoError(func(inputBytes *[]byte) *int {
+		return solvePart2(NewRadio(inputBytes))
+	}),
+	Labels: [2]string{
+		"Characters processed before 4-length marker",
+		"Characters processed before 14-length marker",
+	},
+	Gen: &aoc.Generator{
+		Size: 4096, MinSize: 14, SizeHelp: "characters",
+		Generate: generateInput,
+	},
 }
 
 // Reads input, solves puzzle parts and logs answers
 func main() {
-	answer1, answer2 := solvePuzzle()
-	log.Printf("Characters processed before 4-length marker: %v\n", *answer1)
-	log.Printf("Characters processed before 14-length marker: %v\n", *answer2)
+	aoc.Main(puzzle)
 }
@@ -3,6 +3,8 @@ package main
 
 import (
 	"testing"
+
+	"github.com/erikzak/adventofcode/2022/aoc"
 )
 
 const testPath = "../test.txt"
@@ -10,7 +12,10 @@ const testPath = "../test.txt"
 // Tests part 1 example data
 func TestPart1Example(t *testing.T) {
 	want := 11
-	inputBytes := readInput(testPath)
+	inputBytes, err := aoc.ReadFile(testPath, readInput)
+	if err != nil {
+		t.Fatal(err)
+	}
 	radio := NewRadio(inputBytes)
 	charactersProcessed := radio.FindMarker(4)
 	if *charactersProcessed != want {
@@ -21,7 +26,10 @@ func TestPart1Example(t *testing.T) {
 // Tests part 2 example data
 func TestPart2Example(t *testing.T) {
 	want := 26
-	inputBytes := readInput(testPath)
+	inputBytes, err := aoc.ReadFile(testPath, readInput)
+	if err != nil {
+		t.Fatal(err)
+	}
 	radio := NewRadio(inputBytes)
 	charactersProcessed := radio.FindMarker(14)
 	if *charactersProcessed != want {
@@ -0,0 +1,25 @@
+package main
+
+import (
+	"bufio"
+	"math/rand"
+)
+
+// Generates random datastream of at least size characters. Only 13 letters
+// are used before the 14 distinct letters at the end, so the start-of-message
+// marker is always the last thing in the buffer
+func generateInput(rng *rand.Rand, size int, w *bufio.Writer) {
+	letters := []byte("abcdefghijklmnopqrstuvwxyz")
+	rng.Shuffle(len(letters), func(i, j int) {
+		letters[i], letters[j] = letters[j], letters[i]
+	})
+	for i := 0; i < size-14; i++ {
+		w.WriteByte(letters[rng.Intn(13)])
+	}
+	marker := letters[:14]
+	rng.Shuffle(len(marker), func(i, j int) {
+		marker[i], marker[j] = marker[j], marker[i]
+	})
+	w.Write(marker)
+	w.WriteString("\n")
+}
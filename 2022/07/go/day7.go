@@ -3,20 +3,13 @@
 package main
 
 import (
-	"log"
-	"os"
-	"strconv"
-	"strings"
+	"io"
+
+	"github.com/erikzak/adventofcode/2022/aoc"
 )
 
 const inputPath = "../input.txt"
 
-func check(err error) {
-	if err != nil {
-		log.Panic(err)
-	}
-}
-
 // Keeps track of folder properties, subfolders, files, total file size and parent folder
 type Folder struct {
 	name       string
@@ -72,32 +65,46 @@ func (folder *Folder) GetTotalSize(maxSize int) (sumTotalSize int) {
 	return sumTotalSize
 }
 
-// Parses puzzle input from txt file.
+// Parses puzzle input from reader.
 // Returns root folder complete with size calculation of content.
-func readInput(path string) (root *Folder) {
-	inputBytes, err := os.ReadFile(path)
-	check(err)
-	lines := strings.Split(string(inputBytes), "\n")
+func readInput(r io.Reader) (root *Folder, err error) {
+	input, err := aoc.ReadFrom(r)
+	if err != nil {
+		return nil, err
+	}
 
 	root = NewFolder("/", nil)
 	cwd := root
-	for _, line := range lines {
-		parts := strings.Fields(line)
+	for i := range input.Lines {
+		parts, err := input.Fields(i, 2)
+		if err != nil {
+			return nil, err
+		}
 		if parts[0] == "$" {
 			if parts[1] == "cd" {
 				// Change directory
+				if len(parts) != 3 {
+					return nil, input.Errorf(i, "cd", "expected cd with a single folder")
+				}
 				if parts[2] == ".." {
+					if cwd.parent == nil {
+						return nil, input.Errorf(i, "..", "root folder has no parent")
+					}
 					cwd = cwd.parent
 				} else if parts[2] == "/" {
 					cwd = root
 				} else {
-					cwd = cwd.subfolders[parts[2]]
+					subfolder, ok := cwd.subfolders[parts[2]]
+					if !ok {
+						return nil, input.Errorf(i, parts[2], "unknown folder %q in %s", parts[2], cwd.name)
+					}
+					cwd = subfolder
 				}
 			} else if parts[1] == "ls" {
 				// Content is processed in next parts
 				continue
 			} else {
-				panic("unknown $ command: " + parts[1])
+				return nil, input.Errorf(i, parts[1], "unknown $ command %q", parts[1])
 			}
 		} else if parts[0] == "dir" {
 			// New subfolder
@@ -105,13 +112,15 @@ func readInput(path string) (root *Folder) {
 			cwd.subfolders[parts[1]] = subfolder
 		} else {
 			// New file
-			cwd.files[parts[1]], err = strconv.Atoi(parts[0])
-			check(err)
+			cwd.files[parts[1]], err = input.Atoi(i, parts[0])
+			if err != nil {
+				return nil, err
+			}
 		}
 	}
 	// Perform initial folder size calculations
 	root.CalculateTotalSize()
-	return root
+	return root, nil
 }
 
 // Part 1: What is the sum of the total sizes of directories with a total size of at most 100000?
@@ -130,16 +139,33 @@ func solvePart2(root *Folder) int {
 }
 
 // Solves puzzle parts. Split up for benchmarking
-func solvePuzzle() (int, int) {
-	root := readInput(inputPath)
+func solvePuzzle() (int, int, error) {
+	root, err := aoc.ReadFile(inputPath, readInput)
+	if err != nil {
+		return 0, 0, err
+	}
 	answer1 := solvePart1(root)
 	answer2 := solvePart2(root)
-	return answer1, answer2
+	return answer1, answer2, nil
+}
+
+// Registers puzzle parts with the aoc runner
+var puzzle = aoc.Puzzle[*Folder, int, int]{
+	Year: 2022, Day: 7,
+	ReadInput:  readInput,
+	SolvePart1: aoc.NoError(solvePart1),
+	SolvePart2: aoc.NoError(solvePart2),
+	Labels: [2]string{
+		"Sum of total sizes of directories at most 100000",
+		"Total size of best folder deletion candidate",
+	},
+	Gen: &aoc.Generator{
+		Size: 200, SizeHelp: "directories",
+		Generate: generateInput,
+	},
 }
 
 // Reads input, solves puzzle parts and logs answers
 func main() {
-	answer1, answer2 := solvePuzzle()
-	log.Printf("Sum of total sizes of directories at most 100000: %v\n", answer1)
-	log.Printf("Total size of best folder deletion candidate: %v\n", answer2)
+	aoc.Main(puzzle)
 }
@@ -3,6 +3,8 @@ package main
 
 import (
 	"testing"
+
+	"github.com/erikzak/adventofcode/2022/aoc"
 )
 
 const testPath = "../test.txt"
@@ -10,7 +12,10 @@ const testPath = "../test.txt"
 // Tests part 1 against example data
 func TestPart1Example(t *testing.T) {
 	want := 95437
-	root := readInput(testPath)
+	root, err := aoc.ReadFile(testPath, readInput)
+	if err != nil {
+		t.Fatal(err)
+	}
 	answer1 := solvePart1(root)
 	if answer1 != want {
 		t.Fatalf(`solvePart1() = %v, want %v`, answer1, want)
@@ -20,7 +25,10 @@ func TestPart1Example(t *testing.T) {
 // Tests part 2 against example data
 func TestPart2Example(t *testing.T) {
 	want := 24933642
-	root := readInput(testPath)
+	root, err := aoc.ReadFile(testPath, readInput)
+	if err != nil {
+		t.Fatal(err)
+	}
 	answer2 := solvePart2(root)
 	if answer2 != want {
 		t.Fatalf(`solvePart2() = %v, want %v`, answer2, want)
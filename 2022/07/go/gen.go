@@ -0,0 +1,53 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"math/rand"
+)
+
+// Directory in a generated file system
+type genFolder struct {
+	name       string
+	subfolders []*genFolder
+}
+
+// Generates terminal output of browsing a random file system with size
+// directories below the root, each holding up to five files
+func generateInput(rng *rand.Rand, size int, w *bufio.Writer) {
+	folders := []*genFolder{{name: "/"}}
+	for i := 0; i < size; i++ {
+		parent := folders[rng.Intn(len(folders))]
+		// Index suffix keeps sibling names unique
+		folder := &genFolder{name: fmt.Sprintf("%s%d", randomName(rng), i)}
+		parent.subfolders = append(parent.subfolders, folder)
+		folders = append(folders, folder)
+	}
+	w.WriteString("$ cd /\n")
+	writeFolder(rng, folders[0], w)
+}
+
+// Writes listing of folder, then changes into each subfolder and back
+func writeFolder(rng *rand.Rand, folder *genFolder, w *bufio.Writer) {
+	w.WriteString("$ ls\n")
+	for _, subfolder := range folder.subfolders {
+		fmt.Fprintf(w, "dir %s\n", subfolder.name)
+	}
+	for i := rng.Intn(6); i > 0; i-- {
+		fmt.Fprintf(w, "%d %s%d.%s\n", 1000+rng.Intn(300000), randomName(rng), i, randomName(rng)[:3])
+	}
+	for _, subfolder := range folder.subfolders {
+		fmt.Fprintf(w, "$ cd %s\n", subfolder.name)
+		writeFolder(rng, subfolder, w)
+		w.WriteString("$ cd ..\n")
+	}
+}
+
+// Returns random lowercase name of 3 to 8 letters
+func randomName(rng *rand.Rand) string {
+	name := make([]byte, 3+rng.Intn(6))
+	for i := range name {
+		name[i] = byte('a' + rng.Intn(26))
+	}
+	return string(name)
+}
@@ -6,38 +6,37 @@
 package main
 
 import (
-	"log"
-	"os"
-	"strconv"
-	"strings"
+	"fmt"
+	"io"
 
 	"github.com/erikzak/adventofcode/2022/8/foresting"
+	"github.com/erikzak/adventofcode/2022/aoc"
+	"github.com/erikzak/adventofcode/2022/aoc/visual"
 )
 
 const inputPath = "../input.txt"
 
-func check(err error) {
+// Parses puzzle input from reader.
+// Returns Forest object initialized with input tree grid of rows and columns
+func readInput(r io.Reader) (forest *foresting.Forest, err error) {
+	input, err := aoc.ReadFrom(r)
 	if err != nil {
-		log.Panic(err)
+		return nil, err
 	}
-}
-
-// Parses puzzle input from txt file.
-// Returns Forest object initialized with input tree grid of rows and columns
-func readInput(path string) (forest *foresting.Forest) {
-	inputBytes, err := os.ReadFile(path)
-	check(err)
-	lines := strings.Split(string(inputBytes), "\n")
+	lines := input.Lines
 
 	// Rows from input
 	rows := make([][]*foresting.Tree, len(lines))
 	for i, line := range lines {
-		line = strings.Trim(line, "\n")
+		if len(line) != len(lines[0]) {
+			return nil, input.Errorf(i, "", "expected row of %d trees, got %d", len(lines[0]), len(line))
+		}
 		row := make([]*foresting.Tree, len(line))
 		for j, value := range line {
-			height, err := strconv.Atoi(string(value))
-			check(err)
-			row[j] = foresting.NewTree(height)
+			if value < '0' || value > '9' {
+				return nil, input.ErrorAt(i, j, fmt.Errorf("invalid tree height %q", value))
+			}
+			row[j] = foresting.NewTree(int(value - '0'))
 		}
 		rows[i] = row
 	}
@@ -45,7 +44,7 @@ func readInput(path string) (forest *foresting.Forest) {
 	nCols := len(rows[0])
 	columns := make([][]*foresting.Tree, nCols)
 	for colIdx := 0; colIdx < nCols; colIdx++ {
-		column := make([]*foresting.Tree, nCols)
+		column := make([]*foresting.Tree, len(rows))
 		for i, row := range rows {
 			column[i] = row[colIdx]
 		}
@@ -53,7 +52,7 @@ func readInput(path string) (forest *foresting.Forest) {
 	}
 
 	forest = foresting.NewForest(rows, columns)
-	return forest
+	return forest, nil
 }
 
 // Part 1: how many trees are visible from outside the grid?
@@ -69,16 +68,37 @@ func solvePart2(forest *foresting.Forest) int {
 }
 
 // Solves puzzle parts. Split up for benchmarking
-func solvePuzzle() (int, int) {
-	root := readInput(inputPath)
+func solvePuzzle() (int, int, error) {
+	root, err := aoc.ReadFile(inputPath, readInput)
+	if err != nil {
+		return 0, 0, err
+	}
 	answer1 := solvePart1(root)
 	answer2 := solvePart2(root)
-	return answer1, answer2
+	return answer1, answer2, nil
+}
+
+// Registers puzzle parts with the aoc runner
+var puzzle = aoc.Puzzle[*foresting.Forest, int, int]{
+	Year: 2022, Day: 8,
+	ReadInput:  readInput,
+	SolvePart1: aoc.NoError(solvePart1),
+	SolvePart2: aoc.NoError(solvePart2),
+	Labels: [2]string{
+		"Trees visible from outside the grid",
+		"Highest scenic score possible",
+	},
+	Gen: &aoc.Generator{
+		Size: 99, SizeHelp: "trees per side",
+		Generate: generateInput,
+	},
+	Animate: func(forest *foresting.Forest, animation *visual.Animation) *foresting.Forest {
+		forest.SetAnimation(animation)
+		return forest
+	},
 }
 
 // Reads input, solves puzzle parts and logs answers
 func main() {
-	answer1, answer2 := solvePuzzle()
-	log.Printf("Trees visible from outside the grid: %v\n", answer1)
-	log.Printf("Highest scenic score possible: %v\n", answer2)
+	aoc.Main(puzzle)
 }
@@ -2,7 +2,11 @@
 package main
 
 import (
+	"context"
 	"testing"
+
+	"github.com/erikzak/adventofcode/2022/aoc"
+	"github.com/erikzak/adventofcode/2022/aoc/visual"
 )
 
 const testPath = "../test.txt"
@@ -10,7 +14,10 @@ const testPath = "../test.txt"
 // Tests part 1 against example data
 func TestPart1Example(t *testing.T) {
 	want := 21
-	input := readInput(testPath)
+	input, err := aoc.ReadFile(testPath, readInput)
+	if err != nil {
+		t.Fatal(err)
+	}
 	answer1 := solvePart1(input)
 	if answer1 != want {
 		t.Fatalf(`solvePart1() = %v, want %v`, answer1, want)
@@ -20,9 +27,31 @@ func TestPart1Example(t *testing.T) {
 // Tests part 2 against example data
 func TestPart2Example(t *testing.T) {
 	want := 8
-	input := readInput(testPath)
+	input, err := aoc.ReadFile(testPath, readInput)
+	if err != nil {
+		t.Fatal(err)
+	}
 	answer2 := solvePart2(input)
 	if answer2 != want {
 		t.Fatalf(`solvePart2() = %v, want %v`, answer2, want)
 	}
 }
+
+// Tests that solving part 2 animated records frames and gives the same answer
+func TestAnimatedExample(t *testing.T) {
+	source, err := aoc.LoadSource(testPath, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	recorder := visual.NewRecorder(visual.ExportOptions{})
+	answer, err := puzzle.SolveAnimated(context.Background(), 2, source.Reader(), visual.NewRecording(recorder))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if answer != "8" {
+		t.Fatalf(`SolveAnimated() = %v, want 8`, answer)
+	}
+	if recorder.Frames() == 0 {
+		t.Fatal(`SolveAnimated() recorded no frames`)
+	}
+}
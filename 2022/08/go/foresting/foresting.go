@@ -1,10 +1,24 @@
 package foresting
 
+import (
+	"fmt"
+
+	"github.com/erikzak/adventofcode/2022/aoc/grid"
+	"github.com/erikzak/adventofcode/2022/aoc/visual"
+)
+
 // Keeps track of forest grid, with rows and columns of trees
 type Forest struct {
 	rows         [][]*Tree
 	columns      [][]*Tree
 	visibleTrees int
+
+	// Animation state: trees checked by the calculation running, and the
+	// tree with the highest scenic score so far
+	animation *visual.Animation
+	scoring   bool
+	checked   int
+	best      *Tree
 }
 
 func NewForest(rows [][]*Tree, columns [][]*Tree) *Forest {
@@ -20,15 +34,23 @@ func NewForest(rows [][]*Tree, columns [][]*Tree) *Forest {
 	return &forest
 }
 
+// Animates calculations with animation, one step per tree
+func (forest *Forest) SetAnimation(animation *visual.Animation) {
+	forest.animation = animation
+}
+
 // Calculates tree visibility for all trees in the forest
 func (forest *Forest) CalculateTreeVisibility() int {
 	forest.visibleTrees = 0
+	forest.scoring, forest.checked = false, 0
 	for _, row := range forest.rows {
 		for _, tree := range row {
 			tree.CalculateVisibility()
 			if tree.isVisible {
 				forest.visibleTrees++
 			}
+			forest.checked++
+			forest.animation.Step(forest)
 		}
 	}
 	return forest.visibleTrees
@@ -36,17 +58,58 @@ func (forest *Forest) CalculateTreeVisibility() int {
 
 // Calculates scenic score for all trees in the forest
 func (forest *Forest) CalculateTreeScenicScores() (highestScenicScore int) {
+	forest.scoring, forest.checked, forest.best = true, 0, nil
 	for _, row := range forest.rows {
 		for _, tree := range row {
 			tree.CalculateScenicScore()
-			if tree.scenicScore > highestScenicScore {
+			if tree.scenicScore > highestScenicScore || forest.best == nil {
 				highestScenicScore = tree.scenicScore
+				forest.best = tree
 			}
+			forest.checked++
+			forest.animation.Step(forest)
 		}
 	}
 	return highestScenicScore
 }
 
+// Draws trees as their heights until checked. Checked trees are drawn as ^
+// if visible from outside the grid and . if not, or, when scoring, as . with
+// the best scenic spot so far as *. The last tree checked is drawn as @
+func (forest *Forest) Frame() visual.Frame {
+	width := 0
+	if len(forest.rows) > 0 {
+		width = len(forest.rows[0])
+	}
+	current := grid.Point{}
+	if forest.checked > 0 && width > 0 {
+		current = grid.Point{(forest.checked - 1) % width, (forest.checked - 1) / width}
+	}
+	title := fmt.Sprintf("Trees visible from outside: %d", forest.visibleTrees)
+	if forest.scoring && forest.best != nil {
+		title = fmt.Sprintf("Highest scenic score: %d", forest.best.scenicScore)
+	}
+	return visual.Frame{
+		Title:  title,
+		Bounds: grid.Rect{Max: grid.Point{width - 1, len(forest.rows) - 1}},
+		Glyph: func(p grid.Point) rune {
+			tree := forest.rows[p.Y()][p.X()]
+			switch {
+			case forest.checked > 0 && p == current:
+				return '@'
+			case p.Y()*width+p.X() >= forest.checked:
+				return rune('0' + tree.height)
+			case forest.scoring && tree == forest.best:
+				return '*'
+			case !forest.scoring && tree.isVisible:
+				return '^'
+			}
+			return '.'
+		},
+		Focus: current,
+	}
+}
+
 // Keeps track of tree attributes, its row and column in the forest and calculated visibility
 type Tree struct {
 	height      int
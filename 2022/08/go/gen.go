@@ -0,0 +1,16 @@
+package main
+
+import (
+	"bufio"
+	"math/rand"
+)
+
+// Generates random square tree grid with sides of size trees
+func generateInput(rng *rand.Rand, size int, w *bufio.Writer) {
+	for y := 0; y < size; y++ {
+		for x := 0; x < size; x++ {
+			w.WriteByte(byte('0' + rng.Intn(10)))
+		}
+		w.WriteString("\n")
+	}
+}
@@ -4,32 +4,36 @@ package main
 
 import (
 	"fmt"
-	"log"
+	"io"
 	"math"
-	"os"
-	"strconv"
 	"strings"
+
+	"github.com/erikzak/adventofcode/2022/aoc"
+	"github.com/erikzak/adventofcode/2022/aoc/grid"
+	"github.com/erikzak/adventofcode/2022/aoc/visual"
 )
 
 const inputPath = "../input.txt"
 
-func check(err error) {
-	if err != nil {
-		log.Panic(err)
-	}
-}
-
 // ---------------------------------------------------------------------------
 // Keeps track of rope knots, list of moves and locations visited.
 // Implements methods for moving head and knots.
 type Rope struct {
 	knots           []*Knot
-	moves           []string
-	tailLocsVisited map[string]struct{}
+	moves           []Move
+	tailLocsVisited map[grid.Point]struct{}
+	bounds          grid.Rect // Area covered by any knot so far, for drawing
+	animation       *visual.Animation
+}
+
+// Head move of a number of steps in direction U, D, L or R
+type Move struct {
+	direction string
+	steps     int
 }
 
 // Inits new rope object with moves
-func NewRope(moves []string) *Rope {
+func NewRope(moves []Move) *Rope {
 	rope := Rope{moves: moves}
 	return &rope
 }
@@ -41,7 +45,8 @@ func (rope *Rope) reset(length int) {
 		rope.knots = append(rope.knots, NewKnot())
 	}
 	rope.knots[length-1].isTail = true
-	rope.tailLocsVisited = map[string]struct{}{}
+	rope.tailLocsVisited = map[grid.Point]struct{}{}
+	rope.bounds = grid.Rect{}
 	rope.addTailLoc()
 }
 
@@ -49,15 +54,17 @@ func (rope *Rope) reset(length int) {
 func (rope *Rope) executeMoves(length int) int {
 	rope.reset(length)
 	for _, move := range rope.moves {
-		instructions := strings.Split(move, " ")
-		direction := instructions[0]
-		length, err := strconv.Atoi(instructions[1])
-		check(err)
-		for step := 0; step < length; step++ {
-			rope.moveHead(direction)
+		for step := 0; step < move.steps; step++ {
+			rope.moveHead(move.direction)
 			for i := 1; i < len(rope.knots); i++ {
 				rope.follow(rope.knots[i], *rope.knots[i-1])
 			}
+			if rope.animation != nil {
+				for _, knot := range rope.knots {
+					rope.bounds = rope.bounds.Extend(grid.Point{knot.x, knot.y})
+				}
+				rope.animation.Step(rope)
+			}
 		}
 	}
 	return len(rope.tailLocsVisited)
@@ -105,8 +112,41 @@ func (rope *Rope) follow(knot *Knot, target Knot) {
 // Adds current rope tail to map of visited locations
 func (rope *Rope) addTailLoc() {
 	tail := rope.knots[len(rope.knots)-1]
-	key := fmt.Sprint(tail.x) + ":" + fmt.Sprint(tail.y)
-	rope.tailLocsVisited[key] = struct{}{}
+	rope.tailLocsVisited[grid.Point{tail.x, tail.y}] = struct{}{}
+}
+
+// Draws knots and the positions visited by the tail, following the head
+func (rope *Rope) Frame() visual.Frame {
+	knots := map[grid.Point]rune{}
+	// Knots in front are drawn on top of the ones behind
+	for i := len(rope.knots) - 1; i >= 0; i-- {
+		glyph := rune('0' + i)
+		if i == 0 {
+			glyph = 'H'
+		} else if len(rope.knots) == 2 {
+			glyph = 'T'
+		}
+		knots[grid.Point{rope.knots[i].x, rope.knots[i].y}] = glyph
+	}
+	head := rope.knots[0]
+	return visual.Frame{
+		Title:  fmt.Sprintf("Positions visited by tail: %d", len(rope.tailLocsVisited)),
+		Bounds: rope.bounds,
+		Glyph: func(p grid.Point) rune {
+			if glyph, ok := knots[p]; ok {
+				return glyph
+			}
+			if p == (grid.Point{}) {
+				return 's'
+			}
+			if _, visited := rope.tailLocsVisited[p]; visited {
+				return '#'
+			}
+			return '.'
+		},
+		Up:    true,
+		Focus: grid.Point{head.x, head.y},
+	}
 }
 
 // Calculates distance between two points using dx and dy
@@ -135,14 +175,31 @@ func (knot *Knot) getDist(target Knot) (dx, dy int) {
 }
 
 // ---------------------------------------------------------------------------
-// Parses puzzle input from txt file.
+// Parses puzzle input from reader.
 // Returns rope instance with list of moves
-func readInput(path string) (rope *Rope) {
-	inputBytes, err := os.ReadFile(path)
-	check(err)
-	moves := strings.Split(string(inputBytes), "\n")
+func readInput(r io.Reader) (rope *Rope, err error) {
+	input, err := aoc.ReadFrom(r)
+	if err != nil {
+		return nil, err
+	}
+	moves := make([]Move, len(input.Lines))
+	for i := range input.Lines {
+		instructions, err := input.Split(i, " ", 2)
+		if err != nil {
+			return nil, err
+		}
+		direction := instructions[0]
+		if !strings.Contains("UDLR", direction) || len(direction) != 1 {
+			return nil, input.Errorf(i, direction, "invalid direction %q", direction)
+		}
+		steps, err := input.Atoi(i, instructions[1])
+		if err != nil {
+			return nil, err
+		}
+		moves[i] = Move{direction: direction, steps: steps}
+	}
 	rope = NewRope(moves)
-	return rope
+	return rope, nil
 }
 
 // Part 1: How many positions does the tail of the rope(2) visit at least once?
@@ -160,16 +217,37 @@ func solvePart2(rope *Rope) (tailLocsVisited int) {
 }
 
 // Solves puzzle parts. Split up for benchmarking
-func solvePuzzle() (int, int) {
-	input := readInput(inputPath)
+func solvePuzzle() (int, int, error) {
+	input, err := aoc.ReadFile(inputPath, readInput)
+	if err != nil {
+		return 0, 0, err
+	}
 	answer1 := solvePart1(input)
 	answer2 := solvePart2(input)
-	return answer1, answer2
+	return answer1, answer2, nil
+}
+
+// Registers puzzle parts with the aoc runner
+var puzzle = aoc.Puzzle[*Rope, int, int]{
+	Year: 2022, Day: 9,
+	ReadInput:  readInput,
+	SolvePart1: aoc.NoError(solvePart1),
+	SolvePart2: aoc.NoError(solvePart2),
+	Labels: [2]string{
+		"Positions visited with rope length 2",
+		"Positions visited with rope length 10",
+	},
+	Gen: &aoc.Generator{
+		Size: 2000, SizeHelp: "moves",
+		Generate: generateInput,
+	},
+	Animate: func(rope *Rope, animation *visual.Animation) *Rope {
+		rope.animation = animation
+		return rope
+	},
 }
 
 // Reads input, solves puzzle parts and logs answers
 func main() {
-	answer1, answer2 := solvePuzzle()
-	log.Printf("Positions visited with rope length 2: %v\n", answer1)
-	log.Printf("Positions visited with rope length 10: %v\n", answer2)
+	aoc.Main(puzzle)
 }
@@ -0,0 +1,12 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/erikzak/adventofcode/2022/aoc/aoctest"
+)
+
+// Fuzzes input parsing, seeded with example data
+func FuzzReadInput(f *testing.F) {
+	aoctest.FuzzInput(f, "../test1.txt", readInput)
+}
@@ -0,0 +1,14 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"math/rand"
+)
+
+// Generates size random head moves of 1 to 20 steps
+func generateInput(rng *rand.Rand, size int, w *bufio.Writer) {
+	for i := 0; i < size; i++ {
+		fmt.Fprintf(w, "%c %d\n", "UDLR"[rng.Intn(4)], 1+rng.Intn(20))
+	}
+}
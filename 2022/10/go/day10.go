@@ -3,31 +3,33 @@
 package main
 
 import (
-	"log"
-	"os"
-	"strings"
+	"errors"
+	"io"
 
 	"github.com/erikzak/adventofcode/2022/10/handheld"
+	"github.com/erikzak/adventofcode/2022/aoc"
 )
 
 const inputPath = "../input.txt"
 
-func check(err error) {
+// Parses puzzle input from reader.
+// Returns device instance with instructions executed
+func readInput(r io.Reader) (device *handheld.Device, err error) {
+	input, err := aoc.ReadFrom(r)
 	if err != nil {
-		log.Panic(err)
+		return nil, err
 	}
-}
-
-// Parses puzzle input from txt file.
-// Returns device instance with instructions executed
-func readInput(path string) (device *handheld.Device) {
-	inputBytes, err := os.ReadFile(path)
-	check(err)
-	instructions := strings.Split(strings.ReplaceAll(string(inputBytes), "\r\n", "\n"), "\n")
-	device = handheld.NewDevice(instructions, 6, 40)
+	device = handheld.NewDevice(input.Lines, 6, 40)
 	interestingCycles := []int{20, 60, 100, 140, 180, 220}
-	device.ExecuteInstructions(interestingCycles)
-	return device
+	if err := device.ExecuteInstructions(interestingCycles); err != nil {
+		// Point instruction errors at their input line
+		var instructionError *handheld.InstructionError
+		if errors.As(err, &instructionError) {
+			return nil, input.Error(instructionError.Index, "", instructionError.Err)
+		}
+		return nil, err
+	}
+	return device, nil
 }
 
 // Part 1: What is the sum of these six signal strengths?
@@ -41,19 +43,30 @@ func solvePart2(device *handheld.Device) []string {
 }
 
 // Solves puzzle parts. Split up for benchmarking
-func solvePuzzle() (int, []string) {
-	input := readInput(inputPath)
+func solvePuzzle() (int, []string, error) {
+	input, err := aoc.ReadFile(inputPath, readInput)
+	if err != nil {
+		return 0, nil, err
+	}
 	answer1 := solvePart1(input)
 	answer2 := solvePart2(input)
-	return answer1, answer2
+	return answer1, answer2, nil
+}
+
+// Registers puzzle parts with the aoc runner
+var puzzle = aoc.Puzzle[*handheld.Device, int, []string]{
+	Year: 2022, Day: 10,
+	ReadInput:  readInput,
+	SolvePart1: aoc.NoError(solvePart1),
+	SolvePart2: aoc.NoError(solvePart2),
+	Labels: [2]string{
+		"Sum of six signal strengths",
+		"Eight capital letters appear",
+	},
+	Gen: &aoc.Generator{Generate: generateInput},
 }
 
 // Reads input, solves puzzle parts and logs answers
 func main() {
-	answer1, answer2 := solvePuzzle()
-	log.Printf("Sum of six signal strengths: %v\n", answer1)
-	log.Print("Eight capital letters appear:\n")
-	for _, line := range answer2 {
-		log.Printf("\t%v\n", line)
-	}
+	aoc.Main(puzzle)
 }
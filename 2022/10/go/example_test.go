@@ -4,12 +4,17 @@ package main
 import (
 	"strings"
 	"testing"
+
+	"github.com/erikzak/adventofcode/2022/aoc"
 )
 
 // Tests part 1 against example data
 func TestPart1Example(t *testing.T) {
 	want := 13140
-	input := readInput("../test.txt")
+	input, err := aoc.ReadFile("../test.txt", readInput)
+	if err != nil {
+		t.Fatal(err)
+	}
 	answer1 := solvePart1(input)
 	if answer1 != want {
 		t.Fatalf(`solvePart1() = %v, want %v`, answer1, want)
@@ -26,7 +31,10 @@ func TestPart2Example(t *testing.T) {
 		"######......######......######......####",
 		"#######.......#######.......#######.....",
 	}
-	input := readInput("../test.txt")
+	input, err := aoc.ReadFile("../test.txt", readInput)
+	if err != nil {
+		t.Fatal(err)
+	}
 	answer2 := solvePart2(input)
 	if strings.Join(answer2, ",") != strings.Join(want, ",") {
 		t.Fatalf(`solvePart2() = %v, want %v`, answer2, want)
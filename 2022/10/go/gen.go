@@ -0,0 +1,27 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"math/rand"
+)
+
+// Generates random program running exactly one cycle per CRT pixel, keeping
+// the sprite on screen. Program size is fixed by the screen
+func generateInput(rng *rand.Rand, size int, w *bufio.Writer) {
+	x := 1
+	for cycle := 0; cycle < 6*40; {
+		if 6*40-cycle < 2 || rng.Intn(3) == 0 {
+			w.WriteString("noop\n")
+			cycle++
+			continue
+		}
+		value := 0
+		for value == 0 || x+value < -1 || x+value > 40 {
+			value = rng.Intn(21) - 10
+		}
+		x += value
+		fmt.Fprintf(w, "addx %d\n", value)
+		cycle += 2
+	}
+}
@@ -1,15 +1,5 @@
 package handheld
 
-import (
-	"log"
-)
-
-func check(err error) {
-	if err != nil {
-		log.Panic(err)
-	}
-}
-
 // Keeps track of clock circuit register and sum of interesting cycles
 type ClockCircuit struct {
 	register           int
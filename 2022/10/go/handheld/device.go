@@ -1,10 +1,26 @@
 package handheld
 
 import (
+	"fmt"
 	"strconv"
 	"strings"
 )
 
+// Error executing instruction at index
+type InstructionError struct {
+	Index       int
+	Instruction string
+	Err         error
+}
+
+func (e *InstructionError) Error() string {
+	return fmt.Sprintf("instruction %d %q: %v", e.Index+1, e.Instruction, e.Err)
+}
+
+func (e *InstructionError) Unwrap() error {
+	return e.Err
+}
+
 // Handheld device. Keeps track of clock circuit and screen.
 // Implements methods for executing instructions for modifying clock circuit
 // register and screen updates
@@ -29,18 +45,32 @@ func (device *Device) reset(interestingCycles []int) {
 	device.clockCircuit.sumSignalStrengths = 0
 }
 
-// Executes instructions
-func (device *Device) ExecuteInstructions(interestingCycles []int) {
+// Executes instructions. Fails on unknown instructions, or when running
+// past the last screen pixel
+func (device *Device) ExecuteInstructions(interestingCycles []int) error {
 	device.reset(interestingCycles)
-	for _, command := range device.instructions {
-		if command == "noop" {
+	for i, command := range device.instructions {
+		cycles := 1
+		fields := strings.Fields(command)
+		if len(fields) == 2 && fields[0] == "addx" {
+			cycles = 2
+		} else if len(fields) != 1 || fields[0] != "noop" {
+			return &InstructionError{Index: i, Instruction: command, Err: fmt.Errorf("unknown instruction")}
+		}
+		if device.clockCircuit.cycle+cycles-1 > device.screen.width*device.screen.height {
+			return &InstructionError{Index: i, Instruction: command, Err: fmt.Errorf("screen has no pixels left to draw")}
+		}
+		if cycles == 1 {
 			device.noop()
-		} else {
-			value, err := strconv.Atoi(strings.Split(command, " ")[1])
-			check(err)
-			device.addx(value)
+			continue
+		}
+		value, err := strconv.Atoi(fields[1])
+		if err != nil {
+			return &InstructionError{Index: i, Instruction: command, Err: fmt.Errorf("invalid value %q", fields[1])}
 		}
+		device.addx(value)
 	}
+	return nil
 }
 
 // noop command: takes one cycle to complete, no other effect
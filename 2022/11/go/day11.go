@@ -3,41 +3,65 @@
 package main
 
 import (
-	"log"
-	"os"
+	"errors"
+	"io"
 	"sort"
 	"strings"
 
 	"github.com/erikzak/adventofcode/2022/11/simians"
+	"github.com/erikzak/adventofcode/2022/aoc"
 )
 
 const inputPath = "../input.txt"
 
-func check(err error) {
+// Parses puzzle input from reader.
+// Returns a troop of monkeys
+func readInput(r io.Reader) (troop *simians.Troop, err error) {
+	input, err := aoc.ReadFrom(r)
 	if err != nil {
-		log.Panic(err)
+		return nil, err
 	}
-}
-
-// Parses puzzle input from txt file.
-// Returns a troop of monkeys
-func readInput(path string) (troop *simians.Troop) {
-	inputBytes, err := os.ReadFile(path)
-	check(err)
-	inputString := string(inputBytes)
-	monkeyParts := strings.Split(strings.ReplaceAll(inputString, "\r\n", "\n"), "\n\n")
+	blocks := input.Blocks()
 
 	monkeys := []*simians.Monkey{}
-	for _, monkeyPart := range monkeyParts {
-		lines := strings.Split(monkeyPart, "\n")
-		items := strings.Split(lines[1], ": ")[1]
-		operation := strings.Split(lines[2], ": ")[1]
-		test := strings.Split(lines[3], ": ")[1]
-		onTrue := strings.Split(lines[4], ": ")[1]
-		onFalse := strings.Split(lines[5], ": ")[1]
-		monkeys = append(monkeys, simians.NewMonkey(items, operation, test, onTrue, onFalse))
+	for _, block := range blocks {
+		if len(block.Lines) != 6 {
+			return nil, input.Errorf(block.Start, "", "expected monkey description of 6 lines, got %d", len(block.Lines))
+		}
+		// Field values after the colon, first line is the monkey header
+		fields := make([]string, 5)
+		for i, line := range block.Lines[1:] {
+			_, value, found := strings.Cut(line, ":")
+			if !found {
+				return nil, input.Errorf(block.Start+1+i, "", "expected field like \"Test: divisible by 23\"")
+			}
+			fields[i] = strings.TrimSpace(value)
+		}
+		monkey, err := simians.NewMonkey(fields[0], fields[1], fields[2], fields[3], fields[4])
+		if err != nil {
+			// Point field errors at their input line
+			var fieldError *simians.FieldError
+			if errors.As(err, &fieldError) {
+				return nil, input.Error(block.Start+1+fieldError.Field, fieldError.Value, fieldError.Err)
+			}
+			return nil, input.Error(block.Start, "", err)
+		}
+		monkeys = append(monkeys, monkey)
+	}
+	// Monkeys can only throw to other monkeys in the troop
+	for i, monkey := range monkeys {
+		onTrue, onFalse := monkey.Targets()
+		targets := []struct{ field, monkey int }{
+			{simians.OnTrueField, onTrue},
+			{simians.OnFalseField, onFalse},
+		}
+		for _, target := range targets {
+			if target.monkey < 0 || target.monkey >= len(monkeys) || target.monkey == i {
+				return nil, input.Errorf(blocks[i].Start+1+target.field, "", "invalid target monkey %d", target.monkey)
+			}
+		}
 	}
-	return simians.NewTroop(monkeys)
+	return simians.NewTroop(monkeys), nil
 }
 
 func sortMonkeysByInspectionCount(troop *simians.Troop) {
@@ -64,17 +88,37 @@ func solvePart2(troop *simians.Troop) (monkeyBusinessLevel int) {
 }
 
 // Solves puzzle parts. Split up for benchmarking
-func solvePuzzle() (int, int) {
-	input := readInput(inputPath)
+func solvePuzzle() (int, int, error) {
+	input, err := aoc.ReadFile(inputPath, readInput)
+	if err != nil {
+		return 0, 0, err
+	}
 	answer1 := solvePart1(input)
-	input = readInput(inputPath)
+	input, err = aoc.ReadFile(inputPath, readInput)
+	if err != nil {
+		return 0, 0, err
+	}
 	answer2 := solvePart2(input)
-	return answer1, answer2
+	return answer1, answer2, nil
+}
+
+// Registers puzzle parts with the aoc runner
+var puzzle = aoc.Puzzle[*simians.Troop, int, int]{
+	Year: 2022, Day: 11,
+	ReadInput:  readInput,
+	SolvePart1: aoc.NoError(solvePart1),
+	SolvePart2: aoc.NoError(solvePart2),
+	Labels: [2]string{
+		"Monkey business after 20 rounds",
+		"Monkey business after 10000 rounds",
+	},
+	Gen: &aoc.Generator{
+		Size: 8, MinSize: 2, MaxSize: 9, SizeHelp: "monkeys",
+		Generate: generateInput,
+	},
 }
 
 // Reads input, solves puzzle parts and logs answers
 func main() {
-	answer1, answer2 := solvePuzzle()
-	log.Printf("Monkey business after 20 rounds: %v\n", answer1)
-	log.Printf("Monkey business after 10000 rounds: %v\n", answer2)
+	aoc.Main(puzzle)
 }
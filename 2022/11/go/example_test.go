@@ -3,12 +3,17 @@ package main
 
 import (
 	"testing"
+
+	"github.com/erikzak/adventofcode/2022/aoc"
 )
 
 // Tests part 1 against example data
 func TestPart1Example(t *testing.T) {
 	want := 10605
-	input := readInput("../test.txt")
+	input, err := aoc.ReadFile("../test.txt", readInput)
+	if err != nil {
+		t.Fatal(err)
+	}
 	answer1 := solvePart1(input)
 	if answer1 != want {
 		t.Fatalf(`solvePart1() = %v, want %v`, answer1, want)
@@ -18,7 +23,10 @@ func TestPart1Example(t *testing.T) {
 // Tests part 2 against example data
 func TestPart2Example(t *testing.T) {
 	want := 2713310158
-	input := readInput("../test.txt")
+	input, err := aoc.ReadFile("../test.txt", readInput)
+	if err != nil {
+		t.Fatal(err)
+	}
 	answer2 := solvePart2(input)
 	if answer2 != want {
 		t.Fatalf(`solvePart2() = %v, want %v`, answer2, want)
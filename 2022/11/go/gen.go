@@ -0,0 +1,55 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"math/rand"
+	"strings"
+)
+
+// Divisibility tests, distinct primes. Worry levels are kept modulo their
+// product, which must stay small enough to square without overflow
+var testPrimes = []int{2, 3, 5, 7, 11, 13, 17, 19, 23}
+
+// Generates troop of size monkeys with random items, operations and tests
+func generateInput(rng *rand.Rand, size int, w *bufio.Writer) {
+	primes := append([]int{}, testPrimes...)
+	rng.Shuffle(len(primes), func(i, j int) {
+		primes[i], primes[j] = primes[j], primes[i]
+	})
+	for i := 0; i < size; i++ {
+		if i > 0 {
+			w.WriteString("\n")
+		}
+		items := make([]string, 1+rng.Intn(6))
+		for j := range items {
+			items[j] = fmt.Sprint(50 + rng.Intn(50))
+		}
+		operation := fmt.Sprintf("old + %d", 1+rng.Intn(8))
+		switch rng.Intn(4) {
+		case 0:
+			operation = "old * old"
+		case 1:
+			operation = fmt.Sprintf("old * %d", 2+rng.Intn(18))
+		}
+		onTrue, onFalse := otherMonkey(rng, size, i), otherMonkey(rng, size, i)
+		for size > 2 && onFalse == onTrue {
+			onFalse = otherMonkey(rng, size, i)
+		}
+		fmt.Fprintf(w, "Monkey %d:\n", i)
+		fmt.Fprintf(w, "  Starting items: %s\n", strings.Join(items, ", "))
+		fmt.Fprintf(w, "  Operation: new = %s\n", operation)
+		fmt.Fprintf(w, "  Test: divisible by %d\n", primes[i])
+		fmt.Fprintf(w, "    If true: throw to monkey %d\n", onTrue)
+		fmt.Fprintf(w, "    If false: throw to monkey %d\n", onFalse)
+	}
+}
+
+// Returns random monkey index other than self
+func otherMonkey(rng *rand.Rand, size int, self int) int {
+	other := rng.Intn(size - 1)
+	if other >= self {
+		other++
+	}
+	return other
+}
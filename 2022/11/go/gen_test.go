@@ -0,0 +1,12 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/erikzak/adventofcode/2022/aoc/aoctest"
+)
+
+// Tests that generated input parses and solves
+func TestGeneratedInput(t *testing.T) {
+	aoctest.GeneratedInput(t, puzzle, readInput)
+}
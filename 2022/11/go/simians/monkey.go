@@ -1,22 +1,46 @@
 package simians
 
 import (
-	"log"
+	"fmt"
 	"strconv"
 	"strings"
 )
 
-func check(err error) {
-	if err != nil {
-		log.Panic(err)
-	}
+// Monkey description fields, in input order
+const (
+	ItemsField = iota
+	OperationField
+	TestField
+	OnTrueField
+	OnFalseField
+)
+
+// Error in one of the monkey description fields
+type FieldError struct {
+	Field int
+	Value string
+	Err   error
+}
+
+func (e *FieldError) Error() string {
+	return fmt.Sprintf("%q: %v", e.Value, e.Err)
 }
 
-// Returns int of string
-func stringToInt(str string) int {
-	value, err := strconv.Atoi(str)
-	check(err)
-	return value
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
+// Returns int of the word at index in field value
+func parseWord(field int, value string, index int) (int, error) {
+	words := strings.Split(value, " ")
+	if index >= len(words) {
+		return 0, &FieldError{Field: field, Value: value, Err: fmt.Errorf("expected at least %d words", index+1)}
+	}
+	number, err := strconv.Atoi(words[index])
+	if err != nil {
+		return 0, &FieldError{Field: field, Value: value, Err: fmt.Errorf("invalid number %q", words[index])}
+	}
+	return number, nil
 }
 
 // Keeps track of monkey items and round logic
@@ -29,27 +53,48 @@ type Monkey struct {
 	InspectionCount int
 }
 
-// Inits new monkey based on input text
+// Inits new monkey based on input text. Returns *FieldError pointing out the
+// offending field if the description is malformed
 func NewMonkey(
 	itemStr string, operationStr string,
-	testStr string, onTrueStr string, onFalseStr string) *Monkey {
+	testStr string, onTrueStr string, onFalseStr string) (*Monkey, error) {
 	// Items
 	items := []int{}
-	for _, itemValue := range strings.Split(itemStr, ", ") {
-		value := stringToInt(itemValue)
-		items = append(items, value)
+	if itemStr != "" {
+		for _, itemValue := range strings.Split(itemStr, ", ") {
+			value, err := strconv.Atoi(itemValue)
+			if err != nil {
+				return nil, &FieldError{Field: ItemsField, Value: itemStr, Err: fmt.Errorf("invalid item %q", itemValue)}
+			}
+			items = append(items, value)
+		}
 	}
 	// On true/false test values
-	onTrue := stringToInt((strings.Split(onTrueStr, " ")[3]))
-	onFalse := stringToInt((strings.Split(onFalseStr, " ")[3]))
+	onTrue, err := parseWord(OnTrueField, onTrueStr, 3)
+	if err != nil {
+		return nil, err
+	}
+	onFalse, err := parseWord(OnFalseField, onFalseStr, 3)
+	if err != nil {
+		return nil, err
+	}
 	// Test value
-	testValue := stringToInt(strings.Split(testStr, " ")[2])
+	testValue, err := parseWord(TestField, testStr, 2)
+	if err != nil {
+		return nil, err
+	}
+	if testValue == 0 {
+		return nil, &FieldError{Field: TestField, Value: testStr, Err: fmt.Errorf("can't test divisibility by 0")}
+	}
 
 	// Init monkey
 	monkey := Monkey{items: items, testValue: testValue, onTrue: onTrue, onFalse: onFalse}
 
 	// Operation method
 	operationParts := strings.Split(operationStr, " ")
+	if len(operationParts) != 5 || operationParts[2] != "old" {
+		return nil, &FieldError{Field: OperationField, Value: operationStr, Err: fmt.Errorf("expected operation like \"new = old * 19\"")}
+	}
 	operator := operationParts[3]
 	operationValue, err := strconv.Atoi(operationParts[4])
 	if operator == "+" {
@@ -73,9 +118,14 @@ func NewMonkey(
 			}
 		}
 	} else {
-		panic("undefined operator " + string(operator))
+		return nil, &FieldError{Field: OperationField, Value: operationStr, Err: fmt.Errorf("undefined operator %q", operator)}
 	}
-	return &monkey
+	return &monkey, nil
+}
+
+// Returns indexes of monkeys items are thrown to when test is true or false
+func (monkey *Monkey) Targets() (onTrue int, onFalse int) {
+	return monkey.onTrue, monkey.onFalse
 }
 
 // Tests if item worry level is divisible by monkey test value
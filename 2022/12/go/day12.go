@@ -3,112 +3,109 @@
 package main
 
 import (
-	"log"
-	"os"
-	"strings"
+	"fmt"
+	"io"
+
+	"github.com/erikzak/adventofcode/2022/aoc"
+	"github.com/erikzak/adventofcode/2022/aoc/grid"
+	"github.com/erikzak/adventofcode/2022/aoc/search"
+	"github.com/erikzak/adventofcode/2022/aoc/visual"
 )
 
 const inputPath = "../input.txt"
 
-func check(err error) {
-	if err != nil {
-		log.Panic(err)
-	}
+// Keeps track of terrain height grid, start and end nodes
+type Terrain struct {
+	heights *grid.Dense[byte]
+	start   grid.Point
+	end     grid.Point
+	trace   *searchTrace // Nil unless animated
 }
 
-// Declares node type as basic 2-length array
-type Node [2]int
-
-// Keeps track of terrain grid, start and end nodes
-type Terrain struct {
-	width  int
-	height int
-	rows   []string
-	start  Node
-	end    Node
+// Squares explored by the search running, and the path it found, for
+// animation. Shared by the copies of terrain the search gets
+type searchTrace struct {
+	animation *visual.Animation
+	explored  map[grid.Point]bool
+	current   grid.Point
+	path      []grid.Point
 }
 
-func NewTerrain(rows []string, start Node, end Node) Terrain {
-	terrain := Terrain{rows: rows, start: start, end: end}
-	terrain.width = len(rows[0])
-	terrain.height = len(rows)
+func NewTerrain(heights *grid.Dense[byte], start grid.Point, end grid.Point) Terrain {
+	terrain := Terrain{heights: heights, start: start, end: end}
 	return terrain
 }
 
-// A* finds a path from start to goal. Returns least number of steps.
-// Adapted from https://en.wikipedia.org/wiki/A*_search_algorithm
-func (terrain Terrain) AStar() (leastSteps int) {
-	openSet := map[Node]struct{}{
-		terrain.start: {},
+// A* finds a path from the start square(s) to the end. Returns least number
+// of steps, or -1 if the end can't be reached
+func (terrain Terrain) AStar(starts ...grid.Point) (leastSteps int) {
+	isEnd := func(node grid.Point) bool {
+		return node == terrain.end
 	}
-	cameFrom := map[Node]Node{}
-	gScore := map[Node]int{}
-	gScore[terrain.start] = 0
-	fScore := map[Node]int{}
-	fScore[terrain.start] = terrain.estimateDistanceToEnd(terrain.start)
-
-	for {
-		current := Node{-1, -1}
-		lowestF := -1
-		for node := range openSet {
-			if lowestF == -1 || lowestF > fScore[node] {
-				current, lowestF = node, fScore[node]
-			}
-		}
+	if terrain.trace != nil {
+		terrain.trace.explored = make(map[grid.Point]bool)
+		terrain.trace.path = nil
+	}
+	result := search.AStar[grid.Point](terrain, isEnd, terrain.estimateDistanceToEnd, starts...)
+	if terrain.trace != nil && result.Found {
+		terrain.trace.path = result.Path()
+		terrain.trace.animation.Step(terrain)
+	}
+	return result.Cost
+}
 
-		if current == terrain.end {
-			leastSteps = len(reconstructPath(cameFrom, current)) - 1
-			return leastSteps
-		}
+// Returns edges to neighbors that can be climbed to from the given node
+func (terrain Terrain) Neighbors(node grid.Point) []search.Edge[grid.Point] {
+	if terrain.trace != nil {
+		terrain.trace.explored[node] = true
+		terrain.trace.current = node
+		terrain.trace.animation.Step(terrain)
+	}
+	return search.Unweighted(terrain.getNeighbors(node))
+}
 
-		delete(openSet, current)
-		neighbors := terrain.getNeighbors(current)
-		for _, neighbor := range neighbors {
-			tentativeGScore, cOk := gScore[current]
-			if !cOk {
-				tentativeGScore = 9999999
-			} else {
-				tentativeGScore++
-			}
-			neighborGScore, nOk := gScore[neighbor]
-			if !nOk {
-				neighborGScore = 9999999
-			}
-			if tentativeGScore < neighborGScore {
-				cameFrom[neighbor] = current
-				gScore[neighbor] = tentativeGScore
-				fScore[neighbor] = tentativeGScore + terrain.estimateDistanceToEnd(neighbor)
-				openSet[neighbor] = struct{}{}
+// Draws heightmap in bands of low , middle ; and high = squares, with
+// squares explored by the search as :, the square being explored as @ and the
+// path found as *. Height letters would clash with glyph colors of other days
+func (terrain Terrain) Frame() visual.Frame {
+	trace := terrain.trace
+	onPath := make(map[grid.Point]bool, len(trace.path))
+	for _, node := range trace.path {
+		onPath[node] = true
+	}
+	title := fmt.Sprintf("Squares explored: %d", len(trace.explored))
+	if len(trace.path) > 0 {
+		title = fmt.Sprintf("Fewest steps: %d, squares explored: %d", len(trace.path)-1, len(trace.explored))
+	}
+	return visual.Frame{
+		Title:  title,
+		Bounds: terrain.heights.Bounds(),
+		Glyph: func(node grid.Point) rune {
+			switch {
+			case node == terrain.start:
+				return 'S'
+			case node == terrain.end:
+				return 'E'
+			case onPath[node]:
+				return '*'
+			case len(trace.path) == 0 && node == trace.current:
+				return '@'
+			case trace.explored[node]:
+				return ':'
 			}
-		}
-		if len(openSet) == 0 {
-			break
-		}
+			height, _ := terrain.heights.Get(node)
+			return rune(",;="[(height-'a')/9])
+		},
+		Focus: trace.current,
 	}
-
-	return -1
 }
 
 // Returns slice of valid neighbors for the given terrain node
-func (terrain Terrain) getNeighbors(node Node) (neighbors []Node) {
-	neighbors = []Node{}
-	nodeHeight := terrain.rows[node[1]][node[0]]
-	neighborIndexes := []Node{
-		{-1, 0},
-		{1, 0},
-		{0, -1},
-		{0, 1},
-	}
-	for _, potential := range neighborIndexes {
-		target := Node{
-			node[0] + potential[0],
-			node[1] + potential[1],
-		}
-		if target[0] < 0 || target[0] >= terrain.width ||
-			target[1] < 0 || target[1] >= terrain.height {
-			continue
-		}
-		targetHeight := terrain.rows[target[1]][target[0]]
+func (terrain Terrain) getNeighbors(node grid.Point) (neighbors []grid.Point) {
+	neighbors = []grid.Point{}
+	nodeHeight, _ := terrain.heights.Get(node)
+	for _, target := range terrain.heights.Neighbors4(node) {
+		targetHeight, _ := terrain.heights.Get(target)
 		if nodeHeight >= targetHeight-1 {
 			neighbors = append(neighbors, target)
 		}
@@ -117,91 +114,102 @@ func (terrain Terrain) getNeighbors(node Node) (neighbors []Node) {
 }
 
 // Returns manhattan distance between given node and end
-func (terrain Terrain) estimateDistanceToEnd(node Node) int {
-	return Abs(terrain.end[0]-node[0]) + Abs(terrain.end[1]-node[1])
+func (terrain Terrain) estimateDistanceToEnd(node grid.Point) int {
+	return grid.Manhattan(node, terrain.end)
 }
 
-func Abs(x int) int {
-	if x < 0 {
-		return -x
+// Parses puzzle input from reader.
+// Returns terrain instance
+func readInput(r io.Reader) (terrain Terrain, err error) {
+	input, err := aoc.ReadFrom(r)
+	if err != nil {
+		return Terrain{}, err
 	}
-	return x
-}
-
-// Reconstructs path from start to current node
-func reconstructPath(cameFrom map[Node]Node, current Node) []Node {
-	totalPath := []Node{current}
-	for {
-		next, ok := cameFrom[current]
-		if ok {
-			delete(cameFrom, current)
-			totalPath = append([]Node{next}, totalPath...)
-			current = totalPath[0]
-			continue
+	rows := input.Lines
+	for y, row := range rows {
+		if len(row) != len(rows[0]) {
+			return Terrain{}, input.Errorf(y, "", "expected row of %d squares, got %d", len(rows[0]), len(row))
 		}
-		break
 	}
-	return totalPath
-}
-
-// Parses puzzle input from txt file.
-// Returns terrain instance
-func readInput(path string) (terrain Terrain) {
-	inputBytes, err := os.ReadFile(path)
-	check(err)
-	rows := strings.Split(strings.ReplaceAll(string(inputBytes), "\r\n", "\n"), "\n")
-	start, end := Node{}, Node{}
-	// Grab start and end indexes from terrain model and replace with heights
-	for y, row := range rows {
-		for x, char := range row {
-			if char == 'S' {
-				start = Node{x, y}
-				rows[y] = strings.Replace(rows[y], "S", "a", 1)
-			} else if char == 'E' {
-				end = Node{x, y}
-				rows[y] = strings.Replace(rows[y], "E", "z", 1)
+	heights := grid.Parse(rows)
+	start, end := grid.Point{-1, -1}, grid.Point{-1, -1}
+	// Grab start and end nodes from terrain model and replace with heights
+	for _, node := range heights.Points() {
+		char, _ := heights.Get(node)
+		if char == 'S' || char == 'E' {
+			marker := &start
+			height := byte('a')
+			if char == 'E' {
+				marker, height = &end, 'z'
+			}
+			if *marker != (grid.Point{-1, -1}) {
+				return Terrain{}, input.ErrorAt(node.Y(), node.X(), fmt.Errorf("duplicate %q", char))
 			}
+			*marker = node
+			heights.Set(node, height)
+		} else if char < 'a' || char > 'z' {
+			return Terrain{}, input.ErrorAt(node.Y(), node.X(), fmt.Errorf("invalid elevation %q", char))
 		}
 	}
-	terrain = NewTerrain(rows, start, end)
-	return terrain
+	if start == (grid.Point{-1, -1}) || end == (grid.Point{-1, -1}) {
+		return Terrain{}, input.Errorf(len(rows)-1, "", "expected both start S and end E in heightmap")
+	}
+	terrain = NewTerrain(heights, start, end)
+	return terrain, nil
 }
 
 // Part 1: What is the fewest steps required to move from your current
 // position to the location that should get the best signal?
 func solvePart1(terrain Terrain) int {
-	return terrain.AStar()
+	return terrain.AStar(terrain.start)
 }
 
 // Part 2: What is the fewest steps required to move starting from any square
 // with elevation a to the location that should get the best signal?
 func solvePart2(terrain Terrain) int {
-	leastSteps := -1
-	for y, row := range terrain.rows {
-		for x, char := range row {
-			if char == 'a' {
-				terrain.start = Node{x, y}
-				steps := terrain.AStar()
-				if steps != -1 && (leastSteps == -1 || steps < leastSteps) {
-					leastSteps = steps
-				}
-			}
+	// Search from all of them at once, the closest one wins
+	starts := []grid.Point{}
+	for _, node := range terrain.heights.Points() {
+		char, _ := terrain.heights.Get(node)
+		if char == 'a' {
+			starts = append(starts, node)
 		}
 	}
-	return leastSteps
+	return terrain.AStar(starts...)
 }
 
 // Solves puzzle parts. Split up for benchmarking
-func solvePuzzle() (int, int) {
-	input := readInput(inputPath)
+func solvePuzzle() (int, int, error) {
+	input, err := aoc.ReadFile(inputPath, readInput)
+	if err != nil {
+		return 0, 0, err
+	}
 	answer1 := solvePart1(input)
 	answer2 := solvePart2(input)
-	return answer1, answer2
+	return answer1, answer2, nil
+}
+
+// Registers puzzle parts with the aoc runner
+var puzzle = aoc.Puzzle[Terrain, int, int]{
+	Year: 2022, Day: 12,
+	ReadInput:  readInput,
+	SolvePart1: aoc.NoError(solvePart1),
+	SolvePart2: aoc.NoError(solvePart2),
+	Labels: [2]string{
+		"Fewest steps to best signal",
+		"Fewest steps from any a to best signal",
+	},
+	Gen: &aoc.Generator{
+		Size: 173, MinSize: 26, SizeHelp: "squares per row",
+		Generate: generateInput,
+	},
+	Animate: func(terrain Terrain, animation *visual.Animation) Terrain {
+		terrain.trace = &searchTrace{animation: animation}
+		return terrain
+	},
 }
 
 // Reads input, solves puzzle parts and logs answers
 func main() {
-	answer1, answer2 := solvePuzzle()
-	log.Printf("Fewest steps to best signal: %v\n", answer1)
-	log.Printf("Fewest steps from any a to best signal: %v\n", answer2)
+	aoc.Main(puzzle)
 }
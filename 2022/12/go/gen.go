@@ -0,0 +1,38 @@
+package main
+
+import (
+	"bufio"
+	"math/rand"
+)
+
+// Generates random heightmap size squares wide, rising from a on the left
+// edge to z on the right. Even rows are left clear, so the end can always be
+// reached, while odd rows get cliffs that force detours
+func generateInput(rng *rand.Rand, size int, w *bufio.Writer) {
+	rows := size/4 + 3
+	if rows%2 == 0 {
+		rows++
+	}
+	start, end := 2*rng.Intn(rows/2+1), 2*rng.Intn(rows/2+1)
+	line := make([]byte, size)
+	for y := 0; y < rows; y++ {
+		for x := range line {
+			height := x * 25 / (size - 1)
+			if y%2 == 1 && rng.Intn(10) < 3 {
+				height += 2 + rng.Intn(4)
+				if height > 25 {
+					height = 25
+				}
+			}
+			line[x] = byte('a' + height)
+		}
+		if y == start {
+			line[0] = 'S'
+		}
+		if y == end {
+			line[size-1] = 'E'
+		}
+		w.Write(line)
+		w.WriteString("\n")
+	}
+}
@@ -4,39 +4,39 @@ package main
 
 import (
 	"fmt"
-	"log"
-	"os"
+	"io"
 	"sort"
-	"strings"
 
 	"github.com/erikzak/adventofcode/2022/13/signal"
+	"github.com/erikzak/adventofcode/2022/aoc"
 )
 
 const inputPath = "../input.txt"
 
-func check(err error) {
+// Parses puzzle input from reader.
+// Returns signal with packet pairs.
+func readInput(r io.Reader) (data signal.Signal, err error) {
+	input, err := aoc.ReadFrom(r)
 	if err != nil {
-		log.Panic(err)
+		return signal.Signal{}, err
 	}
-}
-
-// Parses puzzle input from txt file.
-// Returns signal with packet pairs.
-func readInput(path string) (data signal.Signal) {
-	inputBytes, err := os.ReadFile(path)
-	check(err)
-	packetPairInputs := strings.Split(strings.ReplaceAll(string(inputBytes), "\r\n", "\n"), "\n\n")
 	packetPairs := [][]signal.Packet{}
-	for _, pair := range packetPairInputs {
-		packetStrings := strings.Split(pair, "\n")
-		packetPair := []signal.Packet{
-			signal.NewPacket(packetStrings[0]),
-			signal.NewPacket(packetStrings[1]),
+	for _, pair := range input.Blocks() {
+		if len(pair.Lines) != 2 {
+			return signal.Signal{}, input.Errorf(pair.Start, "", "expected pair of packets, got %d", len(pair.Lines))
+		}
+		packetPair := []signal.Packet{}
+		for i, packetString := range pair.Lines {
+			packet, err := signal.NewPacket(packetString)
+			if err != nil {
+				return signal.Signal{}, input.Error(pair.Start+i, "", err)
+			}
+			packetPair = append(packetPair, packet)
 		}
 		packetPairs = append(packetPairs, packetPair)
 	}
 	data = signal.NewSignal(packetPairs)
-	return data
+	return data, nil
 }
 
 // Part 1: What is the sum of the indices of pairs in the right order?
@@ -46,9 +46,10 @@ func solvePart1(data signal.Signal) int {
 
 // Part 2: What is the decoder key for the distress signal?
 func solvePart2(data signal.Signal) int {
+	// Divider packets [[2]] and [[6]]. Parsed JSON numbers are float64
 	packets := []signal.Packet{
-		signal.NewPacket("[[2]]"),
-		signal.NewPacket("[[6]]"),
+		{Values: []any{[]any{2.0}}},
+		{Values: []any{[]any{6.0}}},
 	}
 	for _, packetPair := range data.PacketPairs {
 		packets = append(packets, packetPair[0])
@@ -71,16 +72,33 @@ func getDividerIndex(packets []signal.Packet, divider string) int {
 }
 
 // Solves puzzle parts. Split up for benchmarking
-func solvePuzzle() (int, int) {
-	input := readInput(inputPath)
+func solvePuzzle() (int, int, error) {
+	input, err := aoc.ReadFile(inputPath, readInput)
+	if err != nil {
+		return 0, 0, err
+	}
 	answer1 := solvePart1(input)
 	answer2 := solvePart2(input)
-	return answer1, answer2
+	return answer1, answer2, nil
+}
+
+// Registers puzzle parts with the aoc runner
+var puzzle = aoc.Puzzle[signal.Signal, int, int]{
+	Year: 2022, Day: 13,
+	ReadInput:  readInput,
+	SolvePart1: aoc.NoError(solvePart1),
+	SolvePart2: aoc.NoError(solvePart2),
+	Labels: [2]string{
+		"Sum of indices of right order pairs",
+		"Decoder key",
+	},
+	Gen: &aoc.Generator{
+		Size: 150, SizeHelp: "packet pairs",
+		Generate: generateInput,
+	},
 }
 
 // Reads input, solves puzzle parts and logs answers
 func main() {
-	answer1, answer2 := solvePuzzle()
-	log.Printf("Sum of indices of right order pairs: %v\n", answer1)
-	log.Printf("Decoder key: %v\n", answer2)
+	aoc.Main(puzzle)
 }
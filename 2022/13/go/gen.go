@@ -0,0 +1,36 @@
+package main
+
+import (
+	"bufio"
+	"math/rand"
+	"strconv"
+)
+
+// Generates size pairs of random packets, nested up to four lists deep
+func generateInput(rng *rand.Rand, size int, w *bufio.Writer) {
+	for i := 0; i < size; i++ {
+		if i > 0 {
+			w.WriteString("\n")
+		}
+		writePacket(rng, w, 0)
+		w.WriteString("\n")
+		writePacket(rng, w, 0)
+		w.WriteString("\n")
+	}
+}
+
+// Writes random list of up to five values, integers or lists
+func writePacket(rng *rand.Rand, w *bufio.Writer, depth int) {
+	w.WriteString("[")
+	for i := rng.Intn(6); i > 0; i-- {
+		if depth < 4 && rng.Intn(3) == 0 {
+			writePacket(rng, w, depth+1)
+		} else {
+			w.WriteString(strconv.Itoa(rng.Intn(11)))
+		}
+		if i > 1 {
+			w.WriteString(",")
+		}
+	}
+	w.WriteString("]")
+}
@@ -16,14 +16,36 @@ type Packet struct {
 	Values []any
 }
 
-func NewPacket(input string) Packet {
+func NewPacket(input string) (Packet, error) {
 	// Parse packet string through JSON into either list or int
 	var values []any
 	err := json.Unmarshal([]byte(input), &values)
 	if err != nil {
-		panic("unable to parse packet input:" + fmt.Sprintf("%v", values))
+		return Packet{}, fmt.Errorf("unable to parse packet: %v", err)
 	}
-	return Packet{Values: values}
+	if err := validate(values); err != nil {
+		return Packet{}, err
+	}
+	return Packet{Values: values}, nil
+}
+
+// Checks that packet values only hold integers and lists
+func validate(value any) error {
+	switch value := value.(type) {
+	case float64:
+		if value != float64(int(value)) {
+			return fmt.Errorf("packet value %v is not an integer", value)
+		}
+	case []any:
+		for _, item := range value {
+			if err := validate(item); err != nil {
+				return err
+			}
+		}
+	default:
+		return fmt.Errorf("packet value %v is neither integer nor list", value)
+	}
+	return nil
 }
 
 // Recursively compares packet values
@@ -4,59 +4,50 @@ package main
 
 import (
 	"fmt"
-	"log"
-	"os"
-	"strconv"
+	"io"
 	"strings"
+
+	"github.com/erikzak/adventofcode/2022/aoc"
+	"github.com/erikzak/adventofcode/2022/aoc/grid"
+	"github.com/erikzak/adventofcode/2022/aoc/visual"
 )
 
 const inputPath = "../input.txt"
 
-func check(err error) {
-	if err != nil {
-		log.Panic(err)
-	}
-}
-
-// Define cavern 2d slice node as data type
-type Node [2]int
+// Largest coordinate accepted in the scan. Rock lines are drawn point by
+// point, so absurd coordinates would exhaust memory before the puzzle starts
+const maxCoordinate = 10000
 
-// Cavern 2d vertical slice. Node map points to int specifying if node is
+// Cavern 2d vertical slice. Node grid points to int specifying if node is
 // blocked or not, and what is blocking
 type Cavern struct {
-	nodes       map[Node]int // -1 = spawn, 0 = air, 1 = rock, 2 = sand
-	sandspot    Node
+	nodes       *grid.Sparse[int] // -1 = spawn, 0 = air, 1 = rock, 2 = sand
+	sandspot    grid.Point
 	settledSand int
-	dim         [4]int // [xMin, xMax, zMin, zMax]
-	height      int
-	width       int
 	floor       int
+	falling     grid.Point // Sand currently falling, for drawing
+	animation   *visual.Animation
 }
 
-// Builds node map from scan input nodes. Draws lines between input nodes
-// describing rock parts
-func NewCavern(scan [][]Node, sandspot Node) *Cavern {
-	// Keep track of total dimensions: [xMin, xMax, zMin, zMax]
-	dim := [4]int{sandspot[0], sandspot[0], sandspot[1], sandspot[1]}
-	nodes := map[Node]int{sandspot: -1}
-	// Add rocks to cavern node map
+// Builds node grid from scan input nodes. Draws lines between input nodes
+// describing rock parts. Grid bounds keep track of total dimensions
+func NewCavern(scan [][]grid.Point, sandspot grid.Point) *Cavern {
+	nodes := grid.NewSparse[int]()
+	nodes.Set(sandspot, -1)
+	// Add rocks to cavern node grid
 	for _, rockLine := range scan {
 		for i, rockNode := range rockLine {
-			nodes[rockNode] = 1
-			dim = updateDimensions(dim, rockNode)
+			nodes.Set(rockNode, 1)
 			// Draw line if not first node in line
 			if i == 0 {
 				continue
 			}
 			for _, node := range generateLine(rockNode, rockLine[i-1]) {
-				nodes[node] = 1
+				nodes.Set(node, 1)
 			}
 		}
 	}
-	cavern := Cavern{
-		nodes: nodes, sandspot: sandspot, dim: dim,
-		height: dim[3] - dim[2], width: dim[1] - dim[0],
-	}
+	cavern := Cavern{nodes: nodes, sandspot: sandspot}
 	return &cavern
 }
 
@@ -67,10 +58,12 @@ func (cavern *Cavern) spawnSand() bool {
 	loc := cavern.sandspot
 	for {
 		// Check for freefall
-		down := Node{loc[0], loc[1] + 1}
-		if cavern.floor == 0 && down[1] > cavern.dim[3] {
+		down := loc.Add(grid.Down)
+		if cavern.floor == 0 && down[1] > cavern.nodes.Bounds().Max[1] {
 			return false
 		}
+		cavern.falling = loc
+		cavern.animation.Step(cavern)
 		// Check down
 		content := cavern.getContent(down)
 		if content <= 0 {
@@ -78,14 +71,14 @@ func (cavern *Cavern) spawnSand() bool {
 			continue
 		}
 		// Check down-left
-		downLeft := Node{loc[0] - 1, loc[1] + 1}
+		downLeft := down.Add(grid.Left)
 		content = cavern.getContent(downLeft)
 		if content <= 0 {
 			loc = downLeft
 			continue
 		}
 		// Check down-right
-		downRight := Node{loc[0] + 1, loc[1] + 1}
+		downRight := down.Add(grid.Right)
 		content = cavern.getContent(downRight)
 		if content <= 0 {
 			loc = downRight
@@ -93,66 +86,46 @@ func (cavern *Cavern) spawnSand() bool {
 		}
 		// Settle sand
 		cavern.settledSand++
-		cavern.nodes[loc] = 2
-		cavern.dim = updateDimensions(cavern.dim, loc)
+		cavern.nodes.Set(loc, 2)
 		// Check if sand settled at spawn
 		return loc != cavern.sandspot
 	}
 }
 
 // Returns content of target node
-func (cavern *Cavern) getContent(node Node) int {
+func (cavern *Cavern) getContent(node grid.Point) int {
 	// Check if floor
 	if node[1] == cavern.floor {
 		return 1
 	}
-	// Check map for content
-	content, exists := cavern.nodes[node]
-	if !exists {
-		content = 0
-	}
+	// Check grid for content, unset nodes are air
+	content, _ := cavern.nodes.Get(node)
 	return content
 }
 
-// Update cavern dimensions based on given node
-func updateDimensions(dim [4]int, node Node) [4]int {
-	x, z := node[0], node[1]
-	if dim[0] > x {
-		dim[0] = x
-	} else if dim[1] < x {
-		dim[1] = x
-	}
-	if dim[2] > z {
-		dim[2] = z
-	} else if dim[3] < z {
-		dim[3] = z
-	}
-	return dim
-}
-
 // Returns a slice of nodes describing line between start and end
-func generateLine(start Node, end Node) (nodes []Node) {
+func generateLine(start grid.Point, end grid.Point) (nodes []grid.Point) {
 	x, z := start[0], start[1]
 	dx, dz := x-end[0], z-end[1]
 	for dx != 0 || dz != 0 {
 		if dx > 0 {
-			nodes = append(nodes, Node{x - 1, z})
+			nodes = append(nodes, grid.Point{x - 1, z})
 			x--
 			dx--
 			continue
 		} else if dx < 0 {
-			nodes = append(nodes, Node{x + 1, z})
+			nodes = append(nodes, grid.Point{x + 1, z})
 			x++
 			dx++
 			continue
 		}
 		if dz > 0 {
-			nodes = append(nodes, Node{x, z - 1})
+			nodes = append(nodes, grid.Point{x, z - 1})
 			z--
 			dz--
 			continue
 		} else if dz < 0 {
-			nodes = append(nodes, Node{x, z + 1})
+			nodes = append(nodes, grid.Point{x, z + 1})
 			z++
 			dz++
 			continue
@@ -161,91 +134,133 @@ func generateLine(start Node, end Node) (nodes []Node) {
 	return nodes
 }
 
-// Prints cavern map to console
-func (cavern *Cavern) printMap() {
-	charMap := map[int]string{
-		-1: "+",
-		0:  " ",
-		1:  "#",
-		2:  "o",
+// Draws cavern with rocks, settled sand and the sand falling, following it
+func (cavern *Cavern) Frame() visual.Frame {
+	charMap := map[int]rune{
+		-1: '+',
+		0:  ' ',
+		1:  '#',
+		2:  'o',
 	}
-	for z := cavern.dim[2]; z <= cavern.dim[3]; z++ {
-		for x := cavern.dim[0]; x <= cavern.dim[1]; x++ {
-			sign := " "
-			if z == cavern.floor {
-				sign = "#"
-			} else {
-				content, ok := cavern.nodes[Node{x, z}]
-				if ok {
-					sign = charMap[content]
-				}
-			}
-			fmt.Print(sign)
-		}
-		fmt.Print("\n")
+	bounds := cavern.nodes.Bounds()
+	if cavern.floor > 0 {
+		bounds = bounds.Extend(grid.Point{bounds.Min[0], cavern.floor})
 	}
-	if cavern.floor >= 0 {
-		for x := cavern.dim[0]; x <= cavern.dim[1]; x++ {
-			fmt.Print("#")
-		}
-		fmt.Print("\n")
+	return visual.Frame{
+		Title:  fmt.Sprintf("Units of sand at rest: %d", cavern.settledSand),
+		Bounds: bounds.Extend(cavern.falling),
+		Glyph: func(node grid.Point) rune {
+			if node == cavern.falling {
+				return '~'
+			}
+			if cavern.floor > 0 && node[1] == cavern.floor {
+				return '#'
+			}
+			content, ok := cavern.nodes.Get(node)
+			if !ok {
+				return ' '
+			}
+			return charMap[content]
+		},
+		Focus: cavern.falling,
 	}
 }
 
-// Parses puzzle input from txt file.
+// Parses puzzle input from reader.
 // Returns 2D vertical slice of cavern
-func readInput(path string) *Cavern {
-	inputBytes, err := os.ReadFile(path)
-	check(err)
-	lines := strings.Split(strings.ReplaceAll(string(inputBytes), "\r\n", "\n"), "\n")
-	scan := [][]Node{}
-	sandspot := Node{500, 0}
+func readInput(r io.Reader) (*Cavern, error) {
+	input, err := aoc.ReadFrom(r)
+	if err != nil {
+		return nil, err
+	}
+	scan := [][]grid.Point{}
+	sandspot := grid.Point{500, 0}
 	// Generate rock node slices from input strings
-	for _, line := range lines {
-		nodes := []Node{}
+	for i, line := range input.Lines {
+		nodes := []grid.Point{}
 		for _, nodeString := range strings.Split(line, " -> ") {
-			split := strings.Split(nodeString, ",")
-			x, _ := strconv.Atoi(split[0])
-			z, _ := strconv.Atoi(split[1])
-			nodes = append(nodes, Node{x, z})
+			xString, zString, found := strings.Cut(nodeString, ",")
+			if !found {
+				return nil, input.Errorf(i, nodeString, "expected node like \"498,4\", got %q", nodeString)
+			}
+			x, err := input.Atoi(i, xString)
+			if err != nil {
+				return nil, err
+			}
+			z, err := input.Atoi(i, zString)
+			if err != nil {
+				return nil, err
+			}
+			if x < 0 || z < 0 || x > maxCoordinate || z > maxCoordinate {
+				return nil, input.Errorf(i, nodeString, "node %q outside scanned area 0-%d", nodeString, maxCoordinate)
+			}
+			node := grid.Point{x, z}
+			// Rock paths are drawn as straight lines between nodes
+			if len(nodes) > 0 {
+				previous := nodes[len(nodes)-1]
+				if previous.X() != node.X() && previous.Y() != node.Y() {
+					return nil, input.Errorf(i, nodeString, "diagonal rock path from %v to %v", previous, node)
+				}
+			}
+			nodes = append(nodes, node)
 		}
 		scan = append(scan, nodes)
 	}
 	cavern := NewCavern(scan, sandspot)
-	return cavern
+	return cavern, nil
 }
 
 // Part 1: How many units of sand come to rest before sand starts flowing into the abyss below?
 func solvePart1(cavern *Cavern) int {
-	// cavern.printMap()
 	for cavern.spawnSand() {
 	}
-	// cavern.printMap()
 	return cavern.settledSand
 }
 
 // Part 2: How many units of sand come to rest before sand is blocked?
 func solvePart2(cavern *Cavern) int {
-	// cavern.printMap()
-	cavern.floor = cavern.dim[3] + 2
+	cavern.floor = cavern.nodes.Bounds().Max[1] + 2
 	for cavern.spawnSand() {
 	}
-	// cavern.printMap()
 	return cavern.settledSand
 }
 
 // Solves puzzle parts. Split up for benchmarking
-func solvePuzzle() (int, int) {
-	input := readInput(inputPath)
+func solvePuzzle() (int, int, error) {
+	input, err := aoc.ReadFile(inputPath, readInput)
+	if err != nil {
+		return 0, 0, err
+	}
 	answer1 := solvePart1(input)
-	input = readInput(inputPath)
+	input, err = aoc.ReadFile(inputPath, readInput)
+	if err != nil {
+		return 0, 0, err
+	}
 	answer2 := solvePart2(input)
-	return answer1, answer2
+	return answer1, answer2, nil
+}
+
+// Registers puzzle parts with the aoc runner
+var puzzle = aoc.Puzzle[*Cavern, int, int]{
+	Year: 2022, Day: 14,
+	ReadInput:  readInput,
+	SolvePart1: aoc.NoError(solvePart1),
+	SolvePart2: aoc.NoError(solvePart2),
+	Labels: [2]string{
+		"Units of sand at rest when freefall",
+		"Units of sand at rest when cavern filled",
+	},
+	Gen: &aoc.Generator{
+		Size: 150, SizeHelp: "rock paths",
+		Generate: generateInput,
+	},
+	Animate: func(cavern *Cavern, animation *visual.Animation) *Cavern {
+		cavern.animation = animation
+		return cavern
+	},
 }
 
 // Reads input, solves puzzle parts and logs answers
 func main() {
-	answer1, answer2 := solvePuzzle()
-	log.Printf("Units of sand at rest when freefall: %v\n", answer1)
-	log.Printf("Units of sand at rest when cavern filled: %v\n", answer2)
+	aoc.Main(puzzle)
 }
@@ -0,0 +1,34 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"math/rand"
+	"strings"
+)
+
+// Generates size random rock paths of 2 to 5 nodes below the sand spawn.
+// Segments alternate between horizontal and vertical
+func generateInput(rng *rand.Rand, size int, w *bufio.Writer) {
+	for i := 0; i < size; i++ {
+		x, y := 450+rng.Intn(100), 15+rng.Intn(150)
+		nodes := []string{fmt.Sprintf("%d,%d", x, y)}
+		horizontal := rng.Intn(2) == 0
+		for n := 1 + rng.Intn(4); n > 0; n-- {
+			length := 1 + rng.Intn(10)
+			if rng.Intn(2) == 0 {
+				length = -length
+			}
+			if horizontal {
+				x += length
+			} else if y+length > 0 {
+				y += length
+			} else {
+				y -= length
+			}
+			nodes = append(nodes, fmt.Sprintf("%d,%d", x, y))
+			horizontal = !horizontal
+		}
+		w.WriteString(strings.Join(nodes, " -> ") + "\n")
+	}
+}
@@ -1,3 +1,7 @@
 module github.com/erikzak/adventofcode/2022/14
 
 go 1.19
+
+require github.com/erikzak/adventofcode/2022/aoc v0.0.0
+
+replace github.com/erikzak/adventofcode/2022/aoc => ../../aoc/go
@@ -4,34 +4,26 @@ package main
 
 import (
 	"fmt"
-	"log"
-	"os"
-	"strconv"
-	"strings"
+	"io"
+
+	"github.com/erikzak/adventofcode/2022/aoc"
+	"github.com/erikzak/adventofcode/2022/aoc/grid"
+	"github.com/erikzak/adventofcode/2022/aoc/visual"
 )
 
 const inputPath = "../input.txt"
 
-func check(err error) {
-	if err != nil {
-		log.Panic(err)
-	}
-}
-
-// Define node map unit as XY pair
-type Node [2]int
-
 // Define sensor struct to keep track of node, beacon and Manhattan distance between them
 type Sensor struct {
-	node             Node
-	beacon           Node
+	node             grid.Point
+	beacon           grid.Point
 	distanceToBeacon int
 }
 
 // Returns all nodes at sensor boundary + offset
-func (sensor Sensor) getBoundary(offset int) (nodes []Node) {
+func (sensor Sensor) getBoundary(offset int) (nodes []grid.Point) {
 	distance := sensor.distanceToBeacon + offset
-	corners := []Node{
+	corners := []grid.Point{
 		{sensor.node[0], sensor.node[1] + distance},
 		{sensor.node[0] + distance, sensor.node[1]},
 		{sensor.node[0], sensor.node[1] - distance},
@@ -48,70 +40,55 @@ func (sensor Sensor) getBoundary(offset int) (nodes []Node) {
 		if dy < 0 {
 			incY = -1
 		}
-		node := Node{from[0] + incX, from[1] + incY}
+		node := grid.Point{from[0] + incX, from[1] + incY}
 		for {
 			nodes = append(nodes, node)
 			if node == to {
 				break
 			}
-			node = Node{node[0] + incX, node[1] + incY}
+			node = grid.Point{node[0] + incX, node[1] + incY}
 		}
 	}
 	return nodes
 }
 
 // Inits new sensor with location and beacon, calculating the Manhattan distance between them
-func NewSensor(sensorNode Node, beaconNode Node) Sensor {
+func NewSensor(sensorNode grid.Point, beaconNode grid.Point) Sensor {
 	sensor := Sensor{node: sensorNode, beacon: beaconNode}
-	sensor.distanceToBeacon = calculateManhattanDistance(sensorNode, beaconNode)
+	sensor.distanceToBeacon = grid.Manhattan(sensorNode, beaconNode)
 	return sensor
 }
 
-// Returns manhattan distance between start and end node
-func calculateManhattanDistance(start Node, end Node) int {
-	return Abs(end[0]-start[0]) + Abs(end[1]-start[1])
-}
-
-func Abs(x int) int {
-	if x < 0 {
-		return -x
-	}
-	return x
-}
-
-// Cavern 2d vertical slice. Node map points to int specifying if node is
+// Cavern 2d vertical slice. Node grid points to int specifying if node is
 // blocked or not, and what is blocking
 type Cavern struct {
-	nodes             map[Node]int // -1 = unknown, 0 = beacon void, 1 = sensor, 2 = beacon
-	sensors           map[Node]Sensor
-	dim               [4]int // [xMin, xMax, zMin, zMax]
+	nodes             *grid.Sparse[int] // -1 = unknown, 0 = beacon void, 1 = sensor, 2 = beacon
+	sensors           map[grid.Point]Sensor
 	maxBeaconDistance int
+	scanning          grid.Point // Node being checked, for drawing
+	animation         *visual.Animation
 }
 
-// Builds node map from sensors and beacons.
-func NewCavern(sensors map[Node]Sensor) *Cavern {
-	// Keep track of total dimensions: [xMin, xMax, zMin, zMax]
-	dim := [4]int{}
-	nodes := map[Node]int{}
-	// Add sensors and beacons to node map
+// Builds node grid from sensors and beacons. Grid bounds keep track of total dimensions
+func NewCavern(sensors map[grid.Point]Sensor) *Cavern {
+	nodes := grid.NewSparse[int]()
+	// Add sensors and beacons to node grid
 	maxBeaconDistance := 0
 	for node, sensor := range sensors {
-		nodes[node] = 1
-		nodes[sensor.beacon] = 2
-		dim = updateDimensions(dim, node)
-		dim = updateDimensions(dim, sensor.beacon)
+		nodes.Set(node, 1)
+		nodes.Set(sensor.beacon, 2)
 		if sensor.distanceToBeacon > maxBeaconDistance {
 			maxBeaconDistance = sensor.distanceToBeacon
 		}
 	}
-	cavern := Cavern{nodes: nodes, sensors: sensors, dim: dim, maxBeaconDistance: maxBeaconDistance}
+	cavern := Cavern{nodes: nodes, sensors: sensors, maxBeaconDistance: maxBeaconDistance}
 	return &cavern
 }
 
 // Returns content of target node
-func (cavern *Cavern) GetContent(node Node) int {
-	// Check map for content
-	content, exists := cavern.nodes[node]
+func (cavern *Cavern) GetContent(node grid.Point) int {
+	// Check grid for content
+	content, exists := cavern.nodes.Get(node)
 	if !exists {
 		content = -1
 	}
@@ -121,7 +98,7 @@ func (cavern *Cavern) GetContent(node Node) int {
 // Checks if node is beacon void by calculating distance to all sensors and
 // checking if the distance from the node to the sensor is less than or equal
 // to the distance from sensor to its beacon
-func (cavern *Cavern) IsVoid(node Node) bool {
+func (cavern *Cavern) IsVoid(node grid.Point) bool {
 	content := cavern.GetContent(node)
 	if content == 2 {
 		return false
@@ -130,7 +107,7 @@ func (cavern *Cavern) IsVoid(node Node) bool {
 		return true
 	}
 	for _, sensor := range cavern.sensors {
-		distanceToSensor := calculateManhattanDistance(node, sensor.node)
+		distanceToSensor := grid.Manhattan(node, sensor.node)
 		if distanceToSensor <= sensor.distanceToBeacon {
 			return true
 		}
@@ -138,64 +115,56 @@ func (cavern *Cavern) IsVoid(node Node) bool {
 	return false
 }
 
-// Update node map dimensions based on given node
-func updateDimensions(dim [4]int, node Node) [4]int {
-	x, y := node[0], node[1]
-	if dim[0] > x {
-		dim[0] = x
-	} else if dim[1] < x {
-		dim[1] = x
+// Draws sensors, beacons and the area they cover, following the node being
+// checked
+func (cavern *Cavern) Frame() visual.Frame {
+	charMap := map[int]rune{
+		0: '#',
+		1: 'S',
+		2: 'B',
 	}
-	if dim[2] > y {
-		dim[2] = y
-	} else if dim[3] < y {
-		dim[3] = y
-	}
-	return dim
-}
-
-// Prints cavern map to console
-func (cavern *Cavern) printMap() {
-	charMap := map[int]string{
-		-1: ".",
-		0:  "#",
-		1:  "S",
-		2:  "B",
-	}
-	for y := cavern.dim[2]; y <= cavern.dim[3]; y++ {
-		for x := cavern.dim[0]; x <= cavern.dim[1]; x++ {
-			sign := " "
-			content, ok := cavern.nodes[Node{x, y}]
-			if ok {
-				sign = charMap[content]
+	return visual.Frame{
+		Title:  fmt.Sprintf("Checking x=%d, y=%d", cavern.scanning[0], cavern.scanning[1]),
+		Bounds: cavern.nodes.Bounds().Extend(cavern.scanning),
+		Glyph: func(node grid.Point) rune {
+			if node == cavern.scanning {
+				return '@'
 			}
-			fmt.Print(sign)
-		}
-		fmt.Print("\n")
+			if glyph, ok := charMap[cavern.GetContent(node)]; ok {
+				return glyph
+			}
+			if cavern.IsVoid(node) {
+				return '#'
+			}
+			return '.'
+		},
+		Focus: cavern.scanning,
 	}
 }
 
-// Parses puzzle input from txt file.
+// Parses puzzle input from reader.
 // Returns node map of cavern.
-func readInput(path string) *Cavern {
-	inputBytes, err := os.ReadFile(path)
-	check(err)
-	lines := strings.Split(strings.ReplaceAll(string(inputBytes), "\r\n", "\n"), "\n")
-	sensors := map[Node]Sensor{}
+func readInput(r io.Reader) (*Cavern, error) {
+	input, err := aoc.ReadFrom(r)
+	if err != nil {
+		return nil, err
+	}
+	sensors := map[grid.Point]Sensor{}
 	// Generate sensor/beacon map pointing to signals from input strings
-	for _, line := range lines {
-		split := strings.Split(line, "=")
-		sensorX, _ := strconv.Atoi(strings.Split(split[1], ",")[0])
-		sensorY, _ := strconv.Atoi(strings.Split(split[2], ":")[0])
-		beaconX, _ := strconv.Atoi(strings.Split(split[3], ",")[0])
-		beaconY, _ := strconv.Atoi(strings.Split(split[4], ":")[0])
-		sensorNode := Node{sensorX, sensorY}
-		beaconNode := Node{beaconX, beaconY}
+	for i, line := range input.Lines {
+		var sensorX, sensorY, beaconX, beaconY int
+		_, err := fmt.Sscanf(line, "Sensor at x=%d, y=%d: closest beacon is at x=%d, y=%d",
+			&sensorX, &sensorY, &beaconX, &beaconY)
+		if err != nil {
+			return nil, input.Errorf(i, "", "expected sensor like \"Sensor at x=2, y=18: closest beacon is at x=-2, y=15\"")
+		}
+		sensorNode := grid.Point{sensorX, sensorY}
+		beaconNode := grid.Point{beaconX, beaconY}
 		sensor := NewSensor(sensorNode, beaconNode)
 		sensors[sensorNode] = sensor
 	}
 	cavern := NewCavern(sensors)
-	return cavern
+	return cavern, nil
 }
 
 // Part 1: In the row where y=2000000, how many positions cannot contain a beacon?
@@ -206,22 +175,21 @@ func solvePart1(cavern *Cavern, test bool) int {
 	if test {
 		y = 10
 	}
-	startX := cavern.dim[0] - cavern.maxBeaconDistance
-	endX := cavern.dim[1] + cavern.maxBeaconDistance
+	bounds := cavern.nodes.Bounds()
+	startX := bounds.Min[0] - cavern.maxBeaconDistance
+	endX := bounds.Max[0] + cavern.maxBeaconDistance
 	for x := startX; x < endX; x++ {
-		node := Node{x, y}
+		node := grid.Point{x, y}
+		cavern.scanning = node
+		cavern.animation.Step(cavern)
 		if cavern.IsVoid(node) {
-			cavern.dim = updateDimensions(cavern.dim, node)
-			content := cavern.GetContent(Node{x, y})
+			content := cavern.GetContent(node)
 			if content == -1 {
-				cavern.nodes[node] = 0
+				cavern.nodes.Set(node, 0)
 			}
 			voidCount++
 		}
 	}
-	if test {
-		cavern.printMap()
-	}
 	return voidCount
 }
 
@@ -236,6 +204,8 @@ func solvePart2(cavern *Cavern, test bool) int {
 			if boundaryNode[0] < 0 || boundaryNode[0] > maxIdx || boundaryNode[1] < 0 || boundaryNode[1] > maxIdx {
 				continue
 			}
+			cavern.scanning = boundaryNode
+			cavern.animation.Step(cavern)
 			if !cavern.IsVoid(boundaryNode) {
 				content := cavern.GetContent(boundaryNode)
 				if content == -1 {
@@ -248,16 +218,41 @@ func solvePart2(cavern *Cavern, test bool) int {
 }
 
 // Solves puzzle parts. Split up for benchmarking
-func solvePuzzle() (int, int) {
-	input := readInput(inputPath)
+func solvePuzzle() (int, int, error) {
+	input, err := aoc.ReadFile(inputPath, readInput)
+	if err != nil {
+		return 0, 0, err
+	}
 	answer1 := solvePart1(input, false)
 	answer2 := solvePart2(input, false)
-	return answer1, answer2
+	return answer1, answer2, nil
+}
+
+// Registers puzzle parts with the aoc runner
+var puzzle = aoc.Puzzle[*Cavern, int, int]{
+	Year: 2022, Day: 15,
+	ReadInput: readInput,
+	SolvePart1: aoc.NoError(func(cavern *Cavern) int {
+		return solvePart1(cavern, false)
+	}),
+	SolvePart2: aoc.NoError(func(cavern *Cavern) int {
+		return solvePart2(cavern, false)
+	}),
+	Labels: [2]string{
+		"Positions without beacons in row 2000000",
+		"Distress beacon tuning frequency",
+	},
+	Gen: &aoc.Generator{
+		Size: 25, SizeHelp: "sensors",
+		Generate: generateInput,
+	},
+	Animate: func(cavern *Cavern, animation *visual.Animation) *Cavern {
+		cavern.animation = animation
+		return cavern
+	},
 }
 
 // Reads input, solves puzzle parts and logs answers
 func main() {
-	answer1, answer2 := solvePuzzle()
-	log.Printf("Positions without beacons in row 2000000: %v\n", answer1)
-	log.Printf("Distress beacon tuning frequency: %v\n", answer2)
+	aoc.Main(puzzle)
 }
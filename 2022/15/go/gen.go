@@ -0,0 +1,35 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"math/rand"
+
+	"github.com/erikzak/adventofcode/2022/aoc/grid"
+)
+
+// Generates size random sensors in the distress beacon search area. Every
+// sensor reaches almost, but not quite, to a hidden point, so the area
+// always has at least one position no sensor covers
+func generateInput(rng *rand.Rand, size int, w *bufio.Writer) {
+	const maxIdx = 4000000
+	hidden := grid.Point{rng.Intn(maxIdx + 1), rng.Intn(maxIdx + 1)}
+	for i := 0; i < size; i++ {
+		sensor := hidden
+		for grid.Manhattan(sensor, hidden) < 2 {
+			sensor = grid.Point{rng.Intn(maxIdx + 1), rng.Intn(maxIdx + 1)}
+		}
+		distance := grid.Manhattan(sensor, hidden) - 1
+		distance -= rng.Intn(distance/20 + 1)
+		dx := rng.Intn(distance + 1)
+		dy := distance - dx
+		if rng.Intn(2) == 0 {
+			dx = -dx
+		}
+		if rng.Intn(2) == 0 {
+			dy = -dy
+		}
+		fmt.Fprintf(w, "Sensor at x=%d, y=%d: closest beacon is at x=%d, y=%d\n",
+			sensor.X(), sensor.Y(), sensor.X()+dx, sensor.Y()+dy)
+	}
+}
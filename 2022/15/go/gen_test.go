@@ -0,0 +1,15 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/erikzak/adventofcode/2022/aoc/aoctest"
+)
+
+// Tests that generated input parses and solves
+func TestGeneratedInput(t *testing.T) {
+	// Part 1 scans a row millions of positions wide, however few the sensors
+	aoctest.SolveTimeout = time.Minute
+	aoctest.GeneratedInput(t, puzzle, readInput)
+}
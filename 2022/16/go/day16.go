@@ -3,21 +3,18 @@
 package main
 
 import (
+	"context"
 	"fmt"
-	"log"
-	"os"
-	"strconv"
+	"io"
 	"strings"
+
+	"github.com/erikzak/adventofcode/2022/aoc"
+	"github.com/erikzak/adventofcode/2022/aoc/progress"
+	"github.com/erikzak/adventofcode/2022/aoc/search"
 )
 
 const inputPath = "../input.txt"
 
-func check(err error) {
-	if err != nil {
-		log.Panic(err)
-	}
-}
-
 // Keeps track of valve system and cumulative pressure released
 type Cave struct {
 	valves            map[string]Valve
@@ -33,66 +30,52 @@ func NewCave(valves map[string]Valve) Cave {
 			cave.workingValveCount++
 		}
 		// Calculate distance to all other valves
-		for name, target := range valves {
+		reachable := cave.BFS(valve)
+		for name := range valves {
 			if name == valve.name {
 				continue
 			}
-			valve.distances[name] = cave.BFS(valve, target)
+			if distance, ok := reachable.CostTo(name); ok {
+				valve.distances[name] = distance
+			}
 		}
 	}
 	return cave
 }
 
-// Uses breadth-first search to calculate distance between valves.
-// Returns node distance as integer.
-func (cave Cave) BFS(start Valve, end Valve) int {
-	queue := []Valve{start}
-	parents := map[string]string{}
-	explored := map[string]struct{}{start.name: {}}
-	for {
-		node := queue[0]
-		if node.name == end.name {
-			return getBFSDistance(end.name, parents)
-		}
-		queue = queue[1:]
-		for _, tunnel := range node.tunnels {
-			_, done := explored[tunnel]
-			if done {
-				continue
-			}
-			explored[tunnel] = struct{}{}
-			parents[tunnel] = node.name
-			queue = append(queue, cave.valves[tunnel])
-		}
-	}
+// Uses breadth-first search to find distances from start to every valve it
+// can reach
+func (cave Cave) BFS(start Valve) *search.Result[string] {
+	return search.BFS[string](cave, nil, start.name)
 }
 
-// Recreates path from start to end. Returns length of path
-func getBFSDistance(end string, parents map[string]string) (distance int) {
-	parent := parents[end]
-	var found bool
-	distance++
-	for {
-		parent, found = parents[parent]
-		if !found {
-			break
-		}
-		distance++
-	}
-	return distance
+// Returns edges through the tunnels leading out of the named valve
+func (cave Cave) Neighbors(name string) []search.Edge[string] {
+	return search.Unweighted(cave.valves[name].tunnels)
 }
 
-// Simulates possible routes and finds the one with most pressure released
+// Simulates possible routes and finds the one with most pressure released.
+// Returns the context error if ctx is done before all routes are simulated
 func (cave Cave) getMaxPossiblePressureReleased(
-	startingLocation string, maxMinutes int, openedValves map[string]struct{},
-) Route {
+	ctx context.Context, startingLocation string, maxMinutes int, openedValves map[string]struct{},
+) (Route, error) {
+	tracker := progress.FromContext(ctx)
 	root := NewRoute(cave.valves[startingLocation], openedValves)
 	bestRoute := root
 	routes := []Route{root}
-	for {
+	for explored := 1; ; explored++ {
 		if len(routes) == 0 {
 			break
 		}
+		// Check for cancellation and publish progress now and then
+		if explored%progress.Batch == 0 {
+			if err := ctx.Err(); err != nil {
+				return bestRoute, err
+			}
+			tracker.Explore(progress.Batch)
+			tracker.Queue(len(routes))
+			tracker.Best(bestRoute.pressureReleased)
+		}
 
 		// Pop next queue item
 		route := routes[0]
@@ -151,8 +134,7 @@ func (cave Cave) getMaxPossiblePressureReleased(
 			routes = append(routes, nextStep)
 		}
 	}
-	fmt.Print(bestRoute.history)
-	return bestRoute
+	return bestRoute, nil
 }
 
 func removeRoute(s []Route, i int) []Route {
@@ -167,7 +149,6 @@ type Route struct {
 	opened           map[string]struct{}
 	minutesElapsed   int
 	pressureReleased int
-	history          string
 }
 
 func NewRoute(start Valve, opened map[string]struct{}) Route {
@@ -179,7 +160,7 @@ func NewRoute(start Valve, opened map[string]struct{}) Route {
 }
 
 func CopyRoute(route Route) Route {
-	copy := Route{minutesElapsed: route.minutesElapsed, pressureReleased: route.pressureReleased, history: route.history}
+	copy := Route{minutesElapsed: route.minutesElapsed, pressureReleased: route.pressureReleased}
 	copy.valves = []Valve{}
 	copy.valves = append(copy.valves, route.valves...)
 	copy.opened = map[string]struct{}{}
@@ -214,54 +195,101 @@ func NewValve(name string, flowRate int, tunnels []string) Valve {
 	return valve
 }
 
-// Parses puzzle input from txt file.
+// Parses puzzle input from reader.
 // Returns cave system of valves.
-func readInput(path string) Cave {
-	inputBytes, err := os.ReadFile(path)
-	check(err)
-	lines := strings.Split(strings.ReplaceAll(string(inputBytes), "\r\n", "\n"), "\n")
+func readInput(r io.Reader) (Cave, error) {
+	input, err := aoc.ReadFrom(r)
+	if err != nil {
+		return Cave{}, err
+	}
 	valves := map[string]Valve{}
-	for _, line := range lines {
-		split := strings.Split(line, " ")
+	for i := range input.Lines {
+		split, err := input.Fields(i, 10)
+		if err != nil {
+			return Cave{}, err
+		}
 		name := split[1]
-		flowRate, _ := strconv.Atoi(strings.Trim(strings.Split(split[4], "=")[1], ";"))
+		_, rate, isRate := strings.Cut(split[4], "rate=")
+		if !isRate || !strings.HasSuffix(rate, ";") {
+			return Cave{}, input.Errorf(i, split[4], "expected flow rate like \"rate=20;\", got %q", split[4])
+		}
+		flowRate, err := input.Atoi(i, strings.TrimSuffix(rate, ";"))
+		if err != nil {
+			return Cave{}, err
+		}
 		tunnels := []string{}
 		for _, tunnel := range split[9:] {
 			tunnels = append(tunnels, strings.Trim(tunnel, ","))
 		}
 		valves[name] = NewValve(name, flowRate, tunnels)
 	}
+	// Tunnels can only lead to scanned valves
+	for i := range input.Lines {
+		split := strings.Fields(input.Lines[i])
+		for _, tunnel := range split[9:] {
+			tunnel = strings.Trim(tunnel, ",")
+			if _, ok := valves[tunnel]; !ok {
+				return Cave{}, input.Errorf(i, tunnel, "tunnel to unknown valve %q", tunnel)
+			}
+		}
+	}
 	cave := NewCave(valves)
-	return cave
+	return cave, nil
 }
 
 // Part 1: What is the most pressure you can release?
-func solvePart1(cave Cave) int {
+func solvePart1(ctx context.Context, cave Cave) (int, error) {
 	openedValves := map[string]struct{}{}
-	bestRoute := cave.getMaxPossiblePressureReleased("AA", 30, openedValves)
-	return bestRoute.pressureReleased
+	bestRoute, err := cave.getMaxPossiblePressureReleased(ctx, "AA", 30, openedValves)
+	return bestRoute.pressureReleased, err
 }
 
 // Part 2: With you and an elephant working together for 26 minutes, what is
 // the most pressure you could release?
-func solvePart2(cave Cave) int {
+func solvePart2(ctx context.Context, cave Cave) (int, error) {
 	openedValves := map[string]struct{}{}
-	firstRoute := cave.getMaxPossiblePressureReleased("AA", 26, openedValves)
-	secondRoute := cave.getMaxPossiblePressureReleased("AA", 26, firstRoute.opened)
-	return firstRoute.pressureReleased + secondRoute.pressureReleased
+	firstRoute, err := cave.getMaxPossiblePressureReleased(ctx, "AA", 26, openedValves)
+	if err != nil {
+		return 0, err
+	}
+	secondRoute, err := cave.getMaxPossiblePressureReleased(ctx, "AA", 26, firstRoute.opened)
+	return firstRoute.pressureReleased + secondRoute.pressureReleased, err
 }
 
 // Solves puzzle parts. Split up for benchmarking
-func solvePuzzle() (int, int) {
-	input := readInput(inputPath)
-	answer1 := solvePart1(input)
-	answer2 := solvePart2(input)
-	return answer1, answer2
+func solvePuzzle() (int, int, error) {
+	input, err := aoc.ReadFile(inputPath, readInput)
+	if err != nil {
+		return 0, 0, err
+	}
+	answer1, err := solvePart1(context.Background(), input)
+	if err != nil {
+		return 0, 0, err
+	}
+	answer2, err := solvePart2(context.Background(), input)
+	if err != nil {
+		return 0, 0, err
+	}
+	return answer1, answer2, nil
+}
+
+// Registers puzzle parts with the aoc runner
+var puzzle = aoc.Puzzle[Cave, int, int]{
+	Year: 2022, Day: 16,
+	ReadInput:  readInput,
+	SolvePart1: solvePart1,
+	SolvePart2: solvePart2,
+	Labels: [2]string{
+		"Most pressure that can be released",
+		"Most pressure that can be released with elephant",
+	},
+	Gen: &aoc.Generator{
+		Size: 40, MinSize: 2, MaxSize: maxValves, SizeHelp: "valves",
+		Generate: generateInput,
+	},
 }
 
 // Reads input, solves puzzle parts and logs answers
 func main() {
-	answer1, answer2 := solvePuzzle()
-	log.Printf("Most pressure that can be released: %v\n", answer1)
-	log.Printf("Most pressure that can be released with elephant: %v\n", answer2)
+	aoc.Main(puzzle)
 }
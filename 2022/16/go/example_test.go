@@ -2,14 +2,23 @@
 package main
 
 import (
+	"context"
 	"testing"
+
+	"github.com/erikzak/adventofcode/2022/aoc"
 )
 
 // Tests part 1 against example data
 func TestPart1Example(t *testing.T) {
 	want := 1651
-	input := readInput("../test.txt")
-	answer1 := solvePart1(input)
+	input, err := aoc.ReadFile("../test.txt", readInput)
+	if err != nil {
+		t.Fatal(err)
+	}
+	answer1, err := solvePart1(context.Background(), input)
+	if err != nil {
+		t.Fatal(err)
+	}
 	if answer1 != want {
 		t.Fatalf(`solvePart1() = %v, want %v`, answer1, want)
 	}
@@ -18,8 +27,14 @@ func TestPart1Example(t *testing.T) {
 // Tests part 2 against example data
 func TestPart2Example(t *testing.T) {
 	want := 1707
-	input := readInput("../test.txt")
-	answer2 := solvePart2(input)
+	input, err := aoc.ReadFile("../test.txt", readInput)
+	if err != nil {
+		t.Fatal(err)
+	}
+	answer2, err := solvePart2(context.Background(), input)
+	if err != nil {
+		t.Fatal(err)
+	}
 	if answer2 != want {
 		t.Fatalf(`solvePart2() = %v, want %v`, answer2, want)
 	}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"math/rand"
+	"strings"
+)
+
+// Valve names are two capital letters, so caves have at most this many valves
+const maxValves = 26 * 26
+
+// Generates cave of size valves with random names, starting at AA. About a
+// quarter of the valves work. Tunnels connect all valves, with a few loops
+func generateInput(rng *rand.Rand, size int, w *bufio.Writer) {
+	names := []string{"AA"}
+	used := map[string]bool{"AA": true}
+	for len(names) < size {
+		name := string([]byte{byte('A' + rng.Intn(26)), byte('A' + rng.Intn(26))})
+		if !used[name] {
+			used[name] = true
+			names = append(names, name)
+		}
+	}
+	tunnels := map[string][]string{}
+	connect := func(a string, b string) {
+		for _, tunnel := range tunnels[a] {
+			if a == b || tunnel == b {
+				return
+			}
+		}
+		tunnels[a] = append(tunnels[a], b)
+		tunnels[b] = append(tunnels[b], a)
+	}
+	// Random spanning tree keeps every valve reachable
+	for i := 1; i < size; i++ {
+		connect(names[i], names[rng.Intn(i)])
+	}
+	for i := 0; i < size/4; i++ {
+		connect(names[rng.Intn(size)], names[rng.Intn(size)])
+	}
+	rng.Shuffle(len(names), func(i, j int) {
+		names[i], names[j] = names[j], names[i]
+	})
+	for _, name := range names {
+		flowRate := 0
+		if name != "AA" && rng.Intn(4) == 0 {
+			flowRate = 1 + rng.Intn(25)
+		}
+		if len(tunnels[name]) == 1 {
+			fmt.Fprintf(w, "Valve %s has flow rate=%d; tunnel leads to valve %s\n", name, flowRate, tunnels[name][0])
+			continue
+		}
+		fmt.Fprintf(w, "Valve %s has flow rate=%d; tunnels lead to valves %s\n", name, flowRate, strings.Join(tunnels[name], ", "))
+	}
+}
@@ -0,0 +1,30 @@
+package main
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/erikzak/adventofcode/2022/aoc/aoctest"
+)
+
+// Tests that generated input parses and solves
+func TestGeneratedInput(t *testing.T) {
+	aoctest.GeneratedInput(t, puzzle, readInput)
+}
+
+// Tests that sizes beyond the valve names available are refused, rather than
+// looking for unused names forever
+func TestGenerateMaxSize(t *testing.T) {
+	buffer := &bytes.Buffer{}
+	if err := puzzle.Gen.Write(buffer, 1, maxValves); err != nil {
+		t.Fatal(err)
+	}
+	if lines := strings.Count(buffer.String(), "\n"); lines != maxValves {
+		t.Fatalf(`Write(%d) = %d valves, want %d`, maxValves, lines, maxValves)
+	}
+	if err := puzzle.Gen.Write(io.Discard, 1, maxValves+1); err == nil {
+		t.Fatalf(`Write(%d) did not fail`, maxValves+1)
+	}
+}
@@ -1,24 +1,35 @@
 package main
 
-import "testing"
+import (
+	"testing"
+
+	"github.com/erikzak/adventofcode/2022/aoc"
+)
 
 // Benchmark full solve
 func BenchmarkSolvePuzzle(b *testing.B) {
 	for i := 0; i < b.N; i++ {
-		solvePuzzle()
+		if _, _, err := solvePuzzle(); err != nil {
+			b.Fatal(err)
+		}
 	}
 }
 
 // Benchmark input parsing
 func BenchmarkReadInput(b *testing.B) {
 	for i := 0; i < b.N; i++ {
-		readInput(inputPath)
+		if _, err := aoc.ReadFile(inputPath, readInput); err != nil {
+			b.Fatal(err)
+		}
 	}
 }
 
 // Benchmark part 1
 func BenchmarkSolvePart1(b *testing.B) {
-	input := readInput(inputPath)
+	input, err := aoc.ReadFile(inputPath, readInput)
+	if err != nil {
+		b.Fatal(err)
+	}
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
 		solvePart1(input)
@@ -27,7 +38,10 @@ func BenchmarkSolvePart1(b *testing.B) {
 
 // Benchmark part 2
 func BenchmarkSolvePart2(b *testing.B) {
-	input := readInput(inputPath)
+	input, err := aoc.ReadFile(inputPath, readInput)
+	if err != nil {
+		b.Fatal(err)
+	}
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
 		solvePart2(input)
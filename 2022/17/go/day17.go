@@ -4,22 +4,16 @@ package main
 
 import (
 	"fmt"
-	"log"
-	"os"
+	"io"
 	"reflect"
+
+	"github.com/erikzak/adventofcode/2022/aoc"
+	"github.com/erikzak/adventofcode/2022/aoc/grid"
+	"github.com/erikzak/adventofcode/2022/aoc/visual"
 )
 
 const inputPath = "../input.txt"
 
-func check(err error) {
-	if err != nil {
-		log.Panic(err)
-	}
-}
-
-// Node type for 2D grid coordinates
-type Node [2]int
-
 // Define collection of shapes, in order, along with jet pattern in cave
 type Tetris struct {
 	shapes        []*Shape
@@ -27,22 +21,24 @@ type Tetris struct {
 	jets          []rune
 	jetIdx        int
 	width         int
-	chamber       map[Node]struct{} // Keeps track of filled nodes in chamber
+	chamber       *grid.Sparse[struct{}] // Keeps track of filled nodes in chamber
 	blocks        int
-	currentHeight int // Height of top node
+	currentHeight int    // Height of top node
+	falling       *Shape // Shape being dropped, for drawing
+	animation     *visual.Animation
 }
 
 func NewTetris(jets []rune, width int) *Tetris {
-	tetris := Tetris{jets: jets, width: width, chamber: map[Node]struct{}{}}
-	minus := []Node{{0, 0}, {1, 0}, {2, 0}, {3, 0}}
+	tetris := Tetris{jets: jets, width: width, chamber: grid.NewSparse[struct{}]()}
+	minus := []grid.Point{{0, 0}, {1, 0}, {2, 0}, {3, 0}}
 	tetris.shapes = append(tetris.shapes, NewShape(minus, 4, 1))
-	plus := []Node{{1, 2}, {0, 1}, {1, 1}, {2, 1}, {1, 0}}
+	plus := []grid.Point{{1, 2}, {0, 1}, {1, 1}, {2, 1}, {1, 0}}
 	tetris.shapes = append(tetris.shapes, NewShape(plus, 3, 3))
-	arrow := []Node{{2, 2}, {2, 1}, {0, 0}, {1, 0}, {2, 0}}
+	arrow := []grid.Point{{2, 2}, {2, 1}, {0, 0}, {1, 0}, {2, 0}}
 	tetris.shapes = append(tetris.shapes, NewShape(arrow, 3, 3))
-	straight := []Node{{0, 3}, {0, 2}, {0, 1}, {0, 0}}
+	straight := []grid.Point{{0, 3}, {0, 2}, {0, 1}, {0, 0}}
 	tetris.shapes = append(tetris.shapes, NewShape(straight, 1, 4))
-	square := []Node{{0, 1}, {1, 1}, {0, 0}, {1, 0}}
+	square := []grid.Point{{0, 1}, {1, 1}, {0, 0}, {1, 0}}
 	tetris.shapes = append(tetris.shapes, NewShape(square, 2, 2))
 	return &tetris
 }
@@ -54,7 +50,7 @@ func (tetris *Tetris) getShape() *Shape {
 	if tetris.shpIdx == len(tetris.shapes) {
 		tetris.shpIdx = 0
 	}
-	shape.position = [2]int{2, tetris.currentHeight + 4}
+	shape.position = grid.Point{2, tetris.currentHeight + 4}
 	return shape
 }
 
@@ -69,12 +65,11 @@ func (tetris *Tetris) getJet() rune {
 }
 
 // Returns content of chamber node. True if filled, false if empty
-func (tetris *Tetris) isFilled(node Node) bool {
+func (tetris *Tetris) isFilled(node grid.Point) bool {
 	if node[0] < 0 || node[0] >= tetris.width {
 		return true
 	}
-	_, filled := tetris.chamber[node]
-	return filled
+	return tetris.chamber.Has(node)
 }
 
 // Moves shape left or right, if possible
@@ -108,63 +103,63 @@ func (tetris *Tetris) drop(shape *Shape) bool {
 	return false
 }
 
-// Draws chamber
-func (tetris *Tetris) draw(shape *Shape) {
-	shapeNodes := shape.getChamberNodes()
-	height := shape.position[1] + shape.height - 1
-	if height == -1 {
-		height = tetris.currentHeight
+// Draws chamber, with walls and floor, following the falling shape
+func (tetris *Tetris) Frame() visual.Frame {
+	shapeNodes := map[grid.Point]struct{}{}
+	height := tetris.currentHeight
+	focus := grid.Point{tetris.width / 2, tetris.currentHeight}
+	if tetris.falling != nil {
+		shapeNodes = tetris.falling.getChamberNodes()
+		if top := tetris.falling.position[1] + tetris.falling.height - 1; top > height {
+			height = top
+		}
+		focus = tetris.falling.position
 	}
-	for y := height; y > 0; y-- {
-		fmt.Print("|")
-		for x := 0; x < tetris.width; x++ {
-			node := Node{x, y}
+	return visual.Frame{
+		Title:  fmt.Sprintf("Rocks: %d, height: %d", tetris.blocks, tetris.currentHeight),
+		Bounds: grid.Rect{Min: grid.Point{-1, 0}, Max: grid.Point{tetris.width, height}},
+		Glyph: func(node grid.Point) rune {
+			wall := node[0] == -1 || node[0] == tetris.width
 			_, falling := shapeNodes[node]
-			_, rest := tetris.chamber[node]
-			if falling {
-				fmt.Print("@")
-			} else if rest {
-				fmt.Print("#")
-			} else {
-				fmt.Print(".")
+			switch {
+			case node[1] == 0 && wall:
+				return '+'
+			case node[1] == 0:
+				return '-'
+			case wall:
+				return '|'
+			case falling:
+				return '@'
+			case tetris.chamber.Has(node):
+				return '#'
 			}
-		}
-		fmt.Print("|\n")
-	}
-	fmt.Print("+")
-	for i := 0; i < tetris.width; i++ {
-		fmt.Print("-")
+			return '.'
+		},
+		Up:    true,
+		Focus: focus,
 	}
-	fmt.Print("+\n\n")
 }
 
 // Plays Tetris for the defined number of pieces. Returns total tower height
 func (tetris *Tetris) Go(num int) int {
-	drawStart := -1
-	drawSteps := -1
 	for n := 0; n < num; n++ {
-		tetris.dropBlock(drawStart >= 0 && drawStart <= n, drawSteps == n)
+		tetris.dropBlock()
 	}
 	return tetris.currentHeight
 }
 
-// Drops one block, optionally drawing all drop steps or starting state
-func (tetris *Tetris) dropBlock(drawStart bool, drawSteps bool) {
+// Drops one block, stepping the animation for every push and fall
+func (tetris *Tetris) dropBlock() {
 	shape := tetris.getShape()
-	if drawStart {
-		fmt.Printf("Begin, current height = %v\n", tetris.currentHeight)
-		tetris.draw(shape)
-	}
+	tetris.falling = shape
+	tetris.animation.Step(tetris)
 	for {
 		jet := tetris.getJet()
 		tetris.move(shape, jet)
-		if drawSteps {
-			fmt.Printf("Pushes %v:\n", string(jet))
-			tetris.draw(shape)
-		}
+		tetris.animation.Step(tetris)
 		if tetris.drop(shape) {
 			for node := range shape.getChamberNodes() {
-				tetris.chamber[node] = struct{}{}
+				tetris.chamber.Set(node, struct{}{})
 			}
 			blockHeight := shape.position[1] + shape.height - 1
 			if blockHeight > tetris.currentHeight {
@@ -173,16 +168,9 @@ func (tetris *Tetris) dropBlock(drawStart bool, drawSteps bool) {
 			tetris.blocks++
 			break
 		}
-		if drawSteps {
-			fmt.Printf("Falls:\n")
-			tetris.draw(shape)
-		}
-	}
-	if drawSteps {
-		emptyShape := Shape{nodes: []Node{}, position: Node{0, 0}, height: 0}
-		fmt.Printf("Rest, current height = %v\n", tetris.currentHeight)
-		tetris.draw(&emptyShape)
+		tetris.animation.Step(tetris)
 	}
+	tetris.falling = nil
 }
 
 type heightHash struct {
@@ -193,23 +181,23 @@ type heightHash struct {
 
 // Drops blocks until a looping pattern is found, then returns the number of loop blocks and loop height
 func (tetris *Tetris) findLoop() (int, int) {
-	hashes := map[Node]heightHash{}
+	hashes := map[[2]int]heightHash{}
 	for {
-		tetris.dropBlock(false, false)
+		tetris.dropBlock()
 		// Compare top 30 blocks, and store with jet and shape index as "hash"
 		hash := heightHash{blocks: int(tetris.blocks), height: int(tetris.currentHeight)}
 		i := 0
 		for y := tetris.currentHeight; y > tetris.currentHeight-30 && y > 0; y-- {
 			for x := 0; x < tetris.width; x++ {
 				value := 0
-				if tetris.isFilled(Node{x, y}) {
+				if tetris.isFilled(grid.Point{x, y}) {
 					value = 1
 				}
 				hash.hash = append(hash.hash, value)
 				i++
 			}
 		}
-		idx := Node{tetris.shpIdx, tetris.jetIdx}
+		idx := [2]int{tetris.shpIdx, tetris.jetIdx}
 		last, seen := hashes[idx]
 		if seen && reflect.DeepEqual(last.hash, hash.hash) {
 			return tetris.blocks - last.blocks, tetris.currentHeight - last.height
@@ -220,37 +208,44 @@ func (tetris *Tetris) findLoop() (int, int) {
 
 // Keeps track of shape properties, with grid node map and dimensions
 type Shape struct {
-	nodes    []Node
+	nodes    []grid.Point
 	height   int
 	width    int
-	position Node // Chamber index of bottom-left node
+	position grid.Point // Chamber index of bottom-left node
 }
 
-func NewShape(nodes []Node, width int, height int) *Shape {
+func NewShape(nodes []grid.Point, width int, height int) *Shape {
 	return &Shape{nodes: nodes, width: width, height: height}
 }
 
 // Get chamber position of shape nodes
-func (shape *Shape) getChamberNodes() map[Node]struct{} {
-	nodes := map[Node]struct{}{}
+func (shape *Shape) getChamberNodes() map[grid.Point]struct{} {
+	nodes := map[grid.Point]struct{}{}
 	for _, node := range shape.nodes {
-		nodes[Node{node[0] + shape.position[0], node[1] + shape.position[1]}] = struct{}{}
+		nodes[node.Add(shape.position)] = struct{}{}
 	}
 	return nodes
 }
 
-// Parses puzzle input from txt file.
+// Parses puzzle input from reader.
 // Returns tetris instace with shapes and jet pattern
-func readInput(path string) *Tetris {
-	inputBytes, err := os.ReadFile(path)
-	check(err)
-	inputString := string(inputBytes)
+func readInput(r io.Reader) (*Tetris, error) {
+	input, err := aoc.ReadFrom(r)
+	if err != nil {
+		return nil, err
+	}
+	if len(input.Lines) > 1 {
+		return nil, input.Errorf(1, "", "expected jet pattern on a single line")
+	}
 	jets := []rune{}
-	for _, r := range inputString {
+	for i, r := range input.Lines[0] {
+		if r != '<' && r != '>' {
+			return nil, input.ErrorAt(0, i, fmt.Errorf("invalid jet %q", r))
+		}
 		jets = append(jets, r)
 	}
 	tetris := NewTetris(jets, 7)
-	return tetris
+	return tetris, nil
 }
 
 // Part 1: How many units tall will the tower of rocks be after 2022 rocks have stopped falling?
@@ -275,17 +270,41 @@ func solvePart2(tetris *Tetris) int {
 }
 
 // Solves puzzle parts. Split up for benchmarking
-func solvePuzzle() (int, int) {
-	input := readInput(inputPath)
+func solvePuzzle() (int, int, error) {
+	input, err := aoc.ReadFile(inputPath, readInput)
+	if err != nil {
+		return 0, 0, err
+	}
 	answer1 := solvePart1(input)
-	input = readInput(inputPath)
+	input, err = aoc.ReadFile(inputPath, readInput)
+	if err != nil {
+		return 0, 0, err
+	}
 	answer2 := solvePart2(input)
-	return answer1, answer2
+	return answer1, answer2, nil
+}
+
+// Registers puzzle parts with the aoc runner
+var puzzle = aoc.Puzzle[*Tetris, int, int]{
+	Year: 2022, Day: 17,
+	ReadInput:  readInput,
+	SolvePart1: aoc.NoError(solvePart1),
+	SolvePart2: aoc.NoError(solvePart2),
+	Labels: [2]string{
+		"Tower height after 2022 rocks",
+		"Tower height after 1000000000000 rocks",
+	},
+	Gen: &aoc.Generator{
+		Size: 10091, SizeHelp: "jets",
+		Generate: generateInput,
+	},
+	Animate: func(tetris *Tetris, animation *visual.Animation) *Tetris {
+		tetris.animation = animation
+		return tetris
+	},
 }
 
 // Reads input, solves puzzle parts and logs answers
 func main() {
-	answer1, answer2 := solvePuzzle()
-	log.Printf("Tower height after 2022 rocks: %v\n", answer1)
-	log.Printf("Tower height after 1000000000000 rocks: %v\n", answer2)
+	aoc.Main(puzzle)
 }
@@ -0,0 +1,14 @@
+package main
+
+import (
+	"bufio"
+	"math/rand"
+)
+
+// Generates random jet pattern of size pushes
+func generateInput(rng *rand.Rand, size int, w *bufio.Writer) {
+	for i := 0; i < size; i++ {
+		w.WriteByte("<>"[rng.Intn(2)])
+	}
+	w.WriteString("\n")
+}
@@ -3,20 +3,17 @@
 package main
 
 import (
-	"log"
-	"os"
-	"strconv"
-	"strings"
+	"fmt"
+	"io"
+
+	"github.com/erikzak/adventofcode/2022/aoc"
+	"github.com/erikzak/adventofcode/2022/aoc/grid"
+	"github.com/erikzak/adventofcode/2022/aoc/search"
+	"github.com/erikzak/adventofcode/2022/aoc/visual"
 )
 
 const inputPath = "../input.txt"
 
-func check(err error) {
-	if err != nil {
-		log.Panic(err)
-	}
-}
-
 // Node type for 3D grid coordinates
 type Node [3]int
 
@@ -24,6 +21,10 @@ type Node [3]int
 type Droplet struct {
 	nodes map[Node]int // 0 = undefined, 1 = rock, 2 = internal air, 3 = external air
 	max   [3]int
+
+	animation *visual.Animation
+	current   Node // Cube last checked, for animation
+	exposed   int  // Sides counted so far, for animation
 }
 
 func NewDroplet(nodes map[Node]int) *Droplet {
@@ -70,35 +71,64 @@ func (droplet *Droplet) getNeighbors(node Node) []Node {
 // Checks if air pocket node is interior or exterior
 func (droplet *Droplet) isInteriorAir(node Node) bool {
 	// Uses breadth-first search to check if outside is reachable
-	queue := []Node{node}
-	explored := map[Node]struct{}{node: {}}
-	for {
-		node := queue[0]
-		if node[0] < 0 || node[0] > droplet.max[0] ||
+	isOutside := func(node Node) bool {
+		return node[0] < 0 || node[0] > droplet.max[0] ||
 			node[1] < 0 || node[1] > droplet.max[1] ||
-			node[2] < 0 || node[2] > droplet.max[2] {
-			return false
-		}
-		queue = queue[1:]
-		for _, neighbor := range droplet.getNeighbors(node) {
-			_, checked := explored[neighbor]
-			if checked || droplet.getContent(neighbor) == 1 {
-				continue
-			}
-			explored[neighbor] = struct{}{}
-			queue = append(queue, neighbor)
-		}
-		if len(queue) == 0 {
-			break
-		}
+			node[2] < 0 || node[2] > droplet.max[2]
+	}
+	result := search.BFS[Node](droplet, isOutside, node)
+	if result.Found {
+		return false
 	}
 	// Is internal air pocket. Set all explored node content to 2
-	for node := range explored {
+	for _, node := range result.States() {
 		droplet.nodes[node] = 2
 	}
 	return true
 }
 
+// Returns edges to neighbor nodes that aren't rock
+func (droplet *Droplet) Neighbors(node Node) []search.Edge[Node] {
+	edges := []search.Edge[Node]{}
+	for _, neighbor := range droplet.getNeighbors(node) {
+		if droplet.getContent(neighbor) != 1 {
+			edges = append(edges, search.Edge[Node]{To: neighbor, Cost: 1})
+		}
+	}
+	return edges
+}
+
+// Records sides of node counted, drawing the layer of node if animated
+func (droplet *Droplet) counted(node Node, sides int) {
+	droplet.current = node
+	droplet.exposed += sides
+	droplet.animation.Step(droplet)
+}
+
+// Draws the layer of the cube last checked, with lava as #, air pockets
+// found inside as o and the cube as @
+func (droplet *Droplet) Frame() visual.Frame {
+	z := droplet.current[2]
+	return visual.Frame{
+		Title:  fmt.Sprintf("Layer z=%d, sides counted: %d", z, droplet.exposed),
+		Bounds: grid.Rect{Max: grid.Point{droplet.max[0], droplet.max[1]}},
+		Glyph: func(p grid.Point) rune {
+			node := Node{p.X(), p.Y(), z}
+			if node == droplet.current {
+				return '@'
+			}
+			switch droplet.getContent(node) {
+			case 1:
+				return '#'
+			case 2:
+				return 'o'
+			}
+			return ' '
+		},
+		Focus: grid.Point{droplet.current[0], droplet.current[1]},
+	}
+}
+
 // Inspects neighbors of given node. Returns number of unconnected sides
 func (droplet *Droplet) checkSides(node Node, includeInterior bool) int {
 	unconnectedSides := 0
@@ -128,30 +158,39 @@ func (droplet *Droplet) checkSides(node Node, includeInterior bool) int {
 	return unconnectedSides
 }
 
-// Parses puzzle input from txt file.
+// Parses puzzle input from reader.
 // Returns droplet instace
-func readInput(path string) *Droplet {
-	inputBytes, err := os.ReadFile(path)
-	check(err)
-	lines := strings.Split(strings.ReplaceAll(string(inputBytes), "\r\n", "\n"), "\n")
+func readInput(r io.Reader) (*Droplet, error) {
+	input, err := aoc.ReadFrom(r)
+	if err != nil {
+		return nil, err
+	}
 	nodes := map[Node]int{}
-	for _, line := range lines {
-		split := strings.Split(line, ",")
-		x, _ := strconv.Atoi(split[0])
-		y, _ := strconv.Atoi(split[1])
-		z, _ := strconv.Atoi(split[2])
-		node := Node{x, y, z}
+	for i := range input.Lines {
+		split, err := input.Split(i, ",", 3)
+		if err != nil {
+			return nil, err
+		}
+		node := Node{}
+		for axis, field := range split {
+			node[axis], err = input.Atoi(i, field)
+			if err != nil {
+				return nil, err
+			}
+		}
 		nodes[node] = 1
 	}
 	droplet := NewDroplet(nodes)
-	return droplet
+	return droplet, nil
 }
 
 // Part 1: What is the surface area of your scanned lava droplet?
 func solvePart1(droplet *Droplet) int {
 	unconnectedSides := 0
 	for node := range droplet.nodes {
-		unconnectedSides += droplet.checkSides(node, true)
+		sides := droplet.checkSides(node, true)
+		unconnectedSides += sides
+		droplet.counted(node, sides)
 	}
 	return unconnectedSides
 }
@@ -160,23 +199,53 @@ func solvePart1(droplet *Droplet) int {
 func solvePart2(droplet *Droplet) int {
 	unconnectedSides := 0
 	for node := range droplet.nodes {
-		unconnectedSides += droplet.checkSides(node, false)
+		// Air pockets found are added to nodes, only rock is counted
+		if droplet.nodes[node] != 1 {
+			continue
+		}
+		sides := droplet.checkSides(node, false)
+		unconnectedSides += sides
+		droplet.counted(node, sides)
 	}
 	return unconnectedSides
 }
 
 // Solves puzzle parts. Split up for benchmarking
-func solvePuzzle() (int, int) {
-	input := readInput(inputPath)
+func solvePuzzle() (int, int, error) {
+	input, err := aoc.ReadFile(inputPath, readInput)
+	if err != nil {
+		return 0, 0, err
+	}
 	answer1 := solvePart1(input)
-	input = readInput(inputPath)
+	input, err = aoc.ReadFile(inputPath, readInput)
+	if err != nil {
+		return 0, 0, err
+	}
 	answer2 := solvePart2(input)
-	return answer1, answer2
+	return answer1, answer2, nil
+}
+
+// Registers puzzle parts with the aoc runner
+var puzzle = aoc.Puzzle[*Droplet, int, int]{
+	Year: 2022, Day: 18,
+	ReadInput:  readInput,
+	SolvePart1: aoc.NoError(solvePart1),
+	SolvePart2: aoc.NoError(solvePart2),
+	Labels: [2]string{
+		"Surface area of droplet",
+		"Exterior surface area of droplet",
+	},
+	Gen: &aoc.Generator{
+		Size: 2000, SizeHelp: "cubes",
+		Generate: generateInput,
+	},
+	Animate: func(droplet *Droplet, animation *visual.Animation) *Droplet {
+		droplet.animation = animation
+		return droplet
+	},
 }
 
 // Reads input, solves puzzle parts and logs answers
 func main() {
-	answer1, answer2 := solvePuzzle()
-	log.Printf("Surface area of droplet: %v\n", answer1)
-	log.Printf("Exterior surface area of droplet: %v\n", answer2)
+	aoc.Main(puzzle)
 }
@@ -2,13 +2,20 @@
 package main
 
 import (
+	"context"
 	"testing"
+
+	"github.com/erikzak/adventofcode/2022/aoc"
+	"github.com/erikzak/adventofcode/2022/aoc/visual"
 )
 
 // Tests part 1 against example data
 func TestPart1Example(t *testing.T) {
 	want := 64
-	input := readInput("../test.txt")
+	input, err := aoc.ReadFile("../test.txt", readInput)
+	if err != nil {
+		t.Fatal(err)
+	}
 	answer1 := solvePart1(input)
 	if answer1 != want {
 		t.Fatalf(`solvePart1() = %v, want %v`, answer1, want)
@@ -18,9 +25,31 @@ func TestPart1Example(t *testing.T) {
 // Tests part 2 against example data
 func TestPart2Example(t *testing.T) {
 	want := 58
-	input := readInput("../test.txt")
+	input, err := aoc.ReadFile("../test.txt", readInput)
+	if err != nil {
+		t.Fatal(err)
+	}
 	answer2 := solvePart2(input)
 	if answer2 != want {
 		t.Fatalf(`solvePart2() = %v, want %v`, answer2, want)
 	}
 }
+
+// Tests that solving part 2 animated records frames and gives the same answer
+func TestAnimatedExample(t *testing.T) {
+	source, err := aoc.LoadSource("../test.txt", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	recorder := visual.NewRecorder(visual.ExportOptions{})
+	answer, err := puzzle.SolveAnimated(context.Background(), 2, source.Reader(), visual.NewRecording(recorder))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if answer != "58" {
+		t.Fatalf(`SolveAnimated() = %v, want 58`, answer)
+	}
+	if recorder.Frames() == 0 {
+		t.Fatal(`SolveAnimated() recorded no frames`)
+	}
+}
@@ -0,0 +1,25 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"math/rand"
+)
+
+// Generates size distinct random cubes, filling about half of a box so the
+// droplet gets both surface and interior air pockets
+func generateInput(rng *rand.Rand, size int, w *bufio.Writer) {
+	side := 2
+	for side*side*side < 2*size {
+		side++
+	}
+	seen := map[Node]bool{}
+	for len(seen) < size {
+		node := Node{1 + rng.Intn(side), 1 + rng.Intn(side), 1 + rng.Intn(side)}
+		if seen[node] {
+			continue
+		}
+		seen[node] = true
+		fmt.Fprintf(w, "%d,%d,%d\n", node[0], node[1], node[2])
+	}
+}
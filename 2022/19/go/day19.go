@@ -3,20 +3,16 @@
 package main
 
 import (
-	"log"
-	"os"
-	"strconv"
-	"strings"
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/erikzak/adventofcode/2022/aoc"
+	"github.com/erikzak/adventofcode/2022/aoc/progress"
 )
 
 const inputPath = "../input.txt"
 
-func check(err error) {
-	if err != nil {
-		log.Panic(err)
-	}
-}
-
 // Costs type. Map of robot types pointing to array of cost: [ore, clay, obsidian]
 type Costs map[int][3]int // (0 = ore, 1 = clay, 2 = obsidian, 3 = geode)
 
@@ -47,14 +43,24 @@ func NewBlueprint(id int, costs Costs) *Blueprint {
 }
 
 // Runs blueprint simulation using DFS to find max geodes cracked
-// Returns blueprint quality level
-func (blueprint *Blueprint) Optimize(ticks int) int {
+// Returns blueprint quality level, or the context error if ctx is done first
+func (blueprint *Blueprint) Optimize(ctx context.Context, ticks int) (int, error) {
+	tracker := progress.FromContext(ctx)
 	queue := []*Simulation{NewSimulation(blueprint)}
-	for {
+	for explored := 1; ; explored++ {
 		// Break if queue empty
 		if len(queue) == 0 {
 			break
 		}
+		// Check for cancellation and publish progress now and then
+		if explored%progress.Batch == 0 {
+			if err := ctx.Err(); err != nil {
+				return 0, err
+			}
+			tracker.Explore(progress.Batch)
+			tracker.Queue(len(queue))
+			tracker.Best(blueprint.maxGeodes)
+		}
 		// Pop last queue item
 		sim := queue[len(queue)-1]
 		queue = queue[:len(queue)-1]
@@ -123,7 +129,7 @@ func (blueprint *Blueprint) Optimize(ticks int) int {
 	}
 	// Calculate blueprint quality
 	blueprint.quality = blueprint.id * blueprint.maxGeodes
-	return blueprint.quality
+	return blueprint.quality, nil
 }
 
 // Calculates potential geodes if only producing geode crackers for remaining ticks
@@ -176,63 +182,62 @@ func (sim *Simulation) tick(ticks int) {
 	sim.ticks += ticks
 }
 
-// Parses puzzle input from txt file.
+// Parses puzzle input from reader.
 // Returns slice of blueprint instances
-func readInput(path string) []*Blueprint {
-	inputBytes, err := os.ReadFile(path)
-	check(err)
-	lines := strings.Split(strings.ReplaceAll(string(inputBytes), "\r\n", "\n"), "\n")
+func readInput(r io.Reader) ([]*Blueprint, error) {
+	input, err := aoc.ReadFrom(r)
+	if err != nil {
+		return nil, err
+	}
 	blueprints := []*Blueprint{}
-	for i, line := range lines {
+	for i, line := range input.Lines {
+		var id, oreRobotOreCost, clayRobotOreCost int
+		var obsidianRobotOreCost, obsidianRobotClayCost int
+		var geodeRobotOreCost, geodeRobotObsidianCost int
+		_, err := fmt.Sscanf(line,
+			"Blueprint %d: Each ore robot costs %d ore. Each clay robot costs %d ore. "+
+				"Each obsidian robot costs %d ore and %d clay. Each geode robot costs %d ore and %d obsidian.",
+			&id, &oreRobotOreCost, &clayRobotOreCost,
+			&obsidianRobotOreCost, &obsidianRobotClayCost,
+			&geodeRobotOreCost, &geodeRobotObsidianCost)
+		if err != nil {
+			return nil, input.Errorf(i, "", "unexpected blueprint format: %v", err)
+		}
 		costs := Costs{}
-		split := strings.Split(line, ".")
-
 		// Ore robot
-		oreRobotFields := strings.Fields(split[0])
-		oreRobotOreCost := strToInt(oreRobotFields[len(oreRobotFields)-2])
 		costs[0] = [3]int{oreRobotOreCost, 0, 0}
 		// Clay robot
-		clayRobotFields := strings.Fields(split[1])
-		clayRobotOreCost := strToInt(clayRobotFields[len(clayRobotFields)-2])
 		costs[1] = [3]int{clayRobotOreCost, 0, 0}
 		// Obsidian robot
-		obsidianRobotFields := strings.Fields(split[2])
-		obsidianRobotOreCost := strToInt(obsidianRobotFields[len(obsidianRobotFields)-5])
-		obsidianRobotClayCost := strToInt(obsidianRobotFields[len(obsidianRobotFields)-2])
 		costs[2] = [3]int{obsidianRobotOreCost, obsidianRobotClayCost, 0}
 		// Geode robot
-		geodeRobotFields := strings.Fields(split[3])
-		geodeRobotOreCost := strToInt(geodeRobotFields[len(geodeRobotFields)-5])
-		geodeRobotObsidianCost := strToInt(geodeRobotFields[len(geodeRobotFields)-2])
 		costs[3] = [3]int{geodeRobotOreCost, 0, geodeRobotObsidianCost}
 
-		blueprint := NewBlueprint(i+1, costs)
+		blueprint := NewBlueprint(id, costs)
 		blueprints = append(blueprints, blueprint)
 	}
-	return blueprints
-}
-
-func strToInt(s string) int {
-	value, err := strconv.Atoi(s)
-	check(err)
-	return value
+	return blueprints, nil
 }
 
 // Part 1: What do you get if you add up the quality level of all of the blueprints in your list?
-func solvePart1(blueprints []*Blueprint) int {
+func solvePart1(ctx context.Context, blueprints []*Blueprint) (int, error) {
 	sumQuality := 0
 	for _, blueprint := range blueprints {
-		blueprint.Optimize(24)
+		if _, err := blueprint.Optimize(ctx, 24); err != nil {
+			return 0, err
+		}
 		sumQuality += blueprint.quality
 	}
-	return sumQuality
+	return sumQuality, nil
 }
 
 // Part 2: What do you get if you multiply max geodes together?
-func solvePart2(blueprints []*Blueprint) int {
+func solvePart2(ctx context.Context, blueprints []*Blueprint) (int, error) {
 	answer := 0
 	for i, blueprint := range blueprints {
-		blueprint.Optimize(32)
+		if _, err := blueprint.Optimize(ctx, 32); err != nil {
+			return 0, err
+		}
 		if answer == 0 {
 			answer = blueprint.maxGeodes
 		} else {
@@ -242,21 +247,47 @@ func solvePart2(blueprints []*Blueprint) int {
 			break
 		}
 	}
-	return answer
+	return answer, nil
 }
 
 // Solves puzzle parts. Split up for benchmarking
-func solvePuzzle() (int, int) {
-	input := readInput(inputPath)
-	answer1 := solvePart1(input)
-	input = readInput(inputPath)
-	answer2 := solvePart2(input)
-	return answer1, answer2
+func solvePuzzle() (int, int, error) {
+	input, err := aoc.ReadFile(inputPath, readInput)
+	if err != nil {
+		return 0, 0, err
+	}
+	answer1, err := solvePart1(context.Background(), input)
+	if err != nil {
+		return 0, 0, err
+	}
+	input, err = aoc.ReadFile(inputPath, readInput)
+	if err != nil {
+		return 0, 0, err
+	}
+	answer2, err := solvePart2(context.Background(), input)
+	if err != nil {
+		return 0, 0, err
+	}
+	return answer1, answer2, nil
+}
+
+// Registers puzzle parts with the aoc runner
+var puzzle = aoc.Puzzle[[]*Blueprint, int, int]{
+	Year: 2022, Day: 19,
+	ReadInput:  readInput,
+	SolvePart1: solvePart1,
+	SolvePart2: solvePart2,
+	Labels: [2]string{
+		"Quality level of all blueprints",
+		"Top three blueprint geodes multiplied",
+	},
+	Gen: &aoc.Generator{
+		Size: 30, MinSize: 3, SizeHelp: "blueprints",
+		Generate: generateInput,
+	},
 }
 
 // Reads input, solves puzzle parts and logs answers
 func main() {
-	answer1, answer2 := solvePuzzle()
-	log.Printf("Quality level of all blueprints: %v\n", answer1)
-	log.Printf("Top three blueprint geodes multiplied: %v\n", answer2)
+	aoc.Main(puzzle)
 }
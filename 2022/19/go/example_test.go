@@ -2,14 +2,23 @@
 package main
 
 import (
+	"context"
 	"testing"
+
+	"github.com/erikzak/adventofcode/2022/aoc"
 )
 
 // Tests part 1 against example data
 func TestPart1Example(t *testing.T) {
 	want := 33
-	input := readInput("../test.txt")
-	answer1 := solvePart1(input)
+	input, err := aoc.ReadFile("../test.txt", readInput)
+	if err != nil {
+		t.Fatal(err)
+	}
+	answer1, err := solvePart1(context.Background(), input)
+	if err != nil {
+		t.Fatal(err)
+	}
 	if answer1 != want {
 		t.Fatalf(`solvePart1() = %v, want %v`, answer1, want)
 	}
@@ -18,9 +27,28 @@ func TestPart1Example(t *testing.T) {
 // Tests part 2 against example data
 func TestPart2Example(t *testing.T) {
 	want := 62 * 56
-	input := readInput("../test.txt")
-	answer2 := solvePart2(input)
+	input, err := aoc.ReadFile("../test.txt", readInput)
+	if err != nil {
+		t.Fatal(err)
+	}
+	answer2, err := solvePart2(context.Background(), input)
+	if err != nil {
+		t.Fatal(err)
+	}
 	if answer2 != want {
 		t.Fatalf(`solvePart2() = %v, want %v`, answer2, want)
 	}
 }
+
+// Tests that optimizing stops when cancelled
+func TestOptimizeCancelled(t *testing.T) {
+	input, err := aoc.ReadFile("../test.txt", readInput)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := input[0].Optimize(ctx, 32); err != context.Canceled {
+		t.Fatalf(`Optimize() = %v, want %v`, err, context.Canceled)
+	}
+}
@@ -0,0 +1,17 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"math/rand"
+)
+
+// Generates size blueprints with random robot costs in the ranges of the
+// puzzle input
+func generateInput(rng *rand.Rand, size int, w *bufio.Writer) {
+	for i := 1; i <= size; i++ {
+		fmt.Fprintf(w, "Blueprint %d: Each ore robot costs %d ore. Each clay robot costs %d ore. "+
+			"Each obsidian robot costs %d ore and %d clay. Each geode robot costs %d ore and %d obsidian.\n",
+			i, 2+rng.Intn(3), 2+rng.Intn(3), 2+rng.Intn(3), 5+rng.Intn(16), 2+rng.Intn(3), 5+rng.Intn(16))
+	}
+}
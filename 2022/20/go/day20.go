@@ -4,26 +4,28 @@ package main
 
 import (
 	"fmt"
-	"log"
-	"os"
-	"strconv"
+	"io"
 	"strings"
+
+	"github.com/erikzak/adventofcode/2022/aoc"
+	"github.com/erikzak/adventofcode/2022/aoc/grid"
+	"github.com/erikzak/adventofcode/2022/aoc/visual"
 )
 
 const inputPath = "../input.txt"
 
-func check(err error) {
-	if err != nil {
-		log.Panic(err)
-	}
-}
-
 // File class, keeps track of numbers and mixing methods
 type File struct {
 	values  map[int]*Number
 	orders  map[int]*Number
 	indexes map[int]*Number
 	count   int
+
+	// Mixing state, for drawing
+	round     int
+	modifier  int
+	moved     *Number
+	animation *visual.Animation
 }
 
 func NewFile(numbers []*Number) File {
@@ -40,17 +42,11 @@ func NewFile(numbers []*Number) File {
 }
 
 // Mixes file to decrypt. Modifier is applied to value before index shuffling
-func (file File) Mix(modifier int, debug bool) {
-	if debug {
-		fmt.Print("Initial arrangement:\n")
-		fmt.Print(file.print() + "\n\n")
-	}
+func (file File) Mix(modifier int) {
+	file.modifier = modifier
 	for i := 0; i < file.count; i++ {
 		number := file.orders[i]
 		if number.value == 0 {
-			if debug {
-				fmt.Printf("0 does not move:\n%v\n\n", file.print())
-			}
 			continue
 		}
 		oldIdx := int(number.index)
@@ -63,23 +59,6 @@ func (file File) Mix(modifier int, debug bool) {
 		} else if number.index == file.count-1 {
 			number.index = 0
 		}
-		if debug {
-			fmt.Printf("(%v + %v = %v)\n", oldIdx, number.value*modifier%file.count, number.index)
-			if number.index == file.count-1 {
-				fmt.Printf(
-					"%v moves between %v and %v:\n",
-					number.value*modifier,
-					file.indexes[number.index].value*modifier,
-					file.indexes[0].value*modifier,
-				)
-			} else {
-				fmt.Printf("%v moves between %v and %v:\n",
-					number.value*modifier,
-					file.indexes[number.index].value*modifier,
-					file.indexes[number.index+1].value*modifier,
-				)
-			}
-		}
 		// Update affected indexes
 		affected := []*Number{}
 		if oldIdx < number.index {
@@ -99,8 +78,9 @@ func (file File) Mix(modifier int, debug bool) {
 			file.indexes[num.index] = num
 		}
 		file.indexes[number.index] = number
-		if debug {
-			fmt.Print(file.print() + "\n\n")
+		if file.animation != nil {
+			file.moved = number
+			file.animation.Step(file)
 		}
 	}
 }
@@ -109,12 +89,24 @@ func (file File) GetValue(idx int) int {
 	return file.indexes[idx%file.count].value
 }
 
-func (file File) print() string {
-	numbers := []string{}
+// Draws the arrangement on a single line, following the number last moved
+func (file File) Frame() visual.Frame {
+	line := strings.Builder{}
+	focus := 0
 	for i := 0; i < file.count; i++ {
-		numbers = append(numbers, fmt.Sprint(file.indexes[i].value))
+		if i > 0 {
+			line.WriteString(", ")
+		}
+		if file.indexes[i] == file.moved {
+			focus = line.Len()
+		}
+		line.WriteString(fmt.Sprint(file.indexes[i].value))
+	}
+	title := fmt.Sprintf("Round %d", file.round+1)
+	if file.moved != nil {
+		title += fmt.Sprintf(", moved %d", file.moved.value*file.modifier)
 	}
-	return strings.Join(numbers, ", ")
+	return visual.TextFrame(title, []string{line.String()}, grid.Point{focus, 0})
 }
 
 // Number class, keeps track of value, mixing order and current index
@@ -128,85 +120,87 @@ func NewNumber(value int, order int, index int) *Number {
 	return &Number{value: value, order: order, index: index}
 }
 
-// Parses puzzle input from txt file.
+// Parses puzzle input from reader.
 // Returns maps of Number instances referenced by order, value and (mixed) index
-func readInput(path string) File {
-	inputBytes, err := os.ReadFile(path)
-	check(err)
-	lines := strings.Split(strings.ReplaceAll(string(inputBytes), "\r\n", "\n"), "\n")
+func readInput(r io.Reader) (File, error) {
+	input, err := aoc.ReadFrom(r)
+	if err != nil {
+		return File{}, err
+	}
 
 	numbers := []*Number{}
-	for i, line := range lines {
-		value, err := strconv.Atoi(line)
-		check(err)
+	for i, line := range input.Lines {
+		value, err := input.Atoi(i, line)
+		if err != nil {
+			return File{}, err
+		}
 		number := NewNumber(value, i, i)
 		numbers = append(numbers, number)
 	}
 	file := NewFile(numbers)
-	return file
+	return file, nil
 }
 
 // Part 1: What is the sum of the three numbers that form the grove coordinates?
-func solvePart1(file File, debug bool) int {
-	file.Mix(1, debug)
+func solvePart1(file File) int {
+	file.Mix(1)
 	zeroIdx := file.values[0].index
 	answer := file.GetValue(zeroIdx+1000) +
 		file.GetValue(zeroIdx+2000) +
 		file.GetValue(zeroIdx+3000)
-	if debug {
-		fmt.Printf(
-			"\nAnswer: %v + %v + %v = %v\n",
-			file.GetValue(zeroIdx+1000),
-			file.GetValue(zeroIdx+2000),
-			file.GetValue(zeroIdx+3000),
-			answer,
-		)
-	}
 	return answer
 }
 
 // Part 2: What is the sum of the three keyed numbers that form the grove coordinates?
-func solvePart2(file File, debug bool) int {
+func solvePart2(file File) int {
 	key := 811589153
-	if debug {
-		fmt.Print("Initial arrangement:\n")
-		fmt.Printf("%v\n", file.print())
-	}
 	for i := 0; i < 10; i++ {
-		file.Mix(key, false)
-		if debug {
-			fmt.Printf("After %v round(s) of mixing:\n", i+1)
-			fmt.Printf("%v\n\n", file.print())
-		}
+		file.round = i
+		file.Mix(key)
 	}
 	zeroIdx := file.values[0].index
 	answer := file.GetValue(zeroIdx+1000)*key +
 		file.GetValue(zeroIdx+2000)*key +
 		file.GetValue(zeroIdx+3000)*key
-	if debug {
-		fmt.Printf(
-			"\nAnswer: %v + %v + %v = %v\n\n",
-			file.GetValue(zeroIdx+1000)*key,
-			file.GetValue(zeroIdx+2000)*key,
-			file.GetValue(zeroIdx+3000)*key,
-			answer,
-		)
-	}
 	return answer
 }
 
 // Solves puzzle parts. Split up for benchmarking
-func solvePuzzle() (int, int) {
-	input := readInput(inputPath)
-	answer1 := solvePart1(input, false)
-	input = readInput(inputPath)
-	answer2 := solvePart2(input, false)
-	return answer1, answer2
+func solvePuzzle() (int, int, error) {
+	input, err := aoc.ReadFile(inputPath, readInput)
+	if err != nil {
+		return 0, 0, err
+	}
+	answer1 := solvePart1(input)
+	input, err = aoc.ReadFile(inputPath, readInput)
+	if err != nil {
+		return 0, 0, err
+	}
+	answer2 := solvePart2(input)
+	return answer1, answer2, nil
+}
+
+// Registers puzzle parts with the aoc runner
+var puzzle = aoc.Puzzle[File, int, int]{
+	Year: 2022, Day: 20,
+	ReadInput:  readInput,
+	SolvePart1: aoc.NoError(solvePart1),
+	SolvePart2: aoc.NoError(solvePart2),
+	Labels: [2]string{
+		"Sum of the three numbers",
+		"Sum of the three keyed numbers",
+	},
+	Gen: &aoc.Generator{
+		Size: 5000, SizeHelp: "numbers",
+		Generate: generateInput,
+	},
+	Animate: func(file File, animation *visual.Animation) File {
+		file.animation = animation
+		return file
+	},
 }
 
 // Reads input, solves puzzle parts and logs answers
 func main() {
-	answer1, answer2 := solvePuzzle()
-	log.Printf("Sum of the three numbers: %v\n", answer1)
-	log.Printf("Sum of the three keyed numbers: %v\n", answer2)
+	aoc.Main(puzzle)
 }
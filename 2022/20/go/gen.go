@@ -0,0 +1,19 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"math/rand"
+)
+
+// Generates encrypted file of size random numbers, exactly one of them 0
+func generateInput(rng *rand.Rand, size int, w *bufio.Writer) {
+	zero := rng.Intn(size)
+	for i := 0; i < size; i++ {
+		value := 0
+		for i != zero && value == 0 {
+			value = rng.Intn(20001) - 10000
+		}
+		fmt.Fprintf(w, "%d\n", value)
+	}
+}
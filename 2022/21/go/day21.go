@@ -3,21 +3,17 @@
 package main
 
 import (
-	"log"
-	"os"
+	"fmt"
+	"io"
 	"reflect"
 	"strconv"
 	"strings"
+
+	"github.com/erikzak/adventofcode/2022/aoc"
 )
 
 const inputPath = "../input.txt"
 
-func check(err error) {
-	if err != nil {
-		log.Panic(err)
-	}
-}
-
 // Keeps track of all monkeys, with methods for solving puzzle
 type Troop struct {
 	monkeys      map[string]*Monkey
@@ -79,18 +75,24 @@ type Monkey struct {
 	dependencies []string
 }
 
-func NewMonkey(name string, job string) *Monkey {
+func NewMonkey(name string, job string) (*Monkey, error) {
 	monkey := Monkey{name: name, job: job, dependencies: []string{}}
 	value, err := strconv.Atoi(job)
 	if err == nil {
 		monkey.value = value
 	} else {
 		split := strings.Fields(job)
+		if len(split) != 3 {
+			return nil, fmt.Errorf("expected number or operation like \"pppw + sjmn\", got %q", job)
+		}
+		if !strings.Contains("+-*/", split[1]) || len(split[1]) != 1 {
+			return nil, fmt.Errorf("unknown operator %q", split[1])
+		}
 		monkey.operator = split[1]
 		monkey.dependencies = append(monkey.dependencies, split[0])
 		monkey.dependencies = append(monkey.dependencies, split[2])
 	}
-	return &monkey
+	return &monkey, nil
 }
 
 func (monkey *Monkey) Resolve(monkeys map[string]*Monkey) {
@@ -114,19 +116,26 @@ func (monkey *Monkey) Resolve(monkeys map[string]*Monkey) {
 	}
 }
 
-// Parses puzzle input from txt file.
+// Parses puzzle input from reader.
 // Returns troop of monkeys
-func readInput(path string) *Troop {
-	inputBytes, err := os.ReadFile(path)
-	check(err)
-	lines := strings.Split(strings.ReplaceAll(string(inputBytes), "\r\n", "\n"), "\n")
+func readInput(r io.Reader) (*Troop, error) {
+	input, err := aoc.ReadFrom(r)
+	if err != nil {
+		return nil, err
+	}
 	monkeys := map[string]*Monkey{}
 	dependencies := map[string][]string{}
-	for _, line := range lines {
-		split := strings.Split(line, ": ")
+	for i := range input.Lines {
+		split, err := input.Split(i, ": ", 2)
+		if err != nil {
+			return nil, err
+		}
 		name := split[0]
 		operation := split[1]
-		monkey := NewMonkey(name, operation)
+		monkey, err := NewMonkey(name, operation)
+		if err != nil {
+			return nil, input.Error(i, operation, err)
+		}
 		if monkey.value == 0 {
 			for _, dependency := range monkey.dependencies {
 				_, created := dependencies[dependency]
@@ -139,8 +148,17 @@ func readInput(path string) *Troop {
 		}
 		monkeys[name] = monkey
 	}
+	// Monkeys can only wait for monkeys in the troop
+	for i, line := range input.Lines {
+		name, _, _ := strings.Cut(line, ": ")
+		for _, dependency := range monkeys[name].dependencies {
+			if _, ok := monkeys[dependency]; !ok {
+				return nil, input.Errorf(i, dependency, "unknown monkey %q", dependency)
+			}
+		}
+	}
 	troop := NewTroop(monkeys, dependencies)
-	return troop
+	return troop, nil
 }
 
 // Part 1: What number will the monkey named root yell?
@@ -150,7 +168,6 @@ func solvePart1(troop *Troop) int {
 }
 
 // Part 2: What number do you yell to pass root's equality test?
-// 7010269744524
 func solvePart2(troop *Troop) int {
 	// Figure out which of root's dependency trees has humn
 	human := "humn"
@@ -232,17 +249,37 @@ func getHumanTree(troop *Troop, human string) []string {
 }
 
 // Solves puzzle parts. Split up for benchmarking
-func solvePuzzle() (int, int) {
-	input := readInput(inputPath)
+func solvePuzzle() (int, int, error) {
+	input, err := aoc.ReadFile(inputPath, readInput)
+	if err != nil {
+		return 0, 0, err
+	}
 	answer1 := solvePart1(input)
-	input = readInput(inputPath)
+	input, err = aoc.ReadFile(inputPath, readInput)
+	if err != nil {
+		return 0, 0, err
+	}
 	answer2 := solvePart2(input)
-	return answer1, answer2
+	return answer1, answer2, nil
+}
+
+// Registers puzzle parts with the aoc runner
+var puzzle = aoc.Puzzle[*Troop, int, int]{
+	Year: 2022, Day: 21,
+	ReadInput:  readInput,
+	SolvePart1: aoc.NoError(solvePart1),
+	SolvePart2: aoc.NoError(solvePart2),
+	Labels: [2]string{
+		"root yells",
+		"humn yells",
+	},
+	Gen: &aoc.Generator{
+		Size: 2001, MinSize: 5, SizeHelp: "monkeys",
+		Generate: generateInput,
+	},
 }
 
 // Reads input, solves puzzle parts and logs answers
 func main() {
-	answer1, answer2 := solvePuzzle()
-	log.Printf("root yells: %v\n", answer1)
-	log.Printf("humn yells: %v\n", answer2)
+	aoc.Main(puzzle)
 }
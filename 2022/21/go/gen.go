@@ -0,0 +1,148 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"math/rand"
+	"strconv"
+)
+
+// Largest product a generated monkey yells, keeping sums far from overflow
+const maxProduct = 1_000_000_000_000
+
+// Monkey in a generated troop. Value is what it yells with the listed humn
+// number, wanted what it yells with the number that passes root's test
+type genMonkey struct {
+	name   string
+	job    string
+	value  int
+	wanted int
+	human  bool // Depends on humn
+}
+
+// Generates troop of about size monkeys. One side of root depends on humn,
+// and the other side is balanced so some whole number passes root's equality
+// test. Monkeys never yell 0, the solver takes 0 for not yet resolved, and
+// divisions are exact where it matters
+func generateInput(rng *rand.Rand, size int, w *bufio.Writer) {
+	used := map[string]bool{"root": true, "humn": true}
+	newName := func() string {
+		for {
+			name := []byte{'a', 'a', 'a', 'a'}
+			for i := range name {
+				name[i] += byte(rng.Intn(26))
+			}
+			if !used[string(name)] {
+				used[string(name)] = true
+				return string(name)
+			}
+		}
+	}
+	answer, listed := 1+rng.Intn(5000), 1+rng.Intn(5000)
+	human := &genMonkey{name: "humn", job: strconv.Itoa(listed), value: listed, wanted: answer, human: true}
+	monkeys := []*genMonkey{human}
+	sides := [2][]*genMonkey{{human}, {}}
+	for i := 1; i < (size-1)/2; i++ {
+		value := 1 + rng.Intn(20)
+		leaf := &genMonkey{name: newName(), job: strconv.Itoa(value), value: value, wanted: value}
+		monkeys = append(monkeys, leaf)
+		// Other side gets the first leaf, so neither side is empty
+		side := 1
+		if i > 1 {
+			side = rng.Intn(2)
+		}
+		sides[side] = append(sides[side], leaf)
+	}
+	humanSide := mergeMonkeys(rng, sides[0], newName, &monkeys)
+	otherSide := mergeMonkeys(rng, sides[1], newName, &monkeys)
+
+	// Balance other side to what the human side yells with the answer
+	difference := humanSide.wanted - otherSide.value
+	if difference != 0 {
+		operator := "+"
+		if difference < 0 {
+			operator, difference = "-", -difference
+		}
+		balance := &genMonkey{name: newName(), job: strconv.Itoa(difference), value: difference, wanted: difference}
+		monkeys = append(monkeys, balance)
+		otherSide, _ = combineMonkeys(otherSide, balance, operator, newName())
+		monkeys = append(monkeys, otherSide)
+	}
+	root := &genMonkey{name: "root"}
+	for _, operator := range []string{"+", "-", "*"} {
+		if combined, ok := combineMonkeys(humanSide, otherSide, operator, "root"); ok {
+			root = combined
+			break
+		}
+	}
+	monkeys = append(monkeys, root)
+
+	rng.Shuffle(len(monkeys), func(i, j int) {
+		monkeys[i], monkeys[j] = monkeys[j], monkeys[i]
+	})
+	for _, monkey := range monkeys {
+		fmt.Fprintf(w, "%s: %s\n", monkey.name, monkey.job)
+	}
+}
+
+// Combines random pairs of monkeys with random operations until one is left
+func mergeMonkeys(rng *rand.Rand, group []*genMonkey, newName func() string, monkeys *[]*genMonkey) *genMonkey {
+	group = append([]*genMonkey{}, group...)
+	operators := []string{"+", "-", "*", "/"}
+	for len(group) > 1 {
+		i, j := rng.Intn(len(group)), rng.Intn(len(group)-1)
+		if j >= i {
+			j++
+		}
+		rng.Shuffle(len(operators), func(a, b int) {
+			operators[a], operators[b] = operators[b], operators[a]
+		})
+		for _, operator := range operators {
+			combined, ok := combineMonkeys(group[i], group[j], operator, newName())
+			if !ok {
+				continue
+			}
+			*monkeys = append(*monkeys, combined)
+			group[i] = combined
+			group = append(group[:j], group[j+1:]...)
+			break
+		}
+	}
+	return group[0]
+}
+
+// Returns monkey yelling the result of operation on what a and b yell. Fails
+// on results of 0, products out of range, and inexact or human divisors
+func combineMonkeys(a *genMonkey, b *genMonkey, operator string, name string) (*genMonkey, bool) {
+	combined := genMonkey{name: name, job: a.name + " " + operator + " " + b.name, human: a.human || b.human}
+	switch operator {
+	case "+":
+		combined.value, combined.wanted = a.value+b.value, a.wanted+b.wanted
+	case "-":
+		combined.value, combined.wanted = a.value-b.value, a.wanted-b.wanted
+	case "*":
+		for _, pair := range [][2]int{{a.value, b.value}, {a.wanted, b.wanted}} {
+			if abs(pair[0]) > maxProduct/abs(pair[1]) {
+				return nil, false
+			}
+		}
+		combined.value, combined.wanted = a.value*b.value, a.wanted*b.wanted
+	case "/":
+		if b.human || a.wanted%b.wanted != 0 {
+			return nil, false
+		}
+		combined.value, combined.wanted = a.value/b.value, a.wanted/b.wanted
+	}
+	if combined.value == 0 || combined.wanted == 0 {
+		return nil, false
+	}
+	return &combined, true
+}
+
+// Returns absolute value of n
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
@@ -0,0 +1,121 @@
+// Test helpers shared by the day modules
+package aoctest
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/erikzak/adventofcode/2022/aoc"
+	"github.com/erikzak/adventofcode/2022/aoc/ledger"
+)
+
+// Answer ledger path, relative to a day module like 2022/16/go
+var LedgerPath = filepath.Join("..", "..", ledger.FileName)
+
+// Solves puzzle parts with confirmed answers in the ledger and fails on any
+// other answer. Skipped without puzzle input, since inputs aren't committed
+func KnownAnswers(t *testing.T, solver aoc.Solver) {
+	t.Helper()
+	year, day := solver.Date()
+	if _, err := os.Stat(aoc.DefaultInputPath); errors.Is(err, os.ErrNotExist) {
+		t.Skipf("no puzzle input at %s, run aoc fetch --day %d", aoc.DefaultInputPath, day)
+	}
+	source, err := aoc.LoadSource(aoc.DefaultInputPath, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	answers, err := ledger.Load(LedgerPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if answers.Year != 0 && answers.Year != year {
+		t.Fatalf("%s is the ledger for %d, not %d", LedgerPath, answers.Year, year)
+	}
+	tested := 0
+	for part := 1; part <= 2; part++ {
+		want, ok := answers.Answer(day, part)
+		if !ok {
+			continue
+		}
+		tested++
+		t.Run(fmt.Sprintf("Part%d", part), func(t *testing.T) {
+			answer, err := solver.Solve(context.Background(), part, source.Reader())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if answer != want {
+				t.Fatalf(`Solve(%d) = %v, want %v`, part, answer, want)
+			}
+		})
+	}
+	if tested == 0 {
+		t.Skipf("no confirmed answers for day %d in %s", day, LedgerPath)
+	}
+}
+
+// Fuzzes input parser, seeded with the example input at path. Malformed input
+// must give an error, never a panic
+func FuzzInput[T any](f *testing.F, path string, read func(r io.Reader) (T, error)) {
+	seed, err := os.ReadFile(path)
+	if err != nil {
+		f.Fatal(err)
+	}
+	f.Add(seed)
+	f.Fuzz(func(t *testing.T, data []byte) {
+		read(bytes.NewReader(data))
+	})
+}
+
+// Size of the generated input solved by GeneratedInput, unless the
+// generator's smallest size is larger
+const SolvedSize = 10
+
+// Time limit for solving each part of the generated input
+var SolveTimeout = 10 * time.Second
+
+// Generates input with a few seeds at the default size, and checks that the
+// parser accepts it. Then solves both parts of a small generated input within
+// SolveTimeout
+func GeneratedInput[T any](t *testing.T, solver aoc.Solver, read func(r io.Reader) (T, error)) {
+	t.Helper()
+	generator := solver.Generator()
+	if generator == nil {
+		t.Fatal("no input generator")
+	}
+	for seed := int64(1); seed <= 3; seed++ {
+		buffer := &bytes.Buffer{}
+		if err := generator.Write(buffer, seed, generator.Size); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := read(buffer); err != nil {
+			t.Fatalf(`generated input with seed %d: %v`, seed, err)
+		}
+	}
+
+	size := SolvedSize
+	if generator.Size < size {
+		size = generator.Size
+	}
+	if generator.MinSize > size {
+		size = generator.MinSize
+	}
+	buffer := &bytes.Buffer{}
+	if err := generator.Write(buffer, 1, size); err != nil {
+		t.Fatal(err)
+	}
+	for part := 1; part <= 2; part++ {
+		ctx, cancel := context.WithTimeout(context.Background(), SolveTimeout)
+		_, err := solver.Solve(ctx, part, bytes.NewReader(buffer.Bytes()))
+		cancel()
+		if err != nil {
+			t.Fatalf(`Solve(%d) of generated input of size %d: %v`, part, size, err)
+		}
+	}
+}
@@ -0,0 +1,224 @@
+// Client for the Advent of Code website. Downloads puzzle input and submits
+// answers using the session token of a logged in user, keeping a polite
+// distance between requests. The HTTP client is injectable, so tests can run
+// against a local stand-in server.
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Advent of Code website
+const DefaultBaseURL = "https://adventofcode.com"
+
+// Identifies the tool to the Advent of Code maintainers, as they ask
+const DefaultUserAgent = "github.com/erikzak/adventofcode/2022/aoc"
+
+// Minimum time between requests
+const DefaultInterval = 5 * time.Second
+
+// Environment variable holding the session token
+const SessionEnv = "AOC_SESSION"
+
+var (
+	ErrNoSession    = errors.New("no session token, set " + SessionEnv + " or write it to the session file")
+	ErrUnauthorized = errors.New("session token rejected, log in again and update it")
+	ErrNotFound     = errors.New("puzzle not found, it may not be unlocked yet")
+)
+
+// Performs HTTP requests. Satisfied by *http.Client
+type Doer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// Unexpected response status from the website
+type StatusError struct {
+	Method     string
+	URL        string
+	StatusCode int
+	Body       string
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("%s %s: %s: %s", e.Method, e.URL, http.StatusText(e.StatusCode), e.Body)
+}
+
+// Advent of Code client. Requests are spaced at least Interval apart
+type Client struct {
+	BaseURL   string
+	Session   string
+	UserAgent string
+	Interval  time.Duration
+	HTTP      Doer
+
+	// Clock, replaced in tests
+	now   func() time.Time
+	sleep func(ctx context.Context, d time.Duration) error
+
+	lastRequest time.Time
+}
+
+// Inits client for the website with the given session token
+func New(session string) *Client {
+	return &Client{
+		BaseURL:   DefaultBaseURL,
+		Session:   session,
+		UserAgent: DefaultUserAgent,
+		Interval:  DefaultInterval,
+		HTTP:      &http.Client{Timeout: 30 * time.Second},
+		now:       time.Now,
+		sleep:     sleep,
+	}
+}
+
+// Waits for duration or until context is done
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Downloads puzzle input for the given day
+func (client *Client) Input(ctx context.Context, year int, day int) ([]byte, error) {
+	return client.do(ctx, http.MethodGet, fmt.Sprintf("/%d/day/%d/input", year, day), nil)
+}
+
+// Performs rate limited request with session cookie. Form values are posted
+// if given. Returns response body
+func (client *Client) do(ctx context.Context, method string, path string, form url.Values) ([]byte, error) {
+	if client.Session == "" {
+		return nil, ErrNoSession
+	}
+	if err := client.wait(ctx); err != nil {
+		return nil, err
+	}
+	requestURL := strings.TrimRight(client.BaseURL, "/") + path
+	var body io.Reader
+	if form != nil {
+		body = strings.NewReader(form.Encode())
+	}
+	req, err := http.NewRequestWithContext(ctx, method, requestURL, body)
+	if err != nil {
+		return nil, err
+	}
+	if form != nil {
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	}
+	req.Header.Set("User-Agent", client.UserAgent)
+	req.AddCookie(&http.Cookie{Name: "session", Value: client.Session})
+	resp, err := client.HTTP.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	content, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return content, nil
+	case http.StatusNotFound:
+		return nil, ErrNotFound
+	case http.StatusBadRequest, http.StatusUnauthorized, http.StatusForbidden:
+		// Invalid sessions get 400 with a message asking to log in
+		return nil, ErrUnauthorized
+	}
+	return nil, &StatusError{Method: method, URL: requestURL, StatusCode: resp.StatusCode, Body: strings.TrimSpace(string(content))}
+}
+
+// Waits until Interval has passed since the previous request
+func (client *Client) wait(ctx context.Context) error {
+	if !client.lastRequest.IsZero() {
+		if remaining := client.Interval - client.now().Sub(client.lastRequest); remaining > 0 {
+			if err := client.sleep(ctx, remaining); err != nil {
+				return err
+			}
+		}
+	}
+	client.lastRequest = client.now()
+	return nil
+}
+
+// Returns puzzle input cached at path, downloading it first if the file
+// doesn't exist or force is set. Reports whether the cached file was used
+func (client *Client) FetchInput(ctx context.Context, year int, day int, path string, force bool) (cached bool, err error) {
+	if !force {
+		if info, err := os.Stat(path); err == nil && info.Size() > 0 {
+			return true, nil
+		}
+	}
+	input, err := client.Input(ctx, year, day)
+	if err != nil {
+		return false, err
+	}
+	if len(input) == 0 {
+		return false, fmt.Errorf("day %d: empty input", day)
+	}
+	return false, writeFile(path, input)
+}
+
+// Writes file through a temporary file, so an interrupted write never leaves
+// a partial file behind
+func writeFile(path string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+"-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// Returns path of the session file in the user config directory
+func SessionFile() (string, error) {
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(configDir, "aoc", "session"), nil
+}
+
+// Looks up session token, first in the environment, then in the session file
+func LoadSession(getenv func(string) string, sessionFile string) (string, error) {
+	if session := strings.TrimSpace(getenv(SessionEnv)); session != "" {
+		return session, nil
+	}
+	if sessionFile == "" {
+		return "", ErrNoSession
+	}
+	content, err := os.ReadFile(sessionFile)
+	if os.IsNotExist(err) {
+		return "", ErrNoSession
+	} else if err != nil {
+		return "", err
+	}
+	session := strings.TrimSpace(string(content))
+	if session == "" {
+		return "", ErrNoSession
+	}
+	return session, nil
+}
@@ -0,0 +1,159 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// Stand-in for the website, serving input for 2022 day 1 to session "abc"
+func newTestServer(t *testing.T, requests *int) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*requests++
+		if r.UserAgent() != DefaultUserAgent {
+			t.Errorf(`User-Agent = %q, want %q`, r.UserAgent(), DefaultUserAgent)
+		}
+		cookie, err := r.Cookie("session")
+		if err != nil || cookie.Value != "abc" {
+			http.Error(w, "Puzzle inputs differ by user.  Please log in to get your puzzle input.", http.StatusBadRequest)
+			return
+		}
+		switch r.URL.Path {
+		case "/2022/day/1/input":
+			w.Write([]byte("1000\n2000\n\n3000\n"))
+		case "/2022/day/2/input":
+			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+// Returns client for test server with a fake clock that records sleeps
+func newTestClient(server *httptest.Server, session string, slept *[]time.Duration) *Client {
+	client := New(session)
+	client.BaseURL = server.URL
+	client.HTTP = server.Client()
+	now := time.Date(2022, 12, 1, 5, 0, 0, 0, time.UTC)
+	client.now = func() time.Time {
+		return now
+	}
+	client.sleep = func(ctx context.Context, d time.Duration) error {
+		*slept = append(*slept, d)
+		now = now.Add(d)
+		return nil
+	}
+	return client
+}
+
+// Tests input download and error responses
+func TestInput(t *testing.T) {
+	requests := 0
+	slept := []time.Duration{}
+	server := newTestServer(t, &requests)
+	client := newTestClient(server, "abc", &slept)
+	ctx := context.Background()
+
+	input, err := client.Input(ctx, 2022, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(input) != "1000\n2000\n\n3000\n" {
+		t.Fatalf(`Input() = %q`, input)
+	}
+	if _, err := client.Input(ctx, 2022, 25); !errors.Is(err, ErrNotFound) {
+		t.Fatalf(`Input() of locked day error = %v, want ErrNotFound`, err)
+	}
+	var statusError *StatusError
+	if _, err := client.Input(ctx, 2022, 2); !errors.As(err, &statusError) || statusError.StatusCode != 500 {
+		t.Fatalf(`Input() error = %v, want StatusError 500`, err)
+	}
+	client.Session = "expired"
+	if _, err := client.Input(ctx, 2022, 1); !errors.Is(err, ErrUnauthorized) {
+		t.Fatalf(`Input() with bad session error = %v, want ErrUnauthorized`, err)
+	}
+	client.Session = ""
+	if _, err := client.Input(ctx, 2022, 1); !errors.Is(err, ErrNoSession) {
+		t.Fatalf(`Input() without session error = %v, want ErrNoSession`, err)
+	}
+	if requests != 4 {
+		t.Fatalf(`server got %d requests, want 4`, requests)
+	}
+	// Every request after the first waits out the interval
+	if len(slept) != 3 || slept[0] != DefaultInterval {
+		t.Fatalf(`slept %v, want 3 x %v`, slept, DefaultInterval)
+	}
+}
+
+// Tests that fetched input is cached and only downloaded again when forced
+func TestFetchInput(t *testing.T) {
+	requests := 0
+	slept := []time.Duration{}
+	server := newTestServer(t, &requests)
+	client := newTestClient(server, "abc", &slept)
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "01", "input.txt")
+
+	for i, want := range []bool{false, true} {
+		cached, err := client.FetchInput(ctx, 2022, 1, path, false)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if cached != want {
+			t.Fatalf(`FetchInput() #%d cached = %v, want %v`, i+1, cached, want)
+		}
+	}
+	if requests != 1 {
+		t.Fatalf(`server got %d requests, want 1`, requests)
+	}
+	if _, err := client.FetchInput(ctx, 2022, 1, path, true); err != nil {
+		t.Fatal(err)
+	}
+	if requests != 2 {
+		t.Fatalf(`forced FetchInput() did not download, %d requests`, requests)
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(content) != "1000\n2000\n\n3000\n" {
+		t.Fatalf(`cached input = %q`, content)
+	}
+	// Failed downloads leave no file behind
+	missing := filepath.Join(filepath.Dir(path), "missing.txt")
+	if _, err := client.FetchInput(ctx, 2022, 25, missing, false); err == nil {
+		t.Fatalf(`FetchInput() of locked day did not fail`)
+	}
+	if _, err := os.Stat(missing); !os.IsNotExist(err) {
+		t.Fatalf(`failed FetchInput() left %s behind`, missing)
+	}
+}
+
+// Tests session lookup from environment and session file
+func TestLoadSession(t *testing.T) {
+	sessionFile := filepath.Join(t.TempDir(), "session")
+	env := map[string]string{}
+	getenv := func(key string) string {
+		return env[key]
+	}
+	if _, err := LoadSession(getenv, sessionFile); !errors.Is(err, ErrNoSession) {
+		t.Fatalf(`LoadSession() error = %v, want ErrNoSession`, err)
+	}
+	if err := os.WriteFile(sessionFile, []byte("from-file\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if session, _ := LoadSession(getenv, sessionFile); session != "from-file" {
+		t.Fatalf(`LoadSession() = %q, want from-file`, session)
+	}
+	env[SessionEnv] = "from-env"
+	if session, _ := LoadSession(getenv, sessionFile); session != "from-env" {
+		t.Fatalf(`LoadSession() = %q, want from-env`, session)
+	}
+}
@@ -0,0 +1,35 @@
+package client
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Returns path of the file tracking submission cooldown, in the user cache
+// directory. Shared by all repositories of the user, as is the cooldown
+func CooldownFile() (string, error) {
+	cacheDir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(cacheDir, "aoc", "cooldown"), nil
+}
+
+// Reads time until which submissions have to wait. Zero if there's no cooldown
+func LoadCooldown(path string) (time.Time, error) {
+	content, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return time.Time{}, nil
+	} else if err != nil {
+		return time.Time{}, err
+	}
+	return time.Parse(time.RFC3339, strings.TrimSpace(string(content)))
+}
+
+// Writes time until which submissions have to wait
+func SaveCooldown(path string, until time.Time) error {
+	return writeFile(path, []byte(until.UTC().Format(time.RFC3339)+"\n"))
+}
@@ -0,0 +1,110 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Outcome of an answer submission
+type Outcome int
+
+const (
+	Unknown     Outcome = iota // Response page not recognized
+	Correct                    // Right answer, star awarded
+	Wrong                      // Wrong answer, no hint given
+	TooHigh                    // Wrong answer, too high
+	TooLow                     // Wrong answer, too low
+	RateLimited                // Answer given too recently, not checked
+	WrongLevel                 // Part already solved or not unlocked
+)
+
+func (outcome Outcome) String() string {
+	switch outcome {
+	case Correct:
+		return "correct"
+	case Wrong:
+		return "wrong"
+	case TooHigh:
+		return "too high"
+	case TooLow:
+		return "too low"
+	case RateLimited:
+		return "rate limited"
+	case WrongLevel:
+		return "wrong level"
+	}
+	return "unknown"
+}
+
+// Response to an answer submission
+type Response struct {
+	Outcome Outcome
+	Wait    time.Duration // Time to wait before submitting again
+	Message string        // Text of the response article
+}
+
+// Posts answer to the given puzzle part and parses the response page
+func (client *Client) Submit(ctx context.Context, year int, day int, part int, answer string) (Response, error) {
+	form := url.Values{"level": {strconv.Itoa(part)}, "answer": {answer}}
+	page, err := client.do(ctx, http.MethodPost, fmt.Sprintf("/%d/day/%d/answer", year, day), form)
+	if err != nil {
+		return Response{}, err
+	}
+	return ParseResponse(page), nil
+}
+
+var (
+	articlePattern = regexp.MustCompile(`(?s)<article[^>]*>(.*?)</article>`)
+	tagPattern     = regexp.MustCompile(`<[^>]*>`)
+	spacePattern   = regexp.MustCompile(`\s+`)
+	// "You have 4m 12s left to wait", after answering too recently
+	leftPattern = regexp.MustCompile(`You have (?:(\d+)m )?(\d+)s left to wait`)
+	// "Please wait one minute before trying again", after a wrong answer
+	waitPattern = regexp.MustCompile(`wait (one|\d+) minutes?`)
+)
+
+// Parses submission response page into outcome and wait time
+func ParseResponse(page []byte) Response {
+	text := string(page)
+	if match := articlePattern.FindStringSubmatch(text); match != nil {
+		text = match[1]
+	}
+	text = html.UnescapeString(tagPattern.ReplaceAllString(text, ""))
+	response := Response{Message: strings.TrimSpace(spacePattern.ReplaceAllString(text, " "))}
+
+	switch {
+	case strings.Contains(text, "That's the right answer"):
+		response.Outcome = Correct
+	case strings.Contains(text, "That's not the right answer"):
+		response.Outcome = Wrong
+		if strings.Contains(text, "too high") {
+			response.Outcome = TooHigh
+		} else if strings.Contains(text, "too low") {
+			response.Outcome = TooLow
+		}
+	case strings.Contains(text, "You gave an answer too recently"):
+		response.Outcome = RateLimited
+	case strings.Contains(text, "You don't seem to be solving the right level"):
+		response.Outcome = WrongLevel
+	}
+
+	if match := leftPattern.FindStringSubmatch(text); match != nil {
+		minutes, _ := strconv.Atoi("0" + match[1])
+		seconds, _ := strconv.Atoi(match[2])
+		response.Wait = time.Duration(minutes)*time.Minute + time.Duration(seconds)*time.Second
+	} else if match := waitPattern.FindStringSubmatch(text); match != nil {
+		minutes := 1
+		if match[1] != "one" {
+			minutes, _ = strconv.Atoi(match[1])
+		}
+		response.Wait = time.Duration(minutes) * time.Minute
+	}
+	return response
+}
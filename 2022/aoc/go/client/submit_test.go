@@ -0,0 +1,87 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// Tests parsing of submission response pages
+func TestParseResponse(t *testing.T) {
+	tests := []struct {
+		page    string
+		outcome Outcome
+		wait    time.Duration
+	}{
+		{`<main><article><p>That's the right answer!  You are <em>one gold star</em> closer to collecting enough star fruit.</p></article></main>`, Correct, 0},
+		{`<article><p>That's not the right answer; your answer is too high.  If you're stuck, make sure you're using the full input data. Please wait one minute before trying again. [<a href="/2022/day/1">Return to Day 1</a>]</p></article>`, TooHigh, time.Minute},
+		{`<article><p>That's not the right answer; your answer is too low.  Please wait 5 minutes before trying again.</p></article>`, TooLow, 5 * time.Minute},
+		{`<article><p>That's not the right answer.  If you're stuck, try again.  Please wait one minute before trying again.</p></article>`, Wrong, time.Minute},
+		{`<article><p>You gave an answer too recently; you have to wait after submitting an answer before trying again.  You have 4m 12s left to wait.</p></article>`, RateLimited, 4*time.Minute + 12*time.Second},
+		{`<article><p>You gave an answer too recently.  You have 37s left to wait.</p></article>`, RateLimited, 37 * time.Second},
+		{`<article><p>You don't seem to be solving the right level.  Did you already complete it?</p></article>`, WrongLevel, 0},
+		{`<html>Something else</html>`, Unknown, 0},
+	}
+	for _, test := range tests {
+		response := ParseResponse([]byte(test.page))
+		if response.Outcome != test.outcome || response.Wait != test.wait {
+			t.Fatalf(`ParseResponse(%q) = %v, %v, want %v, %v`, test.page, response.Outcome, response.Wait, test.outcome, test.wait)
+		}
+	}
+}
+
+// Tests posting answers to a stand-in server
+func TestSubmit(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/2022/day/21/answer" {
+			http.NotFound(w, r)
+			return
+		}
+		if r.FormValue("level") != "2" {
+			t.Errorf(`level = %q, want 2`, r.FormValue("level"))
+		}
+		if r.FormValue("answer") == "7010269744524" {
+			w.Write([]byte(`<article><p>That's the right answer!</p></article>`))
+			return
+		}
+		w.Write([]byte(`<article><p>That's not the right answer; your answer is too low.  Please wait one minute before trying again.</p></article>`))
+	}))
+	defer server.Close()
+	slept := []time.Duration{}
+	client := newTestClient(server, "abc", &slept)
+	ctx := context.Background()
+
+	response, err := client.Submit(ctx, 2022, 21, 2, "301")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if response.Outcome != TooLow || response.Wait != time.Minute {
+		t.Fatalf(`Submit() = %v, %v, want too low, 1m`, response.Outcome, response.Wait)
+	}
+	response, err = client.Submit(ctx, 2022, 21, 2, "7010269744524")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if response.Outcome != Correct {
+		t.Fatalf(`Submit() = %v, want correct`, response.Outcome)
+	}
+}
+
+// Tests that saved cooldown loads back the same
+func TestCooldown(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "aoc", "cooldown")
+	until, err := LoadCooldown(path)
+	if err != nil || !until.IsZero() {
+		t.Fatalf(`LoadCooldown() without file = %v, %v, want zero time`, until, err)
+	}
+	want := time.Date(2022, 12, 21, 6, 1, 0, 0, time.UTC)
+	if err := SaveCooldown(path, want); err != nil {
+		t.Fatal(err)
+	}
+	if until, err = LoadCooldown(path); err != nil || !until.Equal(want) {
+		t.Fatalf(`LoadCooldown() = %v, %v, want %v`, until, err, want)
+	}
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/erikzak/adventofcode/2022/aoc/ledger"
+	"github.com/erikzak/adventofcode/2022/aoc/runner"
+)
+
+// Lists confirmed answers in the answer ledger, or generates known answers
+// tests for the day modules
+func answersCommand(args []string) error {
+	flags := flag.NewFlagSet("answers", flag.ContinueOnError)
+	year := flags.Int("year", 2022, "puzzle year")
+	daySpec := flags.String("day", "", `day(s) to list, like "16", "1-5" or "1,3", default all`)
+	genTests := flags.Bool("gen-tests", false, "write "+runner.AnswersTestFile+" to day modules missing one")
+	root := flags.String("root", "", "repository root, default found from working directory")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	repoRoot, err := findRoot(*root, *year)
+	if err != nil {
+		return err
+	}
+
+	if *genTests {
+		modules, err := selectModules(repoRoot, *year, *daySpec)
+		if err != nil {
+			return err
+		}
+		for _, module := range modules {
+			written, err := runner.WriteAnswersTest(module)
+			if err != nil {
+				return err
+			}
+			if written {
+				fmt.Printf("Day %d: wrote %s\n", module.Day, runner.AnswersTestFile)
+			}
+		}
+		return nil
+	}
+
+	answers, err := ledger.Open(repoRoot, *year)
+	if err != nil {
+		return err
+	}
+	days, err := runner.ParseDays(*daySpec)
+	if err != nil {
+		return err
+	}
+	if len(days) == 0 {
+		days = answers.Days()
+	}
+	table := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(table, "Day\tPart\tAnswer")
+	for _, day := range days {
+		for part := 1; part <= 2; part++ {
+			if answer, ok := answers.Answer(day, part); ok {
+				fmt.Fprintf(table, "%d\t%d\t%s\n", day, part, answer)
+			}
+		}
+	}
+	return table.Flush()
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/erikzak/adventofcode/2022/aoc/runner"
+)
+
+// Runs day module benchmarks and compares them with a saved baseline
+func benchCommand(args []string) error {
+	flags := flag.NewFlagSet("bench", flag.ContinueOnError)
+	year := flags.Int("year", 2022, "puzzle year")
+	daySpec := flags.String("day", "", `day(s) to benchmark, like "16", "1-5" or "1,3", default all`)
+	baselinePath := flags.String("baseline", "", "baseline file, default bench.json in the year directory")
+	save := flags.Bool("save", false, "save results as the new baseline for the benchmarked days")
+	threshold := flags.Float64("threshold", 10, "percent slower or allocating more that counts as a regression")
+	benchtime := flags.String("benchtime", "", "benchtime passed on to go test")
+	root := flags.String("root", "", "repository root, default found from working directory")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	repoRoot, err := findRoot(*root, *year)
+	if err != nil {
+		return err
+	}
+	modules, err := selectModules(repoRoot, *year, *daySpec)
+	if err != nil {
+		return err
+	}
+	if *baselinePath == "" {
+		*baselinePath = filepath.Join(repoRoot, strconv.Itoa(*year), "bench.json")
+	}
+	baseline, err := runner.LoadBaseline(*baselinePath)
+	if err != nil {
+		return err
+	}
+
+	run := runner.NewRunner("", nil)
+	ctx := context.Background()
+	current := map[int][]runner.Benchmark{}
+	failed := false
+	for _, module := range modules {
+		fmt.Fprintf(os.Stderr, "Benchmarking day %d\n", module.Day)
+		benchmarks, err := run.Bench(ctx, module, *benchtime)
+		if err != nil {
+			// Benchmarks need puzzle input, report and carry on with the rest
+			fmt.Fprintln(os.Stderr, err)
+			failed = true
+		}
+		if len(benchmarks) > 0 {
+			current[module.Day] = benchmarks
+		}
+	}
+
+	deltas := runner.Compare(baseline, current, *threshold)
+	if err := runner.WriteBenchReport(os.Stdout, deltas); err != nil {
+		return err
+	}
+	if *save {
+		baseline.Year = *year
+		for day, benchmarks := range current {
+			baseline.Days[day] = benchmarks
+		}
+		if err := baseline.Save(*baselinePath); err != nil {
+			return err
+		}
+		fmt.Printf("Saved baseline to %s\n", *baselinePath)
+	}
+	if failed {
+		return errors.New("one or more days failed to benchmark")
+	}
+	regressions := 0
+	for _, delta := range deltas {
+		if delta.Regressed {
+			regressions++
+		}
+	}
+	if regressions > 0 && !*save {
+		return fmt.Errorf("%d benchmark(s) regressed more than %.0f%%", regressions, *threshold)
+	}
+	return nil
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/erikzak/adventofcode/2022/aoc"
+	"github.com/erikzak/adventofcode/2022/aoc/runner"
+)
+
+// Result of a named input, as written in json lines
+type inputResult struct {
+	Input string `json:"input"`
+	aoc.Result
+}
+
+// Solves the day with every named input in dir and writes a matrix of
+// answers and timings, marked against each input's ledger. Recording
+// confirms the answers in the ledgers of the inputs
+func compareInputs(interrupted context.Context, run *runner.Runner, module runner.Module, dir string, options runner.Options, format string, record bool) error {
+	inputs, err := runner.FindInputs(dir, module.Year)
+	if err != nil {
+		return err
+	}
+	comparisons := []runner.Comparison{}
+	for _, input := range inputs {
+		if interrupted.Err() != nil {
+			return errors.New("interrupted")
+		}
+		options.Input = input.Path
+		results, err := run.Run(context.Background(), module, options)
+		if err != nil {
+			// Report crashing input in the matrix and carry on with the rest
+			results = []aoc.Result{{Year: module.Year, Day: module.Day, Part: options.Part, Error: err.Error()}}
+			if options.Part == 0 {
+				second := results[0]
+				results[0].Part, second.Part = 1, 2
+				results = append(results, second)
+			}
+		}
+		comparisons = append(comparisons, runner.Comparison{Input: input, Results: results})
+	}
+
+	if format == aoc.FormatJSON {
+		encoder := json.NewEncoder(os.Stdout)
+		for _, comparison := range comparisons {
+			for _, result := range comparison.Results {
+				if err := encoder.Encode(inputResult{comparison.Input.Name, result}); err != nil {
+					return err
+				}
+			}
+		}
+	} else if err := runner.WriteComparison(os.Stdout, comparisons); err != nil {
+		return err
+	}
+	failed := 0
+	for _, comparison := range comparisons {
+		if comparison.Failed() {
+			failed++
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d inputs crashed or differ from their ledger", failed, len(comparisons))
+	}
+	if record {
+		for _, comparison := range comparisons {
+			if err := recordAnswers(comparison.Input.Ledger, comparison.Results); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
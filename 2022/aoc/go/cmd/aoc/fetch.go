@@ -0,0 +1,58 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"path/filepath"
+
+	"github.com/erikzak/adventofcode/2022/aoc/client"
+	"github.com/erikzak/adventofcode/2022/aoc/runner"
+)
+
+// Downloads puzzle input for the selected days into their day directories
+func fetchCommand(args []string) error {
+	flags := flag.NewFlagSet("fetch", flag.ContinueOnError)
+	year := flags.Int("year", 2022, "puzzle year")
+	daySpec := flags.String("day", "", `day(s) to fetch, like "16", "1-5" or "1,3"`)
+	force := flags.Bool("force", false, "download again even if input is cached")
+	root := flags.String("root", "", "repository root, default found from working directory")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	days, err := runner.ParseDays(*daySpec)
+	if err != nil {
+		return err
+	}
+	if len(days) == 0 {
+		return errors.New("--day is required")
+	}
+	repoRoot, err := findRoot(*root, *year)
+	if err != nil {
+		return err
+	}
+
+	// A missing session only matters if something needs downloading
+	aocClient, err := newClient()
+	if errors.Is(err, client.ErrNoSession) {
+		aocClient = client.New("")
+	} else if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	for _, day := range days {
+		path := filepath.Join(runner.DayDir(repoRoot, *year, day), "input.txt")
+		cached, err := aocClient.FetchInput(ctx, *year, day, path, *force)
+		if err != nil {
+			return fmt.Errorf("day %d: %w", day, err)
+		}
+		if cached {
+			fmt.Printf("Day %d: cached %s\n", day, path)
+		} else {
+			fmt.Printf("Day %d: downloaded %s\n", day, path)
+		}
+	}
+	return nil
+}
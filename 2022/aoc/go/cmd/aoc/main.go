@@ -0,0 +1,64 @@
+// Command line tool for Advent of Code day modules.
+//
+// Usage:
+//
+//	aoc run [--year 2022] [--day 16|1-5] [--part 2] [--input path|-] [--format text|json|csv] [--check] [--record] [--visualize] [--timeout 30s] [--progress 5s] [--cpuprofile dir] [--memprofile dir] [--trace dir] [--inputs dir]
+//	aoc fetch [--year 2022] --day 16|1-5 [--force]
+//	aoc submit [--year 2022] --day 16 --part 2 [--wait]
+//	aoc answers [--year 2022] [--day 16|1-5] [--gen-tests]
+//	aoc bench [--year 2022] [--day 16|1-5] [--save] [--threshold 10]
+//	aoc new [--year 2022] --day 16 [--page day16.html]
+//	aoc serve [--year 2022] [--day 16|1-5] [--addr localhost:8080] [--timeout 30s] [--concurrency 4]
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+)
+
+// Subcommands, by name
+var commands = map[string]func(args []string) error{
+	"run":     runCommand,
+	"fetch":   fetchCommand,
+	"submit":  submitCommand,
+	"answers": answersCommand,
+	"bench":   benchCommand,
+	"new":     newCommand,
+	"serve":   serveCommand,
+}
+
+func usage() {
+	fmt.Fprint(os.Stderr, `Usage: aoc <command> [flags]
+
+Commands:
+  run     solve one day, a range of days or the whole year
+  fetch   download puzzle input, cached in the day directory
+  submit  solve a part and submit the answer, recording the outcome
+  answers list confirmed answers, or generate known answers tests
+  bench   run benchmarks and compare them with a saved baseline
+  new     create a day module skeleton, registered with the runner
+  serve   solve posted puzzle input over HTTP, answering with JSON
+`)
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(2)
+	}
+	command, ok := commands[os.Args[1]]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "aoc: unknown command %q\n", os.Args[1])
+		usage()
+		os.Exit(2)
+	}
+	if err := command(os.Args[2:]); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			os.Exit(2)
+		}
+		fmt.Fprintf(os.Stderr, "aoc %s: %v\n", os.Args[1], err)
+		os.Exit(1)
+	}
+}
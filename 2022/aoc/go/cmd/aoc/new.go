@@ -0,0 +1,67 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/erikzak/adventofcode/2022/aoc/runner"
+	"github.com/erikzak/adventofcode/2022/aoc/scaffold"
+)
+
+// Creates a day module skeleton, optionally with example input and answers
+// from a saved puzzle page
+func newCommand(args []string) error {
+	flags := flag.NewFlagSet("new", flag.ContinueOnError)
+	year := flags.Int("year", 2022, "puzzle year")
+	day := flags.Int("day", 0, "day to create")
+	page := flags.String("page", "", "saved puzzle page to take example input and answers from")
+	root := flags.String("root", "", "repository root, default found from working directory")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *day == 0 {
+		return errors.New("--day is required")
+	}
+	// The year directory may not exist yet, but the shared aoc module does
+	repoRoot, err := findRoot(*root, 2022)
+	if err != nil {
+		return err
+	}
+
+	options := scaffold.Options{Year: *year, Day: *day}
+	if *page != "" {
+		content, err := os.ReadFile(*page)
+		if err != nil {
+			return err
+		}
+		parsed := scaffold.ParsePage(content)
+		if parsed.Example == "" {
+			return fmt.Errorf("%s: no example input found", *page)
+		}
+		options.Example = parsed.Example
+		options.Answers = parsed.Answers
+	}
+	module, paths, err := scaffold.Create(repoRoot, options)
+	if err != nil {
+		return err
+	}
+	if _, err := runner.WriteAnswersTest(module); err != nil {
+		return err
+	}
+	paths = append(paths, filepath.Join(module.Dir, runner.AnswersTestFile))
+	for _, path := range paths {
+		if relative, err := filepath.Rel(repoRoot, path); err == nil {
+			path = relative
+		}
+		fmt.Printf("Day %d: wrote %s\n", *day, path)
+	}
+	for part, answer := range options.Answers {
+		if answer != "" {
+			fmt.Printf("Day %d: part %d example answer %q\n", *day, part+1, answer)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,196 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+
+	"github.com/erikzak/adventofcode/2022/aoc"
+	"github.com/erikzak/adventofcode/2022/aoc/ledger"
+	"github.com/erikzak/adventofcode/2022/aoc/runner"
+)
+
+// Solves selected days and prints a summary table of answers and wall time
+func runCommand(args []string) error {
+	flags := flag.NewFlagSet("run", flag.ContinueOnError)
+	year := flags.Int("year", 2022, "puzzle year")
+	daySpec := flags.String("day", "", `day(s) to run, like "16", "1-5" or "1,3", default all`)
+	part := flags.Int("part", 0, "puzzle part to solve, 0 for both")
+	input := flags.String("input", "", `puzzle input file, "-" for stdin, default the day's ../input.txt`)
+	root := flags.String("root", "", "repository root, default found from working directory")
+	check := flags.Bool("check", false, "mark answers as ✓/✗ against the answer ledger")
+	record := flags.Bool("record", false, "record answers in the answer ledger as confirmed")
+	format := flags.String("format", aoc.FormatText, "output format: text table, json lines or csv")
+	visualize := flags.Bool("visualize", false, "play the simulation in the terminal, for days with a visualization")
+	timeout := flags.Duration("timeout", 0, "time limit for solving each part, like 30s, 0 for none")
+	progress := flags.Duration("progress", 0, "print search progress of long-running days at this interval, like 5s")
+	profiles := aoc.Profiles{}
+	flags.StringVar(&profiles.CPU, "cpuprofile", "", "write CPU profiles of each day and part to this directory, like day16-part1.cpu.pprof")
+	flags.StringVar(&profiles.Memory, "memprofile", "", "write heap profiles of each day and part to this directory")
+	flags.StringVar(&profiles.Trace, "trace", "", "write execution traces of each day and part to this directory")
+	inputsDir := flags.String("inputs", "", "directory of named inputs like alice.txt, each with an answer ledger like alice.json, to compare")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if err := aoc.ValidFormat(*format); err != nil {
+		return err
+	}
+	if *part < 0 || *part > 2 {
+		return fmt.Errorf("invalid part: %d", *part)
+	}
+	if *inputsDir != "" && (*input != "" || *visualize || profiles != (aoc.Profiles{})) {
+		return errors.New("--inputs can't be combined with --input, --visualize or profiling")
+	}
+	if *visualize && profiles != (aoc.Profiles{}) {
+		return errors.New("--visualize can't be combined with profiling")
+	}
+	if *inputsDir != "" && *format == aoc.FormatCSV {
+		return errors.New("--inputs writes a text matrix or json lines, not csv")
+	}
+	if (*check || *record) && *input != "" {
+		return errors.New("the answer ledger only holds answers for the day's own input, drop --input")
+	}
+
+	repoRoot, err := findRoot(*root, *year)
+	if err != nil {
+		return err
+	}
+	modules, err := selectModules(repoRoot, *year, *daySpec)
+	if err != nil {
+		return err
+	}
+	if *input != "" && len(modules) > 1 {
+		return errors.New("--input can only be used when running a single day")
+	}
+	if *visualize && len(modules) > 1 {
+		return errors.New("--visualize can only be used when running a single day")
+	}
+	if *inputsDir != "" && len(modules) > 1 {
+		return errors.New("--inputs can only be used when running a single day")
+	}
+	var answers *ledger.Ledger
+	// Named inputs are checked against their own ledgers
+	if (*check || *record) && *inputsDir == "" {
+		answers, err = ledger.Open(repoRoot, *year)
+		if err != nil {
+			return err
+		}
+	}
+
+	buildDir, err := os.MkdirTemp("", "aoc-build")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(buildDir)
+	run := runner.NewRunner(buildDir, nil)
+	if *progress > 0 {
+		// Progress is logged by the day modules
+		run.Stderr = os.Stderr
+	}
+
+	// Interrupts reach the day module too, which stops the part it is solving
+	// and reports it. Days after it are skipped
+	interrupted, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	if *inputsDir != "" {
+		options := runner.Options{Part: *part, Timeout: *timeout, Progress: *progress}
+		return compareInputs(interrupted, run, modules[0], *inputsDir, options, *format, *record)
+	}
+	results := []aoc.Result{}
+	failed := false
+	for _, module := range modules {
+		if interrupted.Err() != nil {
+			failed = true
+			break
+		}
+		dayResults, err := run.Run(context.Background(), module, runner.Options{
+			Part: *part, Input: *input, Stdin: os.Stdin, Visualize: *visualize,
+			Timeout: *timeout, Progress: *progress, Profiles: profiles,
+		})
+		if err != nil {
+			// Report failing day in table and carry on with the rest
+			dayResults = []aoc.Result{{Year: module.Year, Day: module.Day, Part: *part, Error: err.Error()}}
+		}
+		for _, result := range dayResults {
+			failed = failed || result.Error != ""
+		}
+		results = append(results, dayResults...)
+	}
+	marks := answers
+	if !*check {
+		marks = nil
+	}
+	if err := writeResults(*format, results, marks); err != nil {
+		return err
+	}
+	if failed {
+		return errors.New("one or more days failed")
+	}
+	if answers != nil {
+		for _, result := range results {
+			if answers.Check(result) == ledger.Wrong {
+				return errors.New("one or more answers differ from the answer ledger")
+			}
+		}
+	}
+	if *record {
+		return recordAnswers(answers, results)
+	}
+	return nil
+}
+
+// Writes results as summary table, or encoded in a machine-readable format
+func writeResults(format string, results []aoc.Result, marks *ledger.Ledger) error {
+	if format == aoc.FormatText {
+		return runner.WriteSummary(os.Stdout, results, marks)
+	}
+	encoder, err := aoc.NewResultEncoder(format, os.Stdout)
+	if err != nil {
+		return err
+	}
+	for _, result := range results {
+		if err := encoder.Encode(result); err != nil {
+			return err
+		}
+	}
+	return encoder.Flush()
+}
+
+// Records answers of successful results in the ledger as confirmed
+func recordAnswers(answers *ledger.Ledger, results []aoc.Result) error {
+	for _, result := range results {
+		if result.Error == "" && result.Answer != "" {
+			answers.Confirm(result.Day, result.Part, result.Answer)
+		}
+	}
+	if err := answers.Save(); err != nil {
+		return err
+	}
+	fmt.Printf("Recorded answers in %s\n", answers.Path)
+	return nil
+}
+
+// Returns root, or the repository root found from the working directory
+// if root is empty
+func findRoot(root string, year int) (string, error) {
+	if root != "" {
+		return root, nil
+	}
+	return runner.FindRoot(".", year)
+}
+
+// Finds registered modules for the year, filtered by day selection
+func selectModules(root string, year int, daySpec string) ([]runner.Module, error) {
+	days, err := runner.ParseDays(daySpec)
+	if err != nil {
+		return nil, err
+	}
+	modules, err := runner.Discover(root, year)
+	if err != nil {
+		return nil, err
+	}
+	return runner.Select(modules, days)
+}
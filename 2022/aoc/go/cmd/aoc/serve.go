@@ -0,0 +1,75 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/erikzak/adventofcode/2022/aoc/runner"
+	"github.com/erikzak/adventofcode/2022/aoc/server"
+)
+
+// Serves the day solvers over HTTP until interrupted
+func serveCommand(args []string) error {
+	flags := flag.NewFlagSet("serve", flag.ContinueOnError)
+	year := flags.Int("year", 2022, "puzzle year")
+	daySpec := flags.String("day", "", `day(s) to serve, like "16", "1-5" or "1,3", default all`)
+	addr := flags.String("addr", "localhost:8080", "address to listen on")
+	root := flags.String("root", "", "repository root, default found from working directory")
+	options := server.Options{}
+	flags.DurationVar(&options.Timeout, "timeout", 30*time.Second, "time limit of requests without a timeout parameter")
+	flags.DurationVar(&options.MaxTimeout, "max-timeout", 5*time.Minute, "longest time limit requests can ask for")
+	flags.IntVar(&options.Concurrency, "concurrency", 0, "most requests solved at once, default the number of CPUs")
+	flags.Int64Var(&options.MaxInput, "max-input", 4<<20, "largest puzzle input in bytes")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	repoRoot, err := findRoot(*root, *year)
+	if err != nil {
+		return err
+	}
+	modules, err := selectModules(repoRoot, *year, *daySpec)
+	if err != nil {
+		return err
+	}
+
+	buildDir, err := os.MkdirTemp("", "aoc-build")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(buildDir)
+	run := runner.NewRunner(buildDir, nil)
+	// Build up front, so the first request to a day isn't slowed down by it
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	for _, module := range modules {
+		fmt.Fprintf(os.Stderr, "Building day %d\n", module.Day)
+		if _, err := run.Build(ctx, module); err != nil {
+			return err
+		}
+	}
+
+	httpServer := &http.Server{Addr: *addr, Handler: server.New(modules, run, options)}
+	serveErr := make(chan error, 1)
+	go func() {
+		serveErr <- httpServer.ListenAndServe()
+	}()
+	fmt.Fprintf(os.Stderr, "Serving %d days on http://%s/v1/days\n", len(modules), *addr)
+	select {
+	case err := <-serveErr:
+		return err
+	case <-ctx.Done():
+	}
+	// Let requests being solved finish
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), options.MaxTimeout)
+	defer cancel()
+	if err := httpServer.Shutdown(shutdownCtx); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,135 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/erikzak/adventofcode/2022/aoc/client"
+	"github.com/erikzak/adventofcode/2022/aoc/ledger"
+	"github.com/erikzak/adventofcode/2022/aoc/runner"
+)
+
+// Solves a puzzle part and submits the answer. Answers known to be wrong from
+// the ledger are refused locally, and the outcome is recorded in the ledger
+func submitCommand(args []string) error {
+	flags := flag.NewFlagSet("submit", flag.ContinueOnError)
+	year := flags.Int("year", 2022, "puzzle year")
+	day := flags.Int("day", 0, "puzzle day")
+	part := flags.Int("part", 0, "puzzle part, 1 or 2")
+	wait := flags.Bool("wait", false, "wait out the submission cooldown instead of failing")
+	root := flags.String("root", "", "repository root, default found from working directory")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *part != 1 && *part != 2 {
+		return fmt.Errorf("--part 1 or 2 is required")
+	}
+	repoRoot, err := findRoot(*root, *year)
+	if err != nil {
+		return err
+	}
+	modules, err := selectModules(repoRoot, *year, fmt.Sprint(*day))
+	if err != nil {
+		return err
+	}
+	answers, err := ledger.Open(repoRoot, *year)
+	if err != nil {
+		return err
+	}
+
+	// Solve part with the day's own input
+	buildDir, err := os.MkdirTemp("", "aoc-build")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(buildDir)
+	ctx := context.Background()
+	results, err := runner.NewRunner(buildDir, os.Stderr).Run(ctx, modules[0], runner.Options{Part: *part})
+	if err != nil {
+		return err
+	}
+	if len(results) != 1 {
+		return fmt.Errorf("day %d part %d: got %d results, want 1", *day, *part, len(results))
+	}
+	if results[0].Error != "" {
+		return fmt.Errorf("day %d part %d: %s", *day, *part, results[0].Error)
+	}
+	answer := results[0].Answer
+	if answer == "" || strings.Contains(answer, "\n") {
+		return fmt.Errorf("answer %q can't be submitted as is, read it and submit by hand", answer)
+	}
+	if confirmed, ok := answers.Answer(*day, *part); ok && confirmed == answer {
+		fmt.Printf("Day %d part %d: %s is already confirmed\n", *day, *part, answer)
+		return nil
+	}
+	if err := answers.Precheck(*day, *part, answer); err != nil {
+		return err
+	}
+
+	// Honour cooldown from earlier submissions
+	cooldownFile, err := client.CooldownFile()
+	if err != nil {
+		return err
+	}
+	until, err := client.LoadCooldown(cooldownFile)
+	if err != nil {
+		return err
+	}
+	if left := time.Until(until); left > 0 {
+		if !*wait {
+			return fmt.Errorf("submission cooldown, %v left to wait", left.Round(time.Second))
+		}
+		fmt.Printf("Waiting %v for submission cooldown\n", left.Round(time.Second))
+		time.Sleep(left)
+	}
+
+	aocClient, err := newClient()
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Day %d part %d: submitting %s\n", *day, *part, answer)
+	response, err := aocClient.Submit(ctx, *year, *day, *part, answer)
+	if err != nil {
+		return err
+	}
+	if response.Wait > 0 {
+		if err := client.SaveCooldown(cooldownFile, time.Now().Add(response.Wait)); err != nil {
+			return err
+		}
+	}
+	fmt.Println(response.Message)
+
+	switch response.Outcome {
+	case client.Correct:
+		answers.Confirm(*day, *part, answer)
+	case client.Wrong, client.TooLow, client.TooHigh:
+		answers.Reject(*day, *part, answer, response.Outcome == client.TooLow, response.Outcome == client.TooHigh)
+	default:
+		return fmt.Errorf("answer not checked: %v", response.Outcome)
+	}
+	if err := answers.Save(); err != nil {
+		return err
+	}
+	if response.Outcome != client.Correct {
+		return errors.New("answer " + answer + " is " + response.Outcome.String())
+	}
+	return nil
+}
+
+// Returns website client with session token from environment or session file
+func newClient() (*client.Client, error) {
+	sessionFile, err := client.SessionFile()
+	if err != nil {
+		sessionFile = ""
+	}
+	session, err := client.LoadSession(os.Getenv, sessionFile)
+	if err != nil {
+		return nil, err
+	}
+	return client.New(session), nil
+}
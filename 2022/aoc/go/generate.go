@@ -0,0 +1,66 @@
+package aoc
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"io"
+	"math/rand"
+)
+
+// Random valid input generator, for stress testing solvers and benchmarking
+// them on inputs of any size. The same seed and size give the same input
+type Generator struct {
+	Size     int    // Default size
+	MinSize  int    // Smallest size, at least 1
+	MaxSize  int    // Largest size, 0 for no limit
+	SizeHelp string // What size counts, like "valves". Empty if size is fixed
+	// Writes input of the given size. Writes are buffered, and write errors
+	// are reported when flushed
+	Generate func(rng *rand.Rand, size int, w *bufio.Writer)
+}
+
+// Writes generated input of the given size to w
+func (generator *Generator) Write(w io.Writer, seed int64, size int) error {
+	if generator.SizeHelp != "" {
+		minSize := generator.MinSize
+		if minSize < 1 {
+			minSize = 1
+		}
+		if size < minSize {
+			return fmt.Errorf("invalid size %d, want at least %d", size, minSize)
+		}
+		if generator.MaxSize > 0 && size > generator.MaxSize {
+			return fmt.Errorf("invalid size %d, want at most %d", size, generator.MaxSize)
+		}
+	}
+	buffered := bufio.NewWriter(w)
+	generator.Generate(rand.New(rand.NewSource(seed)), size, buffered)
+	return buffered.Flush()
+}
+
+// Handles the gen subcommand of day modules. Returns exit code
+func runGenerate(solver Solver, args []string, stdout io.Writer, stderr io.Writer) int {
+	generator := solver.Generator()
+	if generator == nil {
+		_, day := solver.Date()
+		fmt.Fprintf(stderr, "day %d has no input generator\n", day)
+		return 2
+	}
+	flags := flag.NewFlagSet("gen", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	seed := flags.Int64("seed", 1, "random seed")
+	sizeHelp := "ignored, input size is fixed"
+	if generator.SizeHelp != "" {
+		sizeHelp = "number of " + generator.SizeHelp
+	}
+	size := flags.Int("size", generator.Size, sizeHelp)
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if err := generator.Write(stdout, *seed, *size); err != nil {
+		fmt.Fprintln(stderr, err)
+		return 1
+	}
+	return 0
+}
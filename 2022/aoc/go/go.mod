@@ -0,0 +1,3 @@
+module github.com/erikzak/adventofcode/2022/aoc
+
+go 1.19
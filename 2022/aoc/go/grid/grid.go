@@ -0,0 +1,154 @@
+package grid
+
+// Common interface for grid backings
+type Grid[T any] interface {
+	// Returns value at point, and whether the point has a value
+	Get(p Point) (T, bool)
+	Set(p Point, value T)
+	// Returns rectangle containing all points with values
+	Bounds() Rect
+}
+
+// Grid backed by a map, for unbounded grids with few points set. Bounds grow
+// as points are set.
+type Sparse[T any] struct {
+	cells  map[Point]T
+	bounds Rect
+}
+
+func NewSparse[T any]() *Sparse[T] {
+	return &Sparse[T]{cells: map[Point]T{}}
+}
+
+func (g *Sparse[T]) Get(p Point) (T, bool) {
+	value, ok := g.cells[p]
+	return value, ok
+}
+
+func (g *Sparse[T]) Set(p Point, value T) {
+	if len(g.cells) == 0 {
+		g.bounds = NewRect(p)
+	} else {
+		g.bounds = g.bounds.Extend(p)
+	}
+	g.cells[p] = value
+}
+
+// Checks if point has a value
+func (g *Sparse[T]) Has(p Point) bool {
+	_, ok := g.cells[p]
+	return ok
+}
+
+// Removes value at point. Bounds are kept as is
+func (g *Sparse[T]) Delete(p Point) {
+	delete(g.cells, p)
+}
+
+// Returns number of points with values
+func (g *Sparse[T]) Len() int {
+	return len(g.cells)
+}
+
+func (g *Sparse[T]) Bounds() Rect {
+	return g.bounds
+}
+
+// Calls fn for each point with a value, in no particular order
+func (g *Sparse[T]) Each(fn func(p Point, value T)) {
+	for p, value := range g.cells {
+		fn(p, value)
+	}
+}
+
+// Grid backed by a slice, for fixed size grids with origin in the top-left
+// corner. All points within bounds have values.
+type Dense[T any] struct {
+	width  int
+	height int
+	cells  []T
+}
+
+func NewDense[T any](width int, height int) *Dense[T] {
+	return &Dense[T]{width: width, height: height, cells: make([]T, width*height)}
+}
+
+// Returns dense grid of characters from lines of text. Lines are expected to
+// have equal length, shorter lines are padded with zero values.
+func Parse(lines []string) *Dense[byte] {
+	width := 0
+	for _, line := range lines {
+		if len(line) > width {
+			width = len(line)
+		}
+	}
+	g := NewDense[byte](width, len(lines))
+	for y, line := range lines {
+		copy(g.cells[y*width:], line)
+	}
+	return g
+}
+
+func (g *Dense[T]) Width() int {
+	return g.width
+}
+
+func (g *Dense[T]) Height() int {
+	return g.height
+}
+
+// Checks if point is within grid bounds
+func (g *Dense[T]) InBounds(p Point) bool {
+	return p[0] >= 0 && p[0] < g.width && p[1] >= 0 && p[1] < g.height
+}
+
+func (g *Dense[T]) Get(p Point) (T, bool) {
+	if !g.InBounds(p) {
+		var zero T
+		return zero, false
+	}
+	return g.cells[p[1]*g.width+p[0]], true
+}
+
+// Sets value at point. Panics if point is out of bounds
+func (g *Dense[T]) Set(p Point, value T) {
+	if !g.InBounds(p) {
+		panic("grid: point out of bounds")
+	}
+	g.cells[p[1]*g.width+p[0]] = value
+}
+
+func (g *Dense[T]) Bounds() Rect {
+	return Rect{Max: Point{g.width - 1, g.height - 1}}
+}
+
+// Returns all points in the grid, row by row
+func (g *Dense[T]) Points() []Point {
+	points := make([]Point, 0, len(g.cells))
+	for y := 0; y < g.height; y++ {
+		for x := 0; x < g.width; x++ {
+			points = append(points, Point{x, y})
+		}
+	}
+	return points
+}
+
+// Returns the orthogonal neighbors of the point that are within bounds
+func (g *Dense[T]) Neighbors4(p Point) []Point {
+	return g.inBounds(p.Neighbors4())
+}
+
+// Returns all neighbors of the point that are within bounds
+func (g *Dense[T]) Neighbors8(p Point) []Point {
+	return g.inBounds(p.Neighbors8())
+}
+
+func (g *Dense[T]) inBounds(points []Point) []Point {
+	inside := points[:0]
+	for _, p := range points {
+		if g.InBounds(p) {
+			inside = append(inside, p)
+		}
+	}
+	return inside
+}
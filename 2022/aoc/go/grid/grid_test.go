@@ -0,0 +1,73 @@
+package grid
+
+import (
+	"reflect"
+	"testing"
+)
+
+// Tests distance functions
+func TestDistances(t *testing.T) {
+	a, b := Point{1, -2}, Point{-3, 4}
+	if got := Manhattan(a, b); got != 10 {
+		t.Fatalf(`Manhattan() = %v, want 10`, got)
+	}
+	if got := Chebyshev(a, b); got != 6 {
+		t.Fatalf(`Chebyshev() = %v, want 6`, got)
+	}
+}
+
+// Tests bounds tracking of sparse grids
+func TestSparseBounds(t *testing.T) {
+	g := NewSparse[int]()
+	g.Set(Point{500, 0}, -1)
+	g.Set(Point{498, 4}, 1)
+	g.Set(Point{503, 9}, 1)
+	want := Rect{Min: Point{498, 0}, Max: Point{503, 9}}
+	if g.Bounds() != want {
+		t.Fatalf(`Bounds() = %v, want %v`, g.Bounds(), want)
+	}
+	if value, ok := g.Get(Point{498, 4}); !ok || value != 1 {
+		t.Fatalf(`Get() = %v, %v, want 1, true`, value, ok)
+	}
+	if _, ok := g.Get(Point{0, 0}); ok {
+		t.Fatalf(`Get() of unset point = true`)
+	}
+}
+
+// Tests dense grid parsing and neighbor iteration
+func TestDenseNeighbors(t *testing.T) {
+	g := Parse([]string{"Sab", "cdE"})
+	if value, _ := g.Get(Point{2, 1}); value != 'E' {
+		t.Fatalf(`Get() = %c, want E`, value)
+	}
+	neighbors := g.Neighbors4(Point{0, 0})
+	if !reflect.DeepEqual(neighbors, []Point{{1, 0}, {0, 1}}) {
+		t.Fatalf(`Neighbors4() = %v`, neighbors)
+	}
+	if n := len(g.Neighbors8(Point{1, 0})); n != 5 {
+		t.Fatalf(`len(Neighbors8()) = %v, want 5`, n)
+	}
+	if _, ok := g.Get(Point{3, 0}); ok {
+		t.Fatalf(`Get() out of bounds = true`)
+	}
+}
+
+// Tests rendering in both row orders
+func TestRender(t *testing.T) {
+	g := NewSparse[int]()
+	g.Set(Point{0, 0}, 1)
+	g.Set(Point{2, 1}, 2)
+	glyphs := map[int]rune{1: '#', 2: 'o'}
+	if got := RenderGrid[int](g, glyphs, '.'); got != "#..\n..o\n" {
+		t.Fatalf(`RenderGrid() = %q`, got)
+	}
+	up := RenderUp(g.Bounds(), func(p Point) rune {
+		if g.Has(p) {
+			return '#'
+		}
+		return '.'
+	})
+	if up != "..#\n#..\n" {
+		t.Fatalf(`RenderUp() = %q`, up)
+	}
+}
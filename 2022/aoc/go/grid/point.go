@@ -0,0 +1,82 @@
+// 2D grid primitives shared by the day modules: points, bounds, sparse and
+// dense grid backings and a text renderer.
+package grid
+
+// Grid coordinate as [x, y]
+type Point [2]int
+
+// Unit steps in each direction, with y growing downwards like in most puzzle
+// input. Days where y grows upwards just read Up and Down flipped.
+var (
+	Up    = Point{0, -1}
+	Down  = Point{0, 1}
+	Left  = Point{-1, 0}
+	Right = Point{1, 0}
+)
+
+// Steps to the four orthogonal neighbors
+var Directions4 = []Point{Left, Right, Up, Down}
+
+// Steps to all eight neighbors, orthogonal and diagonal
+var Directions8 = []Point{
+	{-1, -1}, {0, -1}, {1, -1},
+	{-1, 0}, {1, 0},
+	{-1, 1}, {0, 1}, {1, 1},
+}
+
+func (p Point) X() int {
+	return p[0]
+}
+
+func (p Point) Y() int {
+	return p[1]
+}
+
+// Returns point moved by delta
+func (p Point) Add(delta Point) Point {
+	return Point{p[0] + delta[0], p[1] + delta[1]}
+}
+
+// Returns delta between point and other
+func (p Point) Sub(other Point) Point {
+	return Point{p[0] - other[0], p[1] - other[1]}
+}
+
+// Returns the four orthogonal neighbors of the point
+func (p Point) Neighbors4() []Point {
+	return p.neighbors(Directions4)
+}
+
+// Returns all eight neighbors of the point
+func (p Point) Neighbors8() []Point {
+	return p.neighbors(Directions8)
+}
+
+func (p Point) neighbors(directions []Point) []Point {
+	neighbors := make([]Point, len(directions))
+	for i, delta := range directions {
+		neighbors[i] = p.Add(delta)
+	}
+	return neighbors
+}
+
+func Abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
+
+// Returns Manhattan (taxicab) distance between two points
+func Manhattan(a Point, b Point) int {
+	return Abs(b[0]-a[0]) + Abs(b[1]-a[1])
+}
+
+// Returns Chebyshev (chessboard) distance between two points
+func Chebyshev(a Point, b Point) int {
+	dx, dy := Abs(b[0]-a[0]), Abs(b[1]-a[1])
+	if dx > dy {
+		return dx
+	}
+	return dy
+}
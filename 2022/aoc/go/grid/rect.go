@@ -0,0 +1,43 @@
+package grid
+
+// Rectangle of points, with inclusive min and max corners
+type Rect struct {
+	Min Point
+	Max Point
+}
+
+// Returns rectangle containing only the given point
+func NewRect(p Point) Rect {
+	return Rect{Min: p, Max: p}
+}
+
+// Returns rectangle grown to contain the given point
+func (r Rect) Extend(p Point) Rect {
+	if p[0] < r.Min[0] {
+		r.Min[0] = p[0]
+	}
+	if p[0] > r.Max[0] {
+		r.Max[0] = p[0]
+	}
+	if p[1] < r.Min[1] {
+		r.Min[1] = p[1]
+	}
+	if p[1] > r.Max[1] {
+		r.Max[1] = p[1]
+	}
+	return r
+}
+
+// Checks if point is inside the rectangle
+func (r Rect) Contains(p Point) bool {
+	return p[0] >= r.Min[0] && p[0] <= r.Max[0] &&
+		p[1] >= r.Min[1] && p[1] <= r.Max[1]
+}
+
+func (r Rect) Width() int {
+	return r.Max[0] - r.Min[0] + 1
+}
+
+func (r Rect) Height() int {
+	return r.Max[1] - r.Min[1] + 1
+}
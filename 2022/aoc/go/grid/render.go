@@ -0,0 +1,44 @@
+package grid
+
+import "strings"
+
+// Renders rectangle as text, top row first. Glyph returns the character
+// to draw for each point.
+func Render(r Rect, glyph func(p Point) rune) string {
+	return render(r, glyph, false)
+}
+
+// Renders rectangle as text, bottom row first, for grids where y grows upwards
+func RenderUp(r Rect, glyph func(p Point) rune) string {
+	return render(r, glyph, true)
+}
+
+// Renders grid bounds as text, drawing values using the glyph map. Points
+// without values, or values missing from the map, are drawn as blank.
+func RenderGrid[T comparable](g Grid[T], glyphs map[T]rune, blank rune) string {
+	return Render(g.Bounds(), func(p Point) rune {
+		value, ok := g.Get(p)
+		if !ok {
+			return blank
+		}
+		if glyph, ok := glyphs[value]; ok {
+			return glyph
+		}
+		return blank
+	})
+}
+
+func render(r Rect, glyph func(p Point) rune, up bool) string {
+	builder := strings.Builder{}
+	for row := 0; row < r.Height(); row++ {
+		y := r.Min[1] + row
+		if up {
+			y = r.Max[1] - row
+		}
+		for x := r.Min[0]; x <= r.Max[0]; x++ {
+			builder.WriteRune(glyph(Point{x, y}))
+		}
+		builder.WriteRune('\n')
+	}
+	return builder.String()
+}
@@ -0,0 +1,219 @@
+package aoc
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// Returned as parse error cause for input without any lines
+var ErrEmptyInput = errors.New("empty input")
+
+// Error in puzzle input, pointing to the offending line and column
+type ParseError struct {
+	File   string
+	Line   int    // 1-based line number
+	Column int    // 1-based column, 0 if not known
+	Text   string // Offending line
+	Cause  error
+}
+
+func (e *ParseError) Error() string {
+	location := e.File
+	if location == "" {
+		location = "input"
+	}
+	location += ":" + strconv.Itoa(e.Line)
+	if e.Column > 0 {
+		location += ":" + strconv.Itoa(e.Column)
+	}
+	return fmt.Sprintf("%s: %v: %q", location, e.Cause, e.Text)
+}
+
+func (e *ParseError) Unwrap() error {
+	return e.Cause
+}
+
+// Puzzle input split into lines. Keeps track of file name and line numbers
+// for error reporting
+type Input struct {
+	File  string
+	Lines []string
+}
+
+// Input path meaning standard input
+const StdinPath = "-"
+
+// Puzzle input read into memory, so it can be parsed once per part even when
+// read from standard input. Name is used in parse errors
+type Source struct {
+	Name string
+	Data []byte
+}
+
+// Reads input from file at path, or from stdin if path is "-"
+func LoadSource(path string, stdin io.Reader) (*Source, error) {
+	if path == StdinPath {
+		data, err := io.ReadAll(stdin)
+		if err != nil {
+			return nil, err
+		}
+		return &Source{Name: "stdin", Data: data}, nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return &Source{Name: path, Data: data}, nil
+}
+
+// Returns a new reader of the input, named after the source
+func (source *Source) Reader() io.Reader {
+	return &namedReader{Reader: bytes.NewReader(source.Data), name: source.Name}
+}
+
+// Reader with a file name for error reporting, like *os.File
+type namedReader struct {
+	io.Reader
+	name string
+}
+
+func (r *namedReader) Name() string {
+	return r.name
+}
+
+// Reads input from reader into lines. Fails if the input has no lines. Parse
+// errors carry the file name if the reader has a Name method, like *os.File
+func ReadFrom(r io.Reader) (*Input, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	file := ""
+	if named, ok := r.(interface{ Name() string }); ok {
+		file = named.Name()
+	}
+	input := NewInput(file, string(data))
+	if len(input.Lines) == 0 {
+		return nil, &ParseError{File: file, Line: 1, Cause: ErrEmptyInput}
+	}
+	return input, nil
+}
+
+// Reads file at path, or stdin if path is "-", and parses it with read. Path
+// wrapper for reader based input parsers
+func ReadFile[T any](path string, read func(r io.Reader) (T, error)) (T, error) {
+	source, err := LoadSource(path, os.Stdin)
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	return read(source.Reader())
+}
+
+// Reads input file, or stdin if path is "-", into lines. Fails if the file
+// has no lines
+func ReadInput(path string) (*Input, error) {
+	return ReadFile(path, ReadFrom)
+}
+
+// Splits input text into lines. Line endings are normalized and trailing
+// newlines dropped, so both hand-copied and downloaded input parse the same
+func NewInput(file string, text string) *Input {
+	text = strings.TrimRight(strings.ReplaceAll(text, "\r\n", "\n"), "\n")
+	input := Input{File: file, Lines: []string{}}
+	if text != "" {
+		input.Lines = strings.Split(text, "\n")
+	}
+	return &input
+}
+
+// Returns input as text, lines joined by newlines
+func (input *Input) Text() string {
+	return strings.Join(input.Lines, "\n")
+}
+
+// Group of lines separated from others by blank lines
+type Block struct {
+	Start int // Index of first line in input
+	Lines []string
+}
+
+// Returns groups of lines separated by blank lines
+func (input *Input) Blocks() []Block {
+	blocks := []Block{}
+	var block *Block
+	for i, line := range input.Lines {
+		if strings.TrimSpace(line) == "" {
+			block = nil
+			continue
+		}
+		if block == nil {
+			blocks = append(blocks, Block{Start: i})
+			block = &blocks[len(blocks)-1]
+		}
+		block.Lines = append(block.Lines, line)
+	}
+	return blocks
+}
+
+// Returns parse error for line at index. Column points at the first
+// occurrence of field in line, if field is given and found
+func (input *Input) Error(index int, field string, cause error) error {
+	column := -1
+	if field != "" && index >= 0 && index < len(input.Lines) {
+		column = strings.Index(input.Lines[index], field)
+	}
+	return input.ErrorAt(index, column, cause)
+}
+
+// Returns parse error for line at index, pointing at 0-based column. Pass a
+// negative column if not known
+func (input *Input) ErrorAt(index int, column int, cause error) error {
+	parseError := ParseError{File: input.File, Line: index + 1, Column: column + 1, Cause: cause}
+	if column < 0 {
+		parseError.Column = 0
+	}
+	if index >= 0 && index < len(input.Lines) {
+		parseError.Text = input.Lines[index]
+	}
+	return &parseError
+}
+
+// Returns parse error for line at index with formatted cause
+func (input *Input) Errorf(index int, field string, format string, args ...any) error {
+	return input.Error(index, field, fmt.Errorf(format, args...))
+}
+
+// Parses integer field found in line at index
+func (input *Input) Atoi(index int, field string) (int, error) {
+	value, err := strconv.Atoi(field)
+	if err != nil {
+		return 0, input.Errorf(index, field, "invalid number %q", field)
+	}
+	return value, nil
+}
+
+// Returns the fields of line at index split by sep, or a parse error if the
+// line doesn't have exactly n fields
+func (input *Input) Split(index int, sep string, n int) ([]string, error) {
+	fields := strings.Split(input.Lines[index], sep)
+	if len(fields) != n {
+		return nil, input.Errorf(index, "", "expected %d fields separated by %q, got %d", n, sep, len(fields))
+	}
+	return fields, nil
+}
+
+// Returns whitespace separated fields of line at index, or a parse error if
+// the line has fewer than n fields
+func (input *Input) Fields(index int, n int) ([]string, error) {
+	fields := strings.Fields(input.Lines[index])
+	if len(fields) < n {
+		return nil, input.Errorf(index, "", "expected at least %d fields, got %d", n, len(fields))
+	}
+	return fields, nil
+}
@@ -0,0 +1,90 @@
+package aoc
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// Tests that line endings and trailing newlines don't produce extra lines
+func TestNewInput(t *testing.T) {
+	input := NewInput("test.txt", "1000\r\n2000\r\n\r\n3000\n\n")
+	want := []string{"1000", "2000", "", "3000"}
+	if !reflect.DeepEqual(input.Lines, want) {
+		t.Fatalf(`NewInput().Lines = %q, want %q`, input.Lines, want)
+	}
+	blocks := input.Blocks()
+	if len(blocks) != 2 || blocks[1].Start != 3 || len(blocks[0].Lines) != 2 {
+		t.Fatalf(`Blocks() = %v`, blocks)
+	}
+}
+
+// Tests parse error location and message
+func TestParseError(t *testing.T) {
+	input := NewInput("../input.txt", "2-4,6-8\n2-x,4-5")
+	_, err := input.Atoi(1, "x")
+	var parseError *ParseError
+	if !errors.As(err, &parseError) {
+		t.Fatalf(`Atoi() error = %v, want ParseError`, err)
+	}
+	if parseError.Line != 2 || parseError.Column != 3 {
+		t.Fatalf(`ParseError at %d:%d, want 2:3`, parseError.Line, parseError.Column)
+	}
+	want := `../input.txt:2:3: invalid number "x": "2-x,4-5"`
+	if err.Error() != want {
+		t.Fatalf(`Error() = %q, want %q`, err.Error(), want)
+	}
+	if _, err := input.Split(0, ",", 3); err == nil {
+		t.Fatalf(`Split() with wrong field count did not fail`)
+	}
+	err = input.ErrorAt(0, -1, ErrEmptyInput)
+	if err.Error() != `../input.txt:1: empty input: "2-4,6-8"` {
+		t.Fatalf(`ErrorAt() without column = %q`, err.Error())
+	}
+}
+
+// Tests that empty input files fail to read
+func TestReadEmptyInput(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "input.txt")
+	if err := os.WriteFile(path, []byte("\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ReadInput(path); !errors.Is(err, ErrEmptyInput) {
+		t.Fatalf(`ReadInput() error = %v, want ErrEmptyInput`, err)
+	}
+}
+
+// Tests that parse errors are named after the file or stdin they came from
+func TestReadFrom(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "input.txt")
+	if err := os.WriteFile(path, []byte("1\r\nx\r\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	for _, test := range []struct {
+		path  string
+		stdin string
+		want  string
+	}{
+		{path, "", path + ":2"},
+		{StdinPath, "1\nx\n", "stdin:2"},
+	} {
+		source, err := LoadSource(test.path, strings.NewReader(test.stdin))
+		if err != nil {
+			t.Fatal(err)
+		}
+		input, err := ReadFrom(source.Reader())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if input.Text() != "1\nx" {
+			t.Fatalf(`ReadFrom().Text() = %q, want "1\nx"`, input.Text())
+		}
+		_, err = input.Atoi(1, input.Lines[1])
+		if err == nil || !strings.HasPrefix(err.Error(), test.want) {
+			t.Fatalf(`Atoi() error = %v, want prefix %s`, err, test.want)
+		}
+	}
+}
@@ -0,0 +1,215 @@
+// Ledger of confirmed puzzle answers, kept per year in answers.json. Used to
+// catch refactors that change an answer, and to mark runner output.
+package ledger
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+
+	"github.com/erikzak/adventofcode/2022/aoc"
+)
+
+// Ledger file name, in the year directory
+const FileName = "answers.json"
+
+// Returned when an answer is known to be wrong without asking the website
+var ErrKnownWrong = errors.New("answer is known to be wrong")
+
+// What is known about the answer of a puzzle part
+type Entry struct {
+	Answer  string   `json:"answer,omitempty"`   // Confirmed answer
+	Wrong   []string `json:"wrong,omitempty"`    // Rejected answers
+	TooLow  *int64   `json:"too_low,omitempty"`  // Highest answer known to be too low
+	TooHigh *int64   `json:"too_high,omitempty"` // Lowest answer known to be too high
+}
+
+// Confirmed answers for a year, by day and part
+type Ledger struct {
+	Path    string                 `json:"-"`
+	Year    int                    `json:"year"`
+	Answers map[int]map[int]*Entry `json:"answers"`
+}
+
+// Returns ledger path for the given year
+func Path(root string, year int) string {
+	return filepath.Join(root, strconv.Itoa(year), FileName)
+}
+
+// Loads ledger of the given year from the year directory under root
+func Open(root string, year int) (*Ledger, error) {
+	ledger, err := Load(Path(root, year))
+	if err != nil {
+		return nil, err
+	}
+	if ledger.Year == 0 {
+		ledger.Year = year
+	} else if ledger.Year != year {
+		return nil, fmt.Errorf("%s: ledger is for %d, not %d", ledger.Path, ledger.Year, year)
+	}
+	return ledger, nil
+}
+
+// Loads ledger from path. A missing file gives an empty ledger
+func Load(path string) (*Ledger, error) {
+	ledger := Ledger{Path: path, Answers: map[int]map[int]*Entry{}}
+	content, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return &ledger, nil
+	} else if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(content, &ledger); err != nil {
+		return nil, fmt.Errorf("%s: %v", path, err)
+	}
+	if ledger.Answers == nil {
+		ledger.Answers = map[int]map[int]*Entry{}
+	}
+	return &ledger, nil
+}
+
+// Writes ledger back to its path as indented JSON
+func (ledger *Ledger) Save() error {
+	content, err := json.MarshalIndent(ledger, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(ledger.Path, append(content, '\n'), 0o644)
+}
+
+// Returns ledger entry for day and part, or nil if there is none
+func (ledger *Ledger) Entry(day int, part int) *Entry {
+	return ledger.Answers[day][part]
+}
+
+// Returns ledger entry for day and part, adding an empty one if missing
+func (ledger *Ledger) entry(day int, part int) *Entry {
+	if ledger.Answers[day] == nil {
+		ledger.Answers[day] = map[int]*Entry{}
+	}
+	if ledger.Answers[day][part] == nil {
+		ledger.Answers[day][part] = &Entry{}
+	}
+	return ledger.Answers[day][part]
+}
+
+// Returns confirmed answer for day and part
+func (ledger *Ledger) Answer(day int, part int) (string, bool) {
+	entry := ledger.Entry(day, part)
+	if entry == nil || entry.Answer == "" {
+		return "", false
+	}
+	return entry.Answer, true
+}
+
+// Records answer for day and part as confirmed
+func (ledger *Ledger) Confirm(day int, part int, answer string) {
+	ledger.entry(day, part).Answer = answer
+}
+
+// Records rejected answer for day and part. Numeric answers rejected as too
+// low or too high narrow the bounds of the right answer
+func (ledger *Ledger) Reject(day int, part int, answer string, tooLow bool, tooHigh bool) {
+	entry := ledger.entry(day, part)
+	if !contains(entry.Wrong, answer) {
+		entry.Wrong = append(entry.Wrong, answer)
+	}
+	value, err := strconv.ParseInt(answer, 10, 64)
+	if err != nil {
+		return
+	}
+	if tooLow && (entry.TooLow == nil || value > *entry.TooLow) {
+		entry.TooLow = &value
+	}
+	if tooHigh && (entry.TooHigh == nil || value < *entry.TooHigh) {
+		entry.TooHigh = &value
+	}
+}
+
+// Checks answer against what is known for day and part before submitting.
+// Returns error wrapping ErrKnownWrong if the answer can't be right
+func (ledger *Ledger) Precheck(day int, part int, answer string) error {
+	entry := ledger.Entry(day, part)
+	if entry == nil {
+		return nil
+	}
+	if entry.Answer != "" && entry.Answer != answer {
+		return fmt.Errorf("%w: confirmed answer is %s", ErrKnownWrong, entry.Answer)
+	}
+	if contains(entry.Wrong, answer) {
+		return fmt.Errorf("%w: %s was already rejected", ErrKnownWrong, answer)
+	}
+	value, err := strconv.ParseInt(answer, 10, 64)
+	if err != nil {
+		return nil
+	}
+	if entry.TooLow != nil && value <= *entry.TooLow {
+		return fmt.Errorf("%w: %s is too low, answer is above %d", ErrKnownWrong, answer, *entry.TooLow)
+	}
+	if entry.TooHigh != nil && value >= *entry.TooHigh {
+		return fmt.Errorf("%w: %s is too high, answer is below %d", ErrKnownWrong, answer, *entry.TooHigh)
+	}
+	return nil
+}
+
+// Checks if values contain value
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// Returns days with at least one confirmed answer, sorted
+func (ledger *Ledger) Days() []int {
+	days := []int{}
+	for day, parts := range ledger.Answers {
+		for _, entry := range parts {
+			if entry != nil && entry.Answer != "" {
+				days = append(days, day)
+				break
+			}
+		}
+	}
+	sort.Ints(days)
+	return days
+}
+
+// Result of checking an answer against the ledger
+type Verdict int
+
+const (
+	Unknown Verdict = iota // No confirmed answer
+	Correct
+	Wrong
+)
+
+// Returns check mark for verdict, empty if unknown
+func (verdict Verdict) String() string {
+	switch verdict {
+	case Correct:
+		return "✓"
+	case Wrong:
+		return "✗"
+	}
+	return ""
+}
+
+// Checks result answer against the confirmed answer. Failed results are
+// always wrong if there is a confirmed answer
+func (ledger *Ledger) Check(result aoc.Result) Verdict {
+	answer, ok := ledger.Answer(result.Day, result.Part)
+	if !ok {
+		return Unknown
+	}
+	if result.Error != "" || result.Answer != answer {
+		return Wrong
+	}
+	return Correct
+}
@@ -0,0 +1,96 @@
+package ledger
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/erikzak/adventofcode/2022/aoc"
+)
+
+// Tests confirming answers and checking results against them
+func TestCheck(t *testing.T) {
+	ledger, err := Open(t.TempDir(), 2022)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ledger.Confirm(21, 2, "7010269744524")
+	tests := []struct {
+		result aoc.Result
+		want   Verdict
+	}{
+		{aoc.Result{Day: 21, Part: 2, Answer: "7010269744524"}, Correct},
+		{aoc.Result{Day: 21, Part: 2, Answer: "301"}, Wrong},
+		{aoc.Result{Day: 21, Part: 2, Error: "panic"}, Wrong},
+		{aoc.Result{Day: 21, Part: 1, Answer: "152"}, Unknown},
+	}
+	for _, test := range tests {
+		if got := ledger.Check(test.result); got != test.want {
+			t.Fatalf(`Check(%+v) = %v, want %v`, test.result, got, test.want)
+		}
+	}
+}
+
+// Tests that saved ledgers load back the same
+func TestSaveLoad(t *testing.T) {
+	root := t.TempDir()
+	if err := os.Mkdir(filepath.Join(root, "2022"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	ledger, err := Open(root, 2022)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ledger.Confirm(1, 1, "24000")
+	ledger.Confirm(1, 2, "45000")
+	if err := ledger.Save(); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := Load(ledger.Path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if answer, _ := loaded.Answer(1, 2); answer != "45000" || loaded.Year != 2022 {
+		t.Fatalf(`loaded answer %q for %d, want 45000 for 2022`, answer, loaded.Year)
+	}
+	if days := loaded.Days(); len(days) != 1 || days[0] != 1 {
+		t.Fatalf(`Days() = %v, want [1]`, days)
+	}
+}
+
+// Tests that rejected answers and bounds are refused before submitting
+func TestPrecheck(t *testing.T) {
+	ledger, err := Open(t.TempDir(), 2022)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ledger.Reject(21, 2, "301", true, false)
+	ledger.Reject(21, 2, "9000000000000", false, true)
+	ledger.Reject(21, 2, "8000000000000", false, true)
+	ledger.Reject(21, 2, "7000000000001", false, false)
+	tests := []struct {
+		answer string
+		wrong  bool
+	}{
+		{"7010269744524", false},
+		{"301", true},
+		{"42", true},
+		{"8500000000000", true},
+		{"7000000000001", true},
+		{"7999999999999", false},
+	}
+	for _, test := range tests {
+		err := ledger.Precheck(21, 2, test.answer)
+		if wrong := errors.Is(err, ErrKnownWrong); wrong != test.wrong {
+			t.Fatalf(`Precheck(%s) = %v, want known wrong %v`, test.answer, err, test.wrong)
+		}
+	}
+	if high := *ledger.Entry(21, 2).TooHigh; high != 8000000000000 {
+		t.Fatalf(`TooHigh = %d, want 8000000000000`, high)
+	}
+	ledger.Confirm(21, 2, "7010269744524")
+	if err := ledger.Precheck(21, 2, "7999999999999"); !errors.Is(err, ErrKnownWrong) {
+		t.Fatalf(`Precheck() of other than confirmed answer = %v`, err)
+	}
+}
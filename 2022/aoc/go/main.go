@@ -0,0 +1,277 @@
+package aoc
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/erikzak/adventofcode/2022/aoc/progress"
+	"github.com/erikzak/adventofcode/2022/aoc/visual"
+)
+
+// Entry point for day modules. Solves puzzle parts and logs answers, or writes
+// them as JSON lines or CSV rows. The aoc runner reads JSON lines. The gen
+// subcommand writes random input instead, for days with a generator, and
+// days may add subcommands of their own.
+// Interrupting stops the part being solved, which is reported as an error
+func Main(solver Solver) {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	code := run(ctx, solver, os.Args[1:], os.Stdin, os.Stdout, os.Stderr)
+	stop()
+	os.Exit(code)
+}
+
+// Parses command line arguments and solves the requested parts. Returns exit code
+func run(ctx context.Context, solver Solver, args []string, stdin io.Reader, stdout io.Writer, stderr io.Writer) int {
+	if len(args) > 0 && args[0] == "gen" {
+		return runGenerate(solver, args[1:], stdout, stderr)
+	}
+	if len(args) > 0 {
+		if command := solver.Command(args[0]); command != nil {
+			return command(args[1:], stdin, stdout, stderr)
+		}
+	}
+	flags := flag.NewFlagSet("day", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	part := flags.Int("part", 0, "puzzle part to solve, 0 for both")
+	inputPath := flags.String("input", DefaultInputPath, `puzzle input file, "-" for stdin`)
+	format := flags.String("format", FormatText, "output format: text, json or csv")
+	visualize := flags.Bool("visualize", false, "play the simulation in the terminal, for days with a visualization")
+	speed := flags.Int("speed", 20, "visualization speed in steps per second")
+	gifPath := flags.String("gif", "", "write the simulation as an animated GIF, for days with a visualization")
+	pngPath := flags.String("png", "", "write the final state of the simulation as a PNG")
+	cellSize := flags.Int("cell", 4, "pixels per cell in exported images")
+	delay := flags.Int("delay", 5, "delay between GIF frames in 100ths of a second")
+	paletteSpec := flags.String("palette", "", `glyph colors in exported images, like "#=808080,o=ffcc00,bg=000000"`)
+	timeout := flags.Duration("timeout", 0, "time limit for solving each part, like 30s, 0 for none")
+	progressInterval := flags.Duration("progress", 0, "log search progress at this interval, like 5s, 0 for none")
+	profiles := Profiles{}
+	flags.StringVar(&profiles.CPU, "cpuprofile", "", "write a CPU profile of each part to this directory")
+	flags.StringVar(&profiles.Memory, "memprofile", "", "write a heap profile of each part to this directory")
+	flags.StringVar(&profiles.Trace, "trace", "", "write an execution trace of each part to this directory")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if err := ValidFormat(*format); err != nil {
+		fmt.Fprintln(stderr, err)
+		return 2
+	}
+	// Animations sleep between frames, which would only show up as noise
+	if profiles != (Profiles{}) && (*visualize || *gifPath != "" || *pngPath != "") {
+		fmt.Fprintln(stderr, "-visualize, -gif and -png can't be combined with profiling")
+		return 2
+	}
+	palette, err := visual.DefaultPalette.With(*paletteSpec)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return 2
+	}
+	exportOptions := visual.ExportOptions{Palette: palette, CellSize: *cellSize, Delay: *delay}
+	parts := []int{1, 2}
+	if *part != 0 {
+		parts = []int{*part}
+	}
+
+	logger := log.New(stderr, "", log.LstdFlags)
+	var encoder ResultEncoder
+	if *format != FormatText {
+		encoder, _ = NewResultEncoder(*format, stdout)
+	}
+	// Read once up front, stdin can't be read again for the second part
+	source, loadErr := LoadSource(*inputPath, stdin)
+	var terminal *visual.Terminal
+	if *visualize && loadErr == nil {
+		if terminal, err = visual.OpenTerminal(*speed); err != nil {
+			fmt.Fprintln(stderr, err)
+			return 1
+		}
+	}
+	results := []Result{}
+	exitCode := 0
+	// Part left running after its timeout, still stepping the shared
+	// animation and recorder, so later animated parts are skipped
+	abandoned := 0
+	for _, p := range parts {
+		partCtx, cancel := partContext(ctx, *timeout)
+		if *progressInterval > 0 {
+			partCtx = reportProgress(partCtx, logger, solver.Label(p), *progressInterval)
+		}
+		var result Result
+		if loadErr != nil {
+			year, day := solver.Date()
+			result = Result{Year: year, Day: day, Part: p, Error: loadErr.Error()}
+		} else if abandoned != 0 {
+			year, day := solver.Date()
+			result = Result{Year: year, Day: day, Part: p, Error: fmt.Sprintf("skipped, part %d still running", abandoned)}
+		} else if terminal != nil || *gifPath != "" || *pngPath != "" {
+			var recorder *visual.Recorder
+			if *gifPath != "" || *pngPath != "" {
+				recorder = visual.NewRecorder(exportOptions)
+			}
+			animation := visual.NewRecording(recorder)
+			if terminal != nil {
+				animation = terminal.Animation
+				animation.Record(recorder)
+			}
+			result = SolvePartAnimated(partCtx, solver, p, source, animation)
+			if result.Error != "" && partCtx.Err() != nil {
+				abandoned = p
+			} else if terminal != nil {
+				terminal.Hold(fmt.Sprintf("part %d done", p))
+			}
+			if recorder != nil && result.Error == "" {
+				if err := exportImages(recorder, *gifPath, *pngPath, p, len(parts) > 1); err != nil {
+					result.Error = err.Error()
+				}
+			}
+		} else {
+			result = solveProfiled(partCtx, solver, p, source, profiles)
+		}
+		result.Error = contextError(partCtx, result.Error, *timeout)
+		cancel()
+		if result.Error != "" {
+			exitCode = 1
+		}
+		results = append(results, result)
+		// Results wait for the terminal to be restored when visualizing
+		if terminal != nil {
+			continue
+		}
+		if err := writeResult(result, solver, logger, encoder); err != nil {
+			fmt.Fprintln(stderr, err)
+			return 1
+		}
+	}
+	if terminal != nil {
+		if err := terminal.Close(); err != nil {
+			fmt.Fprintln(stderr, err)
+		}
+		for _, result := range results {
+			if err := writeResult(result, solver, logger, encoder); err != nil {
+				fmt.Fprintln(stderr, err)
+				return 1
+			}
+		}
+	}
+	if encoder != nil {
+		if err := encoder.Flush(); err != nil {
+			fmt.Fprintln(stderr, err)
+			return 1
+		}
+	}
+	return exitCode
+}
+
+// Solves part like SolvePart, writing the requested profiles of it
+func solveProfiled(ctx context.Context, solver Solver, part int, source *Source, profiles Profiles) Result {
+	year, day := solver.Date()
+	stop, err := profiles.Start(day, part)
+	if err != nil {
+		return Result{Year: year, Day: day, Part: part, Error: err.Error()}
+	}
+	result := SolvePart(ctx, solver, part, source)
+	if err := stop(); err != nil && result.Error == "" {
+		result.Error = err.Error()
+	}
+	return result
+}
+
+// Returns context for solving a part, with the timeout unless zero. The
+// returned cancel also stops progress reports of the part
+func partContext(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout > 0 {
+		return context.WithTimeout(ctx, timeout)
+	}
+	return context.WithCancel(ctx)
+}
+
+// Returns context carrying a progress tracker, logged at interval until ctx
+// is done. Progress is only logged by solvers publishing it
+func reportProgress(ctx context.Context, logger *log.Logger, label string, interval time.Duration) context.Context {
+	tracker := progress.NewTracker()
+	go progress.Report(ctx, tracker, interval, func(snapshot progress.Snapshot, elapsed time.Duration) {
+		logger.Printf("%s: %v after %v\n", label, snapshot, elapsed.Round(100*time.Millisecond))
+	})
+	return progress.NewContext(ctx, tracker)
+}
+
+// Returns error message of a part stopped by ctx, which is clearer than the
+// context error. Other errors are returned as is
+func contextError(ctx context.Context, message string, timeout time.Duration) string {
+	if message == "" {
+		return message
+	}
+	switch ctx.Err() {
+	case context.DeadlineExceeded:
+		return fmt.Sprintf("%s after %v", TimeoutError, timeout)
+	case context.Canceled:
+		return "interrupted"
+	}
+	return message
+}
+
+// Writes recorded simulation to the GIF and PNG paths, skipping empty ones.
+// Paths get a part suffix when solving both parts, like day14-part2.gif
+func exportImages(recorder *visual.Recorder, gifPath string, pngPath string, part int, both bool) error {
+	exports := []struct {
+		path  string
+		write func(w io.Writer) error
+	}{
+		{gifPath, recorder.WriteGIF},
+		{pngPath, recorder.WritePNG},
+	}
+	for _, export := range exports {
+		if export.path == "" {
+			continue
+		}
+		path := export.path
+		if both {
+			extension := filepath.Ext(path)
+			path = fmt.Sprintf("%s-part%d%s", strings.TrimSuffix(path, extension), part, extension)
+		}
+		file, err := os.Create(path)
+		if err != nil {
+			return err
+		}
+		if err := export.write(file); err != nil {
+			file.Close()
+			return err
+		}
+		if err := file.Close(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Logs result, or encodes it if there is an encoder
+func writeResult(result Result, solver Solver, logger *log.Logger, encoder ResultEncoder) error {
+	if encoder == nil {
+		logResult(logger, solver.Label(result.Part), result)
+		return nil
+	}
+	return encoder.Encode(result)
+}
+
+// Logs result like the day modules always have, with multi-line answers indented
+func logResult(logger *log.Logger, label string, result Result) {
+	if result.Error != "" {
+		logger.Printf("%s: error: %v\n", label, result.Error)
+		return
+	}
+	if !strings.Contains(result.Answer, "\n") {
+		logger.Printf("%s: %v\n", label, result.Answer)
+		return
+	}
+	logger.Printf("%s:\n", label)
+	for _, line := range strings.Split(result.Answer, "\n") {
+		logger.Printf("\t%v\n", line)
+	}
+}
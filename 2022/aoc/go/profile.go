@@ -0,0 +1,108 @@
+package aoc
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"runtime/pprof"
+	"runtime/trace"
+)
+
+// Directories receiving profiles of each solved part, named like
+// day16-part1.cpu.pprof. Empty directories are not profiled
+type Profiles struct {
+	CPU    string
+	Memory string // Heap profile written after the part, allocations included
+	Trace  string // Execution trace, for go tool trace
+}
+
+// Returns profile path for part in dir, with extension like ".cpu.pprof"
+func profilePath(dir string, day int, part int, extension string) string {
+	return filepath.Join(dir, fmt.Sprintf("day%02d-part%d%s", day, part, extension))
+}
+
+// Starts CPU profiling and tracing of part. The returned stop function ends
+// them and writes the heap profile. Allocations in the heap profile are
+// counted from the start of the process, so part 2 includes part 1 unless
+// solved alone
+func (profiles Profiles) Start(day int, part int) (stop func() error, err error) {
+	// Files are closed by stop, or right away if starting fails
+	files := []*os.File{}
+	closeAll := func() error {
+		var firstErr error
+		for _, file := range files {
+			if err := file.Close(); err != nil && firstErr == nil {
+				firstErr = err
+			}
+		}
+		return firstErr
+	}
+	create := func(dir string, extension string) (*os.File, error) {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return nil, err
+		}
+		file, err := os.Create(profilePath(dir, day, part, extension))
+		if err == nil {
+			files = append(files, file)
+		}
+		return file, err
+	}
+	stopCPU, stopTrace := false, false
+	stopProfiling := func() error {
+		if stopCPU {
+			pprof.StopCPUProfile()
+		}
+		if stopTrace {
+			trace.Stop()
+		}
+		return closeAll()
+	}
+	if profiles.CPU != "" {
+		file, err := create(profiles.CPU, ".cpu.pprof")
+		if err != nil {
+			stopProfiling()
+			return nil, err
+		}
+		if err := pprof.StartCPUProfile(file); err != nil {
+			stopProfiling()
+			return nil, err
+		}
+		stopCPU = true
+	}
+	if profiles.Trace != "" {
+		file, err := create(profiles.Trace, ".trace")
+		if err != nil {
+			stopProfiling()
+			return nil, err
+		}
+		if err := trace.Start(file); err != nil {
+			stopProfiling()
+			return nil, err
+		}
+		stopTrace = true
+	}
+	return func() error {
+		err := stopProfiling()
+		if profiles.Memory == "" {
+			return err
+		}
+		if err := os.MkdirAll(profiles.Memory, 0o755); err != nil {
+			return err
+		}
+		file, createErr := os.Create(profilePath(profiles.Memory, day, part, ".mem.pprof"))
+		if createErr != nil {
+			return createErr
+		}
+		// Get up-to-date statistics on memory in use
+		runtime.GC()
+		writeErr := pprof.WriteHeapProfile(file)
+		if closeErr := file.Close(); writeErr == nil {
+			writeErr = closeErr
+		}
+		if err == nil {
+			err = writeErr
+		}
+		return err
+	}, nil
+}
@@ -0,0 +1,110 @@
+// Progress reporting for long-running searches. Solvers find their tracker
+// in the context they are given and publish states explored, best answer so
+// far and queue depth. A reporter reads the tracker periodically.
+package progress
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"time"
+)
+
+// Number of search states between cancellation checks and tracker updates.
+// Checking the context for every state would slow down the tight loops
+const Batch = 4096
+
+// Progress of a search. Safe for concurrent use. Updates to a nil tracker
+// are ignored, so solvers can publish unconditionally
+type Tracker struct {
+	explored atomic.Int64
+	best     atomic.Int64
+	hasBest  atomic.Bool
+	queue    atomic.Int64
+}
+
+// Progress at one point in time
+type Snapshot struct {
+	Explored int64 // Search states explored
+	Best     int64 // Best answer so far, if HasBest
+	HasBest  bool
+	Queue    int64 // States waiting to be explored
+}
+
+func NewTracker() *Tracker {
+	return &Tracker{}
+}
+
+// Adds to the number of states explored
+func (tracker *Tracker) Explore(states int) {
+	if tracker != nil {
+		tracker.explored.Add(int64(states))
+	}
+}
+
+// Sets the best answer found so far
+func (tracker *Tracker) Best(value int) {
+	if tracker != nil {
+		tracker.best.Store(int64(value))
+		tracker.hasBest.Store(true)
+	}
+}
+
+// Sets the number of states waiting to be explored
+func (tracker *Tracker) Queue(depth int) {
+	if tracker != nil {
+		tracker.queue.Store(int64(depth))
+	}
+}
+
+// Returns current progress
+func (tracker *Tracker) Snapshot() Snapshot {
+	if tracker == nil {
+		return Snapshot{}
+	}
+	return Snapshot{
+		Explored: tracker.explored.Load(),
+		Best:     tracker.best.Load(),
+		HasBest:  tracker.hasBest.Load(),
+		Queue:    tracker.queue.Load(),
+	}
+}
+
+func (snapshot Snapshot) String() string {
+	text := fmt.Sprintf("%d states explored", snapshot.Explored)
+	if snapshot.HasBest {
+		text += fmt.Sprintf(", best %d", snapshot.Best)
+	}
+	return text + fmt.Sprintf(", queue %d", snapshot.Queue)
+}
+
+type trackerKey struct{}
+
+// Returns context carrying tracker
+func NewContext(ctx context.Context, tracker *Tracker) context.Context {
+	return context.WithValue(ctx, trackerKey{}, tracker)
+}
+
+// Returns tracker carried by context, nil if there is none
+func FromContext(ctx context.Context) *Tracker {
+	tracker, _ := ctx.Value(trackerKey{}).(*Tracker)
+	return tracker
+}
+
+// Calls report with the tracker's progress and time elapsed every interval,
+// until ctx is done. Progress is only reported once something was explored
+func Report(ctx context.Context, tracker *Tracker, interval time.Duration, report func(snapshot Snapshot, elapsed time.Duration)) {
+	start := time.Now()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if snapshot := tracker.Snapshot(); snapshot.Explored > 0 {
+				report(snapshot, time.Since(start))
+			}
+		}
+	}
+}
@@ -0,0 +1,68 @@
+package progress
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+)
+
+// Tests concurrent updates and that nil trackers ignore them
+func TestTracker(t *testing.T) {
+	tracker := NewTracker()
+	wg := sync.WaitGroup{}
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 1000; j++ {
+				tracker.Explore(1)
+			}
+		}()
+	}
+	wg.Wait()
+	tracker.Best(1651)
+	tracker.Queue(12)
+	want := "4000 states explored, best 1651, queue 12"
+	if got := tracker.Snapshot().String(); got != want {
+		t.Fatalf(`Snapshot() = %q, want %q`, got, want)
+	}
+	var none *Tracker
+	none.Explore(1)
+	none.Best(1)
+	none.Queue(1)
+	if got := none.Snapshot(); got != (Snapshot{}) {
+		t.Fatalf(`nil Snapshot() = %+v, want zero`, got)
+	}
+	if got := FromContext(NewContext(context.Background(), tracker)); got != tracker {
+		t.Fatalf(`FromContext() = %p, want %p`, got, tracker)
+	}
+	if got := FromContext(context.Background()); got != nil {
+		t.Fatalf(`FromContext() without tracker = %p, want nil`, got)
+	}
+}
+
+// Tests that progress is reported periodically until the context is done,
+// but not before anything was explored
+func TestReport(t *testing.T) {
+	tracker := NewTracker()
+	ctx, cancel := context.WithCancel(context.Background())
+	reports := make(chan Snapshot, 100)
+	done := make(chan struct{})
+	go func() {
+		Report(ctx, tracker, time.Millisecond, func(snapshot Snapshot, elapsed time.Duration) {
+			reports <- snapshot
+		})
+		close(done)
+	}()
+	time.Sleep(20 * time.Millisecond)
+	if len(reports) != 0 {
+		t.Fatalf(`Report() reported %d times before progress`, len(reports))
+	}
+	tracker.Explore(Batch)
+	if snapshot := <-reports; snapshot.Explored != Batch {
+		t.Fatalf(`Report() reported %+v, want %d explored`, snapshot, Batch)
+	}
+	cancel()
+	<-done
+}
@@ -0,0 +1,183 @@
+// Shared plumbing for the Advent of Code day modules.
+//
+// Each day registers its input parser and part solvers as a Puzzle and hands
+// it to Main, which lets the aoc runner dispatch to every day the same way.
+package aoc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+
+	"github.com/erikzak/adventofcode/2022/aoc/visual"
+)
+
+// Input file used when no other input is given. Relative to the day module
+const DefaultInputPath = "../input.txt"
+
+// Common interface for solving a day's puzzle parts
+type Solver interface {
+	Date() (year int, day int)
+	Label(part int) string
+	// Solves part, stopping early with the context error if ctx is done
+	// before the solver returns
+	Solve(ctx context.Context, part int, r io.Reader) (answer string, err error)
+	Generator() *Generator       // Nil if the day has none
+	Command(name string) Command // Nil if the day has no such subcommand
+	// Solves part like Solve, playing the simulation on animation.
+	// ErrNoAnimation if the day has no Frame hook
+	SolveAnimated(ctx context.Context, part int, r io.Reader, animation *visual.Animation) (answer string, err error)
+}
+
+// Returned when visualizing a day that can't be animated
+var ErrNoAnimation = errors.New("day has no visualization")
+
+// Registers a day's input parser and part solvers. T is the parsed input type,
+// A1 and A2 the answer types of part 1 and 2. Solvers with long searches
+// should return ctx.Err() once ctx is done, and publish their progress to
+// the tracker from progress.FromContext(ctx). Others can ignore ctx.
+type Puzzle[T, A1, A2 any] struct {
+	Year       int
+	Day        int
+	ReadInput  func(r io.Reader) (T, error)
+	SolvePart1 func(ctx context.Context, input T) (A1, error)
+	SolvePart2 func(ctx context.Context, input T) (A2, error)
+	Labels     [2]string  // Answer descriptions used when logging
+	Gen        *Generator // Random input generator, optional
+	// Extra subcommands of the day module, like day 1's stats, by name.
+	// Optional
+	Commands map[string]Command
+	// Attaches animation to parsed input, for days implementing
+	// visual.Framer. Returns the input to solve. Optional
+	Animate func(input T, animation *visual.Animation) T
+}
+
+// Returns puzzle year and day
+func (puzzle Puzzle[T, A1, A2]) Date() (int, int) {
+	return puzzle.Year, puzzle.Day
+}
+
+// Returns random input generator, nil if the puzzle has none
+func (puzzle Puzzle[T, A1, A2]) Generator() *Generator {
+	return puzzle.Gen
+}
+
+// Returns subcommand of the given name, nil if the puzzle has none
+func (puzzle Puzzle[T, A1, A2]) Command(name string) Command {
+	return puzzle.Commands[name]
+}
+
+// Subcommand of a day module, run with the arguments after its name. Input is
+// read from the -input path or stdin, as when solving. Returns exit code
+type Command func(args []string, stdin io.Reader, stdout io.Writer, stderr io.Writer) int
+
+// Returns answer description for the given part
+func (puzzle Puzzle[T, A1, A2]) Label(part int) string {
+	if part < 1 || part > len(puzzle.Labels) || puzzle.Labels[part-1] == "" {
+		return fmt.Sprintf("Part %d", part)
+	}
+	return puzzle.Labels[part-1]
+}
+
+// Parses input from reader and solves the given part. Input is parsed fresh
+// for every part, since some solvers modify their input. Solver panics are
+// returned as errors
+func (puzzle Puzzle[T, A1, A2]) Solve(ctx context.Context, part int, r io.Reader) (answer string, err error) {
+	return puzzle.solve(ctx, part, r, nil)
+}
+
+// Parses input from reader and solves the given part with animation attached
+func (puzzle Puzzle[T, A1, A2]) SolveAnimated(ctx context.Context, part int, r io.Reader, animation *visual.Animation) (string, error) {
+	if puzzle.Animate == nil {
+		return "", ErrNoAnimation
+	}
+	return puzzle.solve(ctx, part, r, animation)
+}
+
+// Result of a solver running in the background
+type solved struct {
+	answer string
+	err    error
+}
+
+// Solves part, with animation attached to the parsed input unless nil.
+// Solvers that don't check ctx themselves are left running in the
+// background when ctx is done, and the context error is returned
+func (puzzle Puzzle[T, A1, A2]) solve(ctx context.Context, part int, r io.Reader, animation *visual.Animation) (string, error) {
+	if part != 1 && part != 2 {
+		return "", fmt.Errorf("invalid part: %d", part)
+	}
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+	done := make(chan solved, 1)
+	go func() {
+		answer, err := puzzle.solveInput(ctx, part, r, animation)
+		done <- solved{answer, err}
+	}()
+	select {
+	case result := <-done:
+		return result.answer, result.err
+	case <-ctx.Done():
+		return "", ctx.Err()
+	}
+}
+
+// Parses input and solves part. Solver panics are returned as errors
+func (puzzle Puzzle[T, A1, A2]) solveInput(ctx context.Context, part int, r io.Reader, animation *visual.Animation) (answer string, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("day %d part %d: %v", puzzle.Day, part, r)
+		}
+	}()
+	input, err := puzzle.ReadInput(r)
+	if err != nil {
+		return "", err
+	}
+	if animation != nil {
+		input = puzzle.Animate(input, animation)
+	}
+	if part == 1 {
+		answer1, err := puzzle.SolvePart1(ctx, input)
+		return FormatAnswer(answer1), err
+	}
+	answer2, err := puzzle.SolvePart2(ctx, input)
+	return FormatAnswer(answer2), err
+}
+
+// Adapts solver that can't fail to the Puzzle solver signature. The solver
+// is not cancellable
+func NoError[T, A any](solve func(input T) A) func(ctx context.Context, input T) (A, error) {
+	return func(ctx context.Context, input T) (A, error) {
+		return solve(input), nil
+	}
+}
+
+// Adapts solver that doesn't take a context to the Puzzle solver signature
+func NoContext[T, A any](solve func(input T) (A, error)) func(ctx context.Context, input T) (A, error) {
+	return func(ctx context.Context, input T) (A, error) {
+		return solve(input)
+	}
+}
+
+// Formats answer of any type as string. Pointers are dereferenced and string
+// slices (like rendered screens) are joined by newlines
+func FormatAnswer(answer any) string {
+	value := reflect.ValueOf(answer)
+	if !value.IsValid() {
+		return "<nil>"
+	}
+	for value.Kind() == reflect.Pointer {
+		if value.IsNil() {
+			return "<nil>"
+		}
+		value = value.Elem()
+	}
+	if lines, ok := value.Interface().([]string); ok {
+		return strings.Join(lines, "\n")
+	}
+	return fmt.Sprint(value.Interface())
+}
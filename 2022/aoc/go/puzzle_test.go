@@ -0,0 +1,294 @@
+package aoc
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/erikzak/adventofcode/2022/aoc/progress"
+	"github.com/erikzak/adventofcode/2022/aoc/visual"
+)
+
+// Test puzzle with input parsed as its first line
+var testPuzzle = Puzzle[string, int, []string]{
+	Year: 2022, Day: 99,
+	ReadInput: func(r io.Reader) (string, error) {
+		input, err := ReadFrom(r)
+		if err != nil {
+			return "", err
+		}
+		return input.Lines[0], nil
+	},
+	SolvePart1: NoError(func(input string) int {
+		if input == "panic" {
+			panic("malformed input")
+		}
+		return len(input)
+	}),
+	SolvePart2: func(ctx context.Context, input string) ([]string, error) {
+		// Searches until stopped, for testing timeouts and progress
+		if input == "search" {
+			tracker := progress.FromContext(ctx)
+			for states := 1; ; states++ {
+				if states%progress.Batch == 0 {
+					if err := ctx.Err(); err != nil {
+						return nil, err
+					}
+					tracker.Explore(progress.Batch)
+					tracker.Best(states)
+				}
+			}
+		}
+		return []string{"##..", "..##"}, nil
+	},
+	Labels: [2]string{"Length of input", ""},
+}
+
+// Tests formatting of answers of different types
+func TestFormatAnswer(t *testing.T) {
+	n := 42
+	tests := []struct {
+		answer any
+		want   string
+	}{
+		{1651, "1651"},
+		{"CMZ", "CMZ"},
+		{&n, "42"},
+		{nil, "<nil>"},
+		{(*int)(nil), "<nil>"},
+		{[]string{"#.", ".#"}, "#.\n.#"},
+	}
+	for _, test := range tests {
+		if got := FormatAnswer(test.answer); got != test.want {
+			t.Fatalf(`FormatAnswer(%v) = %q, want %q`, test.answer, got, test.want)
+		}
+	}
+}
+
+// Tests that solver panics are returned as errors
+func TestSolveRecoversPanic(t *testing.T) {
+	result := SolvePart(context.Background(), testPuzzle, 1, &Source{Data: []byte("panic")})
+	if !strings.Contains(result.Error, "malformed input") {
+		t.Fatalf(`SolvePart().Error = %q, want malformed input`, result.Error)
+	}
+	if _, err := testPuzzle.Solve(context.Background(), 3, strings.NewReader("input")); err == nil {
+		t.Fatalf(`Solve(3) did not fail`)
+	}
+}
+
+// Tests JSON result output used by the runner
+func TestRunJSON(t *testing.T) {
+	stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+	code := run(context.Background(), testPuzzle, []string{"-format", "json", "-input", "-"}, strings.NewReader("abcd\r\n"), stdout, stderr)
+	if code != 0 {
+		t.Fatalf(`run() = %v, want 0: %s`, code, stderr)
+	}
+	decoder := json.NewDecoder(stdout)
+	for _, want := range []string{"4", "##..\n..##"} {
+		var result Result
+		if err := decoder.Decode(&result); err != nil {
+			t.Fatal(err)
+		}
+		if result.Answer != want {
+			t.Fatalf(`result.Answer = %q, want %q`, result.Answer, want)
+		}
+	}
+}
+
+// Tests CSV result output
+func TestRunCSV(t *testing.T) {
+	stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+	code := run(context.Background(), testPuzzle, []string{"-format", "csv", "-input", "-"}, strings.NewReader("abcd"), stdout, stderr)
+	if code != 0 {
+		t.Fatalf(`run() = %v, want 0: %s`, code, stderr)
+	}
+	records, err := csv.NewReader(stdout).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 3 || records[0][4] != "duration_ns" || records[1][3] != "4" || records[2][3] != "##..\n..##" {
+		t.Fatalf(`run() wrote CSV %q`, records)
+	}
+	if code := run(context.Background(), testPuzzle, []string{"-format", "xml"}, nil, stdout, stderr); code != 2 {
+		t.Fatalf(`run() with invalid format = %v, want 2`, code)
+	}
+}
+
+// Tests logged answers and exit code of failing part
+func TestRunLog(t *testing.T) {
+	stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+	code := run(context.Background(), testPuzzle, []string{"-part", "1", "-input", "-"}, strings.NewReader("panic"), stdout, stderr)
+	if code != 1 {
+		t.Fatalf(`run() = %v, want 1`, code)
+	}
+	if !strings.Contains(stderr.String(), "Length of input: error:") {
+		t.Fatalf(`run() logged %q`, stderr)
+	}
+	stderr.Reset()
+	missing := filepath.Join(t.TempDir(), "input.txt")
+	if code := run(context.Background(), testPuzzle, []string{"-input", missing}, nil, stdout, stderr); code != 1 {
+		t.Fatalf(`run() with missing input = %v, want 1`, code)
+	}
+	if !strings.Contains(stderr.String(), "Length of input: error:") {
+		t.Fatalf(`run() with missing input logged %q`, stderr)
+	}
+}
+
+// Tests that parts are stopped at the timeout, with progress logged while
+// searching. Cancelled parts are not solved
+func TestRunTimeout(t *testing.T) {
+	stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+	args := []string{"-part", "2", "-input", "-", "-timeout", "100ms", "-progress", "10ms"}
+	if code := run(context.Background(), testPuzzle, args, strings.NewReader("search"), stdout, stderr); code != 1 {
+		t.Fatalf(`run() = %v, want 1`, code)
+	}
+	if !strings.Contains(stderr.String(), "Part 2: error: timed out after 100ms") {
+		t.Fatalf(`run() logged %q, want timeout`, stderr)
+	}
+	if !strings.Contains(stderr.String(), "states explored, best") {
+		t.Fatalf(`run() logged %q, want progress`, stderr)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	result := SolvePart(ctx, testPuzzle, 1, &Source{Data: []byte("input")})
+	if result.Error != context.Canceled.Error() {
+		t.Fatalf(`SolvePart() with cancelled context = %+v, want cancelled`, result)
+	}
+	start := time.Now()
+	ctx, cancel = context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := testPuzzle.Solve(ctx, 2, strings.NewReader("search")); err != context.DeadlineExceeded {
+		t.Fatalf(`Solve() = %v, want deadline exceeded`, err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Fatalf(`Solve() took %v to time out`, elapsed)
+	}
+}
+
+// Tests that animated parts are skipped after a part is left running past
+// its timeout, since it still steps the shared animation
+func TestRunTimeoutAnimated(t *testing.T) {
+	puzzle := testPuzzle
+	release := make(chan struct{})
+	defer close(release)
+	puzzle.SolvePart1 = NoError(func(input string) int {
+		// Ignores ctx, like most solvers
+		<-release
+		return len(input)
+	})
+	puzzle.Animate = func(input string, animation *visual.Animation) string {
+		return input
+	}
+	stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+	args := []string{"-input", "-", "-timeout", "50ms", "-gif", filepath.Join(t.TempDir(), "day99.gif")}
+	if code := run(context.Background(), puzzle, args, strings.NewReader("abcd"), stdout, stderr); code != 1 {
+		t.Fatalf(`run() = %v, want 1`, code)
+	}
+	for _, want := range []string{"Length of input: error: timed out after 50ms", "Part 2: error: skipped, part 1 still running"} {
+		if !strings.Contains(stderr.String(), want) {
+			t.Fatalf(`run() logged %q, want %q`, stderr, want)
+		}
+	}
+}
+
+// Tests that profiles and traces are written per part
+func TestRunProfiles(t *testing.T) {
+	dir := t.TempDir()
+	stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+	args := []string{"-input", "-", "-cpuprofile", dir, "-memprofile", filepath.Join(dir, "mem"), "-trace", dir}
+	if code := run(context.Background(), testPuzzle, args, strings.NewReader("abcd"), stdout, stderr); code != 0 {
+		t.Fatalf(`run() = %v, want 0: %s`, code, stderr)
+	}
+	for _, name := range []string{
+		"day99-part1.cpu.pprof", "day99-part2.cpu.pprof", "mem/day99-part1.mem.pprof",
+		"mem/day99-part2.mem.pprof", "day99-part1.trace", "day99-part2.trace",
+	} {
+		info, err := os.Stat(filepath.Join(dir, name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if info.Size() == 0 {
+			t.Fatalf(`run() wrote empty %s`, name)
+		}
+	}
+}
+
+// Tests that profiling is refused while visualizing
+func TestRunProfilesAnimated(t *testing.T) {
+	stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+	args := []string{"-input", "-", "-cpuprofile", t.TempDir(), "-png", filepath.Join(t.TempDir(), "day99.png")}
+	if code := run(context.Background(), testPuzzle, args, strings.NewReader("abcd"), stdout, stderr); code != 2 {
+		t.Fatalf(`run() = %v, want 2`, code)
+	}
+	if !strings.Contains(stderr.String(), "combined with profiling") {
+		t.Fatalf(`run() logged %q, want profiles refused`, stderr)
+	}
+}
+
+// Tests gen subcommand output and seeding
+func TestRunGenerate(t *testing.T) {
+	puzzle := testPuzzle
+	puzzle.Gen = &Generator{
+		Size: 3, MaxSize: 10, SizeHelp: "letters",
+		Generate: func(rng *rand.Rand, size int, w *bufio.Writer) {
+			for i := 0; i < size; i++ {
+				w.WriteByte(byte('a' + rng.Intn(26)))
+			}
+			w.WriteByte('\n')
+		},
+	}
+	generate := func(args ...string) string {
+		stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+		if code := run(context.Background(), puzzle, append([]string{"gen"}, args...), nil, stdout, stderr); code != 0 {
+			t.Fatalf(`run(gen %v) = %v, want 0: %s`, args, code, stderr)
+		}
+		return stdout.String()
+	}
+	if got := generate(); len(got) != 4 {
+		t.Fatalf(`gen wrote %q, want 3 letters`, got)
+	}
+	if generate("-seed", "7", "-size", "10") != generate("-size", "10", "-seed", "7") {
+		t.Fatalf(`gen with same seed and size differs`)
+	}
+	if generate("-seed", "7") == generate("-seed", "8") {
+		t.Fatalf(`gen with different seeds is the same`)
+	}
+	stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+	if code := run(context.Background(), puzzle, []string{"gen", "-size", "11"}, nil, stdout, stderr); code != 1 {
+		t.Fatalf(`run(gen -size 11) = %v, want 1`, code)
+	}
+	if code := run(context.Background(), testPuzzle, []string{"gen"}, nil, stdout, stderr); code != 2 {
+		t.Fatalf(`run(gen) without generator = %v, want 2`, code)
+	}
+}
+
+// Tests that day subcommands get the remaining arguments and streams
+func TestRunCommand(t *testing.T) {
+	puzzle := testPuzzle
+	puzzle.Commands = map[string]Command{
+		"echo": func(args []string, stdin io.Reader, stdout io.Writer, stderr io.Writer) int {
+			fmt.Fprint(stdout, strings.Join(args, " "))
+			return 3
+		},
+	}
+	stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+	if code := run(context.Background(), puzzle, []string{"echo", "-input", "-"}, strings.NewReader(""), stdout, stderr); code != 3 {
+		t.Fatalf(`run() = %v, want 3: %s`, code, stderr)
+	}
+	if stdout.String() != "-input -" {
+		t.Fatalf(`echo output = %q, want "-input -"`, stdout)
+	}
+	if puzzle.Command("missing") != nil {
+		t.Fatal(`Command("missing") is not nil`)
+	}
+}
@@ -0,0 +1,140 @@
+package aoc
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"runtime"
+	"strconv"
+	"time"
+
+	"github.com/erikzak/adventofcode/2022/aoc/visual"
+)
+
+// Answer, timing and allocations of a solved puzzle part
+type Result struct {
+	Year     int           `json:"year"`
+	Day      int           `json:"day"`
+	Part     int           `json:"part"`
+	Answer   string        `json:"answer"`
+	Duration time.Duration `json:"duration"`
+	Allocs   uint64        `json:"allocs"` // Heap allocations, input parsing included
+	Error    string        `json:"error,omitempty"`
+}
+
+// Start of the error of parts stopped at their time limit
+const TimeoutError = "timed out"
+
+// Solves part and returns result with answer, wall time and allocations.
+// Timing starts after the input is read, but includes parsing
+func SolvePart(ctx context.Context, solver Solver, part int, source *Source) Result {
+	return measure(solver, part, func() (string, error) {
+		return solver.Solve(ctx, part, source.Reader())
+	})
+}
+
+// Solves part like SolvePart, playing the simulation on animation. Timing
+// includes the animation
+func SolvePartAnimated(ctx context.Context, solver Solver, part int, source *Source, animation *visual.Animation) Result {
+	return measure(solver, part, func() (string, error) {
+		return solver.SolveAnimated(ctx, part, source.Reader(), animation)
+	})
+}
+
+// Returns result of solve, with wall time and allocations
+func measure(solver Solver, part int, solve func() (string, error)) Result {
+	year, day := solver.Date()
+	result := Result{Year: year, Day: day, Part: part}
+	var before, after runtime.MemStats
+	runtime.ReadMemStats(&before)
+	start := time.Now()
+	answer, err := solve()
+	result.Duration = time.Since(start)
+	runtime.ReadMemStats(&after)
+	result.Allocs = after.Mallocs - before.Mallocs
+	result.Answer = answer
+	if err != nil {
+		result.Error = err.Error()
+	}
+	return result
+}
+
+// Result output formats
+const (
+	FormatText = "text"
+	FormatJSON = "json"
+	FormatCSV  = "csv"
+)
+
+// Checks that format is one of the result output formats
+func ValidFormat(format string) error {
+	switch format {
+	case FormatText, FormatJSON, FormatCSV:
+		return nil
+	}
+	return fmt.Errorf("invalid format %q, want %s, %s or %s", format, FormatText, FormatJSON, FormatCSV)
+}
+
+// Writes results in a machine-readable format
+type ResultEncoder interface {
+	Encode(result Result) error
+	Flush() error
+}
+
+// Returns encoder writing results as JSON lines or CSV rows
+func NewResultEncoder(format string, w io.Writer) (ResultEncoder, error) {
+	switch format {
+	case FormatJSON:
+		return jsonEncoder{json.NewEncoder(w)}, nil
+	case FormatCSV:
+		return &csvEncoder{writer: csv.NewWriter(w)}, nil
+	}
+	return nil, fmt.Errorf("no encoder for format %q", format)
+}
+
+// Writes results as JSON lines
+type jsonEncoder struct {
+	encoder *json.Encoder
+}
+
+func (e jsonEncoder) Encode(result Result) error {
+	return e.encoder.Encode(result)
+}
+
+func (e jsonEncoder) Flush() error {
+	return nil
+}
+
+// Column headers of CSV output
+var csvHeader = []string{"year", "day", "part", "answer", "duration_ns", "allocs", "error"}
+
+// Writes results as CSV rows, headers first. Durations are in nanoseconds
+type csvEncoder struct {
+	writer       *csv.Writer
+	wroteHeaders bool
+}
+
+func (e *csvEncoder) Encode(result Result) error {
+	if !e.wroteHeaders {
+		if err := e.writer.Write(csvHeader); err != nil {
+			return err
+		}
+		e.wroteHeaders = true
+	}
+	return e.writer.Write([]string{
+		strconv.Itoa(result.Year),
+		strconv.Itoa(result.Day),
+		strconv.Itoa(result.Part),
+		result.Answer,
+		strconv.FormatInt(int64(result.Duration), 10),
+		strconv.FormatUint(result.Allocs, 10),
+		result.Error,
+	})
+}
+
+func (e *csvEncoder) Flush() error {
+	e.writer.Flush()
+	return e.writer.Error()
+}
@@ -0,0 +1,39 @@
+package runner
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+)
+
+// Name of generated known answers test in day modules
+const AnswersTestFile = "answers_test.go"
+
+// Generated known answers test, checking the module puzzle against the ledger
+const answersTest = `// Code generated by aoc answers --gen-tests. DO NOT EDIT.
+
+package main
+
+import (
+	"testing"
+
+	"github.com/erikzak/adventofcode/2022/aoc/aoctest"
+)
+
+// Tests puzzle answers against confirmed answers in the answer ledger
+func TestKnownAnswers(t *testing.T) {
+	aoctest.KnownAnswers(t, puzzle)
+}
+`
+
+// Writes known answers test to the module, unless it already has one.
+// Reports whether the test was written
+func WriteAnswersTest(module Module) (bool, error) {
+	path := filepath.Join(module.Dir, AnswersTestFile)
+	if _, err := os.Stat(path); err == nil {
+		return false, nil
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return false, err
+	}
+	return true, os.WriteFile(path, []byte(answersTest), 0o644)
+}
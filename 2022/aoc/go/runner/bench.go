@@ -0,0 +1,202 @@
+package runner
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+)
+
+// Result of a single benchmark, as reported by go test -benchmem
+type Benchmark struct {
+	Name        string  `json:"name"` // Without GOMAXPROCS suffix
+	Iterations  int     `json:"iterations"`
+	NsPerOp     float64 `json:"ns_per_op"`
+	BytesPerOp  float64 `json:"bytes_per_op"`
+	AllocsPerOp float64 `json:"allocs_per_op"`
+}
+
+// Parses benchmark result lines from go test output. Other lines are skipped
+func ParseBenchmarks(r io.Reader) ([]Benchmark, error) {
+	benchmarks := []Benchmark{}
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 4 || !strings.HasPrefix(fields[0], "Benchmark") {
+			continue
+		}
+		iterations, err := strconv.Atoi(fields[1])
+		if err != nil {
+			continue
+		}
+		benchmark := Benchmark{Name: trimProcs(fields[0]), Iterations: iterations}
+		// Value and unit pairs, like "1234 ns/op"
+		for i := 2; i+1 < len(fields); i += 2 {
+			value, err := strconv.ParseFloat(fields[i], 64)
+			if err != nil {
+				return nil, fmt.Errorf("benchmark %s: invalid value %q", fields[0], fields[i])
+			}
+			switch fields[i+1] {
+			case "ns/op":
+				benchmark.NsPerOp = value
+			case "B/op":
+				benchmark.BytesPerOp = value
+			case "allocs/op":
+				benchmark.AllocsPerOp = value
+			}
+		}
+		benchmarks = append(benchmarks, benchmark)
+	}
+	return benchmarks, scanner.Err()
+}
+
+// Strips GOMAXPROCS suffix from benchmark name, e.g. BenchmarkReadInput-8
+func trimProcs(name string) string {
+	if i := strings.LastIndex(name, "-"); i > 0 {
+		if _, err := strconv.Atoi(name[i+1:]); err == nil {
+			return name[:i]
+		}
+	}
+	return name
+}
+
+// Runs the module benchmarks with memory statistics. Benchtime is passed on
+// to go test if set
+func (runner *Runner) Bench(ctx context.Context, module Module, benchtime string) ([]Benchmark, error) {
+	args := []string{"test", "-run", "^$", "-bench", ".", "-benchmem"}
+	if benchtime != "" {
+		args = append(args, "-benchtime", benchtime)
+	}
+	cmd := exec.CommandContext(ctx, "go", args...)
+	cmd.Dir = module.Dir
+	output, err := cmd.CombinedOutput()
+	benchmarks, parseErr := ParseBenchmarks(strings.NewReader(string(output)))
+	if parseErr != nil {
+		return nil, parseErr
+	}
+	if err != nil {
+		return benchmarks, fmt.Errorf("benchmarking day %d: %v\n%s", module.Day, err, output)
+	}
+	return benchmarks, nil
+}
+
+// Benchmark results of a year, by day
+type Baseline struct {
+	Year int                 `json:"year"`
+	Days map[int][]Benchmark `json:"days"`
+}
+
+// Loads baseline from path. A missing file gives an empty baseline
+func LoadBaseline(path string) (*Baseline, error) {
+	baseline := Baseline{Days: map[int][]Benchmark{}}
+	content, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return &baseline, nil
+	} else if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(content, &baseline); err != nil {
+		return nil, fmt.Errorf("%s: %v", path, err)
+	}
+	if baseline.Days == nil {
+		baseline.Days = map[int][]Benchmark{}
+	}
+	return &baseline, nil
+}
+
+// Writes baseline to path as indented JSON
+func (baseline *Baseline) Save(path string) error {
+	content, err := json.MarshalIndent(baseline, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(content, '\n'), 0o644)
+}
+
+// Change of a benchmark compared to baseline. Percentages are relative to
+// the baseline, positive when slower or allocating more
+type BenchDelta struct {
+	Day       int
+	Current   Benchmark
+	Baseline  *Benchmark // Nil if not in baseline
+	NsPct     float64
+	BytesPct  float64
+	AllocsPct float64
+	Regressed bool // Any delta above threshold
+}
+
+// Compares current benchmark results with baseline. Regressions are deltas
+// above threshold percent
+func Compare(baseline *Baseline, current map[int][]Benchmark, threshold float64) []BenchDelta {
+	days := make([]int, 0, len(current))
+	for day := range current {
+		days = append(days, day)
+	}
+	sort.Ints(days)
+	deltas := []BenchDelta{}
+	for _, day := range days {
+		previous := map[string]Benchmark{}
+		for _, benchmark := range baseline.Days[day] {
+			previous[benchmark.Name] = benchmark
+		}
+		for _, benchmark := range current[day] {
+			delta := BenchDelta{Day: day, Current: benchmark}
+			if old, ok := previous[benchmark.Name]; ok {
+				delta.Baseline = &old
+				delta.NsPct = percentChange(old.NsPerOp, benchmark.NsPerOp)
+				delta.BytesPct = percentChange(old.BytesPerOp, benchmark.BytesPerOp)
+				delta.AllocsPct = percentChange(old.AllocsPerOp, benchmark.AllocsPerOp)
+				delta.Regressed = delta.NsPct > threshold || delta.BytesPct > threshold || delta.AllocsPct > threshold
+			}
+			deltas = append(deltas, delta)
+		}
+	}
+	return deltas
+}
+
+// Returns change from old to new in percent of old
+func percentChange(old float64, new float64) float64 {
+	if old == 0 {
+		if new == 0 {
+			return 0
+		}
+		return 100
+	}
+	return (new - old) / old * 100
+}
+
+// Writes table of benchmark results with deltas against baseline.
+// Regressions are marked with an exclamation mark
+func WriteBenchReport(w io.Writer, deltas []BenchDelta) error {
+	table := tabwriter.NewWriter(w, 0, 4, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(table, "Day\tBenchmark\tns/op\tΔ\tB/op\tΔ\tallocs/op\tΔ\t\t")
+	for _, delta := range deltas {
+		benchmark := delta.Current
+		mark := ""
+		if delta.Regressed {
+			mark = "!"
+		}
+		fmt.Fprintf(table, "%d\t%s\t%.0f\t%s\t%.0f\t%s\t%.0f\t%s\t%s\t\n",
+			delta.Day, strings.TrimPrefix(benchmark.Name, "Benchmark"),
+			benchmark.NsPerOp, formatDelta(delta, delta.NsPct),
+			benchmark.BytesPerOp, formatDelta(delta, delta.BytesPct),
+			benchmark.AllocsPerOp, formatDelta(delta, delta.AllocsPct), mark)
+	}
+	return table.Flush()
+}
+
+// Formats percentage change, or "new" for benchmarks not in baseline
+func formatDelta(delta BenchDelta, pct float64) string {
+	if delta.Baseline == nil {
+		return "new"
+	}
+	return fmt.Sprintf("%+.1f%%", pct)
+}
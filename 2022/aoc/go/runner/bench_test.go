@@ -0,0 +1,80 @@
+package runner
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// Tests parsing of go test benchmark output
+func TestParseBenchmarks(t *testing.T) {
+	output := `goos: linux
+goarch: amd64
+pkg: github.com/erikzak/adventofcode/2022/21
+BenchmarkSolvePuzzle-8   	    1268	    935713 ns/op	  402544 B/op	    5919 allocs/op
+BenchmarkReadInput     	    2436	    482871.5 ns/op	  206480 B/op	    4434 allocs/op
+PASS
+ok  	github.com/erikzak/adventofcode/2022/21	3.116s
+`
+	benchmarks, err := ParseBenchmarks(strings.NewReader(output))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Benchmark{
+		{Name: "BenchmarkSolvePuzzle", Iterations: 1268, NsPerOp: 935713, BytesPerOp: 402544, AllocsPerOp: 5919},
+		{Name: "BenchmarkReadInput", Iterations: 2436, NsPerOp: 482871.5, BytesPerOp: 206480, AllocsPerOp: 4434},
+	}
+	if len(benchmarks) != len(want) || benchmarks[0] != want[0] || benchmarks[1] != want[1] {
+		t.Fatalf(`ParseBenchmarks() = %+v, want %+v`, benchmarks, want)
+	}
+}
+
+// Tests comparing benchmarks against a saved baseline
+func TestCompare(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "bench.json")
+	baseline, err := LoadBaseline(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	baseline.Year = 2022
+	baseline.Days[21] = []Benchmark{
+		{Name: "BenchmarkSolvePuzzle", NsPerOp: 1000, BytesPerOp: 400, AllocsPerOp: 10},
+		{Name: "BenchmarkReadInput", NsPerOp: 500, BytesPerOp: 200, AllocsPerOp: 5},
+	}
+	if err := baseline.Save(path); err != nil {
+		t.Fatal(err)
+	}
+	if baseline, err = LoadBaseline(path); err != nil {
+		t.Fatal(err)
+	}
+
+	current := map[int][]Benchmark{21: {
+		{Name: "BenchmarkSolvePuzzle", NsPerOp: 1050, BytesPerOp: 400, AllocsPerOp: 10},
+		{Name: "BenchmarkReadInput", NsPerOp: 500, BytesPerOp: 200, AllocsPerOp: 6},
+		{Name: "BenchmarkSolvePart1", NsPerOp: 100},
+	}}
+	deltas := Compare(baseline, current, 10)
+	if len(deltas) != 3 {
+		t.Fatalf(`Compare() = %+v, want 3 deltas`, deltas)
+	}
+	if deltas[0].Regressed || deltas[0].NsPct != 5 {
+		t.Fatalf(`5%% slower delta = %+v, want no regression`, deltas[0])
+	}
+	if !deltas[1].Regressed || deltas[1].AllocsPct != 20 {
+		t.Fatalf(`20%% more allocs delta = %+v, want regression`, deltas[1])
+	}
+	if deltas[2].Baseline != nil || deltas[2].Regressed {
+		t.Fatalf(`new benchmark delta = %+v`, deltas[2])
+	}
+
+	report := &bytes.Buffer{}
+	if err := WriteBenchReport(report, deltas); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"+5.0%", "+20.0%", "new", "!"} {
+		if !strings.Contains(report.String(), want) {
+			t.Fatalf("WriteBenchReport() missing %q:\n%s", want, report)
+		}
+	}
+}
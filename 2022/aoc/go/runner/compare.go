@@ -0,0 +1,139 @@
+package runner
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/erikzak/adventofcode/2022/aoc"
+	"github.com/erikzak/adventofcode/2022/aoc/ledger"
+)
+
+// Puzzle input of a team member, like inputs/alice.txt, with its own answer
+// ledger next to it, like inputs/alice.json
+type NamedInput struct {
+	Name   string
+	Path   string
+	Ledger *ledger.Ledger // Empty if the input has no ledger file yet
+}
+
+// Finds the .txt inputs in dir, sorted by name, and loads their ledgers
+func FindInputs(dir string, year int) ([]NamedInput, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.txt"))
+	if err != nil {
+		return nil, err
+	}
+	if len(paths) == 0 {
+		return nil, fmt.Errorf("no .txt inputs in %s", dir)
+	}
+	sort.Strings(paths)
+	inputs := []NamedInput{}
+	for _, path := range paths {
+		name := strings.TrimSuffix(filepath.Base(path), ".txt")
+		answers, err := ledger.Load(filepath.Join(dir, name+".json"))
+		if err != nil {
+			return nil, err
+		}
+		if answers.Year == 0 {
+			answers.Year = year
+		} else if answers.Year != year {
+			return nil, fmt.Errorf("%s: ledger is for %d, not %d", answers.Path, answers.Year, year)
+		}
+		inputs = append(inputs, NamedInput{Name: name, Path: path, Ledger: answers})
+	}
+	return inputs, nil
+}
+
+// Results of a day solved with one of the named inputs
+type Comparison struct {
+	Input   NamedInput
+	Results []aoc.Result
+}
+
+// Returns true if a part crashed, or disagrees with the input's ledger
+func (comparison Comparison) Failed() bool {
+	for _, result := range comparison.Results {
+		if result.Error != "" || comparison.Input.Ledger.Check(result) == ledger.Wrong {
+			return true
+		}
+	}
+	return false
+}
+
+// Writes matrix of answers and wall time, a row per input and a column pair
+// per part. Crashes are shown as errors, and answers are marked against each
+// input's ledger, with the confirmed answer after wrong ones
+func WriteComparison(w io.Writer, comparisons []Comparison) error {
+	parts := []int{}
+	for _, comparison := range comparisons {
+		for _, result := range comparison.Results {
+			if !containsInt(parts, result.Part) {
+				parts = append(parts, result.Part)
+			}
+		}
+	}
+	sort.Ints(parts)
+	table := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprint(table, "Input")
+	for _, part := range parts {
+		fmt.Fprintf(table, "\tPart %d\tTime", part)
+	}
+	fmt.Fprintln(table)
+	for _, comparison := range comparisons {
+		fmt.Fprint(table, comparison.Input.Name)
+		for _, part := range parts {
+			result, ok := findPart(comparison.Results, part)
+			if !ok {
+				fmt.Fprint(table, "\t-\t")
+				continue
+			}
+			fmt.Fprintf(table, "\t%s\t%v", comparisonCell(result, comparison.Input.Ledger), roundDuration(result.Duration))
+		}
+		fmt.Fprintln(table)
+	}
+	return table.Flush()
+}
+
+// Returns answer of result marked against the ledger, or its error
+func comparisonCell(result aoc.Result, answers *ledger.Ledger) string {
+	answer := result.Answer
+	if result.Error != "" {
+		answer = "error: " + firstLine(result.Error)
+	} else if strings.Contains(answer, "\n") {
+		answer = fmt.Sprintf("(%d lines)", strings.Count(answer, "\n")+1)
+	}
+	switch answers.Check(result) {
+	case ledger.Correct:
+		return answer + " " + ledger.Correct.String()
+	case ledger.Wrong:
+		want, _ := answers.Answer(result.Day, result.Part)
+		if strings.Contains(want, "\n") {
+			return answer + " " + ledger.Wrong.String()
+		}
+		return fmt.Sprintf("%s %v want %s", answer, ledger.Wrong, want)
+	}
+	return answer
+}
+
+// Returns result of part
+func findPart(results []aoc.Result, part int) (aoc.Result, bool) {
+	for _, result := range results {
+		if result.Part == part {
+			return result, true
+		}
+	}
+	return aoc.Result{}, false
+}
+
+// Checks if values contain value
+func containsInt(values []int, value int) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,82 @@
+package runner
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/erikzak/adventofcode/2022/aoc"
+)
+
+// Tests finding named inputs with their ledgers
+func TestFindInputs(t *testing.T) {
+	dir := t.TempDir()
+	for name, content := range map[string]string{
+		"bob.txt":    "2\n",
+		"alice.txt":  "1\n",
+		"alice.json": `{"year":2022,"answers":{"1":{"1":{"answer":"24000"}}}}`,
+		"notes.md":   "not an input",
+	} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	inputs, err := FindInputs(dir, 2022)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(inputs) != 2 || inputs[0].Name != "alice" || inputs[1].Name != "bob" {
+		t.Fatalf(`FindInputs() = %v, want alice and bob`, inputs)
+	}
+	if answer, ok := inputs[0].Ledger.Answer(1, 1); !ok || answer != "24000" {
+		t.Fatalf(`alice ledger answer = %q, want 24000`, answer)
+	}
+	if inputs[1].Ledger.Path != filepath.Join(dir, "bob.json") || inputs[1].Ledger.Year != 2022 {
+		t.Fatalf(`bob ledger = %+v, want empty ledger at bob.json`, inputs[1].Ledger)
+	}
+	if _, err := FindInputs(dir, 2023); err == nil {
+		t.Fatal(`FindInputs() with ledger of another year did not fail`)
+	}
+	if _, err := FindInputs(t.TempDir(), 2022); err == nil {
+		t.Fatal(`FindInputs() of empty directory did not fail`)
+	}
+}
+
+// Tests comparison matrix marks against each input's ledger
+func TestWriteComparison(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"alice", "bob", "carol"} {
+		if err := os.WriteFile(filepath.Join(dir, name+".txt"), nil, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	inputs, err := FindInputs(dir, 2022)
+	if err != nil {
+		t.Fatal(err)
+	}
+	inputs[0].Ledger.Confirm(1, 1, "24000")
+	inputs[1].Ledger.Confirm(1, 1, "71934")
+	comparisons := []Comparison{
+		{inputs[0], []aoc.Result{{Day: 1, Part: 1, Answer: "24000"}, {Day: 1, Part: 2, Answer: "45000"}}},
+		{inputs[1], []aoc.Result{{Day: 1, Part: 1, Answer: "71924"}, {Day: 1, Part: 2, Answer: "209603"}}},
+		{inputs[2], []aoc.Result{{Day: 1, Part: 1, Error: "stdin:3:1: invalid number"}, {Day: 1, Part: 2, Answer: "1"}}},
+	}
+	matrix := &bytes.Buffer{}
+	if err := WriteComparison(matrix, comparisons); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(matrix.String(), "\n")
+	wants := []string{"Part 2", "24000 ✓", "71924 ✗ want 71934", "error: stdin:3:1: invalid number"}
+	for i, want := range wants {
+		if !strings.Contains(lines[i], want) {
+			t.Fatalf("WriteComparison() line %d missing %q:\n%s", i, want, matrix)
+		}
+	}
+	for i, want := range []bool{false, true, true} {
+		if got := comparisons[i].Failed(); got != want {
+			t.Fatalf(`%s Failed() = %v, want %v`, comparisons[i].Input.Name, got, want)
+		}
+	}
+}
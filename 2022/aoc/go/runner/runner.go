@@ -0,0 +1,383 @@
+// Discovers day modules and dispatches to them. Every day module is its own
+// main package, so days are built and run as separate processes that report
+// results back as JSON lines.
+package runner
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/erikzak/adventofcode/2022/aoc"
+)
+
+// Module path of the shared aoc module
+const AocModule = "github.com/erikzak/adventofcode/2022/aoc"
+
+// A day module registered with the runner
+type Module struct {
+	Year int
+	Day  int
+	Dir  string // Module directory, e.g. 2022/16/go
+}
+
+// Walks up from start until a directory containing the given year is found.
+// Returns the repository root
+func FindRoot(start string, year int) (string, error) {
+	dir, err := filepath.Abs(start)
+	if err != nil {
+		return "", err
+	}
+	for {
+		info, err := os.Stat(filepath.Join(dir, strconv.Itoa(year)))
+		if err == nil && info.IsDir() {
+			return dir, nil
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", fmt.Errorf("no %d directory found above %s", year, start)
+		}
+		dir = parent
+	}
+}
+
+// Returns directory of the given day, e.g. 2022/05. Day inputs are kept here
+func DayDir(root string, year int, day int) string {
+	return filepath.Join(root, strconv.Itoa(year), fmt.Sprintf("%02d", day))
+}
+
+// Finds registered day modules for the given year, sorted by day. Modules
+// are registered by passing their puzzle to aoc.Main
+func Discover(root string, year int) ([]Module, error) {
+	yearDir := filepath.Join(root, strconv.Itoa(year))
+	entries, err := os.ReadDir(yearDir)
+	if err != nil {
+		return nil, err
+	}
+	modules := []Module{}
+	for _, entry := range entries {
+		day, err := strconv.Atoi(entry.Name())
+		if err != nil || !entry.IsDir() {
+			continue
+		}
+		dir := filepath.Join(yearDir, entry.Name(), "go")
+		registered, err := isRegistered(dir)
+		if err != nil {
+			return nil, err
+		}
+		if registered {
+			modules = append(modules, Module{Year: year, Day: day, Dir: dir})
+		}
+	}
+	sort.Slice(modules, func(i, j int) bool {
+		return modules[i].Day < modules[j].Day
+	})
+	return modules, nil
+}
+
+// Checks if module in dir requires the aoc module and calls aoc.Main
+func isRegistered(dir string) (bool, error) {
+	goMod, err := os.ReadFile(filepath.Join(dir, "go.mod"))
+	if os.IsNotExist(err) {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+	if !requiresAoc(goMod) {
+		return false, nil
+	}
+	sources, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		return false, err
+	}
+	for _, source := range sources {
+		if strings.HasSuffix(source, "_test.go") {
+			continue
+		}
+		calls, err := callsMain(source)
+		if err != nil {
+			return false, err
+		}
+		if calls {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// Checks if Go source file calls Main of the aoc module, through whatever
+// name it is imported as. Mentions in comments and strings don't count.
+// Files with syntax errors are checked as far as they parse, so a day being
+// edited doesn't stop discovery of the others
+func callsMain(path string) (bool, error) {
+	file, err := parser.ParseFile(token.NewFileSet(), path, nil, parser.SkipObjectResolution)
+	if file == nil {
+		return false, err
+	}
+	name := ""
+	for _, spec := range file.Imports {
+		if importPath, err := strconv.Unquote(spec.Path.Value); err != nil || importPath != AocModule {
+			continue
+		}
+		name = "aoc"
+		if spec.Name != nil {
+			name = spec.Name.Name
+		}
+	}
+	if name == "" || name == "_" {
+		return false, nil
+	}
+	calls := false
+	ast.Inspect(file, func(node ast.Node) bool {
+		if call, ok := node.(*ast.CallExpr); ok && !calls {
+			switch fun := call.Fun.(type) {
+			case *ast.SelectorExpr:
+				pkg, ok := fun.X.(*ast.Ident)
+				calls = ok && pkg.Name == name && fun.Sel.Name == "Main"
+			case *ast.Ident:
+				// Dot import
+				calls = name == "." && fun.Name == "Main"
+			}
+		}
+		return !calls
+	})
+	return calls, nil
+}
+
+// Checks if go.mod content requires the aoc module
+func requiresAoc(goMod []byte) bool {
+	inBlock := false
+	for _, line := range strings.Split(string(goMod), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		if inBlock {
+			if fields[0] == ")" {
+				inBlock = false
+			} else if fields[0] == AocModule {
+				return true
+			}
+			continue
+		}
+		if fields[0] != "require" || len(fields) < 2 {
+			continue
+		}
+		if fields[1] == "(" {
+			inBlock = true
+		} else if fields[1] == AocModule {
+			return true
+		}
+	}
+	return false
+}
+
+// Parses day selection like "16", "1-5" or "1,3,10-12". An empty spec selects
+// all days. Returns sorted slice of unique days
+func ParseDays(spec string) ([]int, error) {
+	if strings.TrimSpace(spec) == "" {
+		return nil, nil
+	}
+	selected := map[int]struct{}{}
+	for _, part := range strings.Split(spec, ",") {
+		part = strings.TrimSpace(part)
+		first, last, isRange := strings.Cut(part, "-")
+		from, err := parseDay(first)
+		if err != nil {
+			return nil, err
+		}
+		to := from
+		if isRange {
+			to, err = parseDay(last)
+			if err != nil {
+				return nil, err
+			}
+		}
+		if to < from {
+			return nil, fmt.Errorf("invalid day range: %s", part)
+		}
+		for day := from; day <= to; day++ {
+			selected[day] = struct{}{}
+		}
+	}
+	days := make([]int, 0, len(selected))
+	for day := range selected {
+		days = append(days, day)
+	}
+	sort.Ints(days)
+	return days, nil
+}
+
+// Parses a single day number between 1 and 25
+func parseDay(s string) (int, error) {
+	day, err := strconv.Atoi(strings.TrimSpace(s))
+	if err != nil || day < 1 || day > 25 {
+		return 0, fmt.Errorf("invalid day: %q", s)
+	}
+	return day, nil
+}
+
+// Filters modules by selected days. No selection keeps all modules
+func Select(modules []Module, days []int) ([]Module, error) {
+	if len(days) == 0 {
+		return modules, nil
+	}
+	byDay := map[int]Module{}
+	for _, module := range modules {
+		byDay[module.Day] = module
+	}
+	selected := []Module{}
+	for _, day := range days {
+		module, ok := byDay[day]
+		if !ok {
+			return nil, fmt.Errorf("day %d is not registered with the runner", day)
+		}
+		selected = append(selected, module)
+	}
+	return selected, nil
+}
+
+// Options passed on to day modules
+type Options struct {
+	Part  int       // 0 for both parts
+	Input string    // Input file, empty for the module default, "-" for Stdin
+	Stdin io.Reader // Passed on to the day module
+	// Play the simulation on the terminal. Days open the terminal themselves,
+	// so results still come back as JSON lines
+	Visualize bool
+	Timeout   time.Duration // Time limit for each part, 0 for none
+	// Interval of search progress logged by the day module to Stderr, 0 for
+	// none
+	Progress time.Duration
+	// Directories receiving CPU and heap profiles and execution traces of
+	// each part. Empty directories are not profiled
+	Profiles aoc.Profiles
+}
+
+// Builds and runs day modules. Built binaries are kept in BuildDir. Safe for
+// concurrent use
+type Runner struct {
+	BuildDir string
+	Stderr   io.Writer // Receives day module stderr, may be nil
+	mu       sync.Mutex
+	binaries map[string]string
+}
+
+func NewRunner(buildDir string, stderr io.Writer) *Runner {
+	return &Runner{BuildDir: buildDir, Stderr: stderr, binaries: map[string]string{}}
+}
+
+// Builds the day module binary, if not already built. Returns binary path
+func (runner *Runner) Build(ctx context.Context, module Module) (string, error) {
+	// Builds are serialized, so a module is only built once
+	runner.mu.Lock()
+	defer runner.mu.Unlock()
+	if binary, ok := runner.binaries[module.Dir]; ok {
+		return binary, nil
+	}
+	binary := filepath.Join(runner.BuildDir, fmt.Sprintf("day%d-%02d", module.Year, module.Day))
+	cmd := exec.CommandContext(ctx, "go", "build", "-o", binary, ".")
+	cmd.Dir = module.Dir
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return "", fmt.Errorf("building day %d: %v\n%s", module.Day, err, output)
+	}
+	runner.binaries[module.Dir] = binary
+	return binary, nil
+}
+
+// Builds and runs the day module, returning results for the solved parts
+func (runner *Runner) Run(ctx context.Context, module Module, options Options) ([]aoc.Result, error) {
+	binary, err := runner.Build(ctx, module)
+	if err != nil {
+		return nil, err
+	}
+	args := []string{"-format", aoc.FormatJSON, "-part", strconv.Itoa(options.Part)}
+	if options.Input == aoc.StdinPath {
+		args = append(args, "-input", aoc.StdinPath)
+	} else if options.Input != "" {
+		input, err := filepath.Abs(options.Input)
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, "-input", input)
+	}
+	if options.Visualize {
+		args = append(args, "-visualize")
+	}
+	if options.Timeout > 0 {
+		args = append(args, "-timeout", options.Timeout.String())
+	}
+	if options.Progress > 0 {
+		args = append(args, "-progress", options.Progress.String())
+	}
+	profileFlags := []struct {
+		flag string
+		dir  string
+	}{
+		{"-cpuprofile", options.Profiles.CPU},
+		{"-memprofile", options.Profiles.Memory},
+		{"-trace", options.Profiles.Trace},
+	}
+	for _, profile := range profileFlags {
+		if profile.dir == "" {
+			continue
+		}
+		// Day modules run in their own directory
+		dir, err := filepath.Abs(profile.dir)
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, profile.flag, dir)
+	}
+	cmd := exec.CommandContext(ctx, binary, args...)
+	cmd.Dir = module.Dir
+	cmd.Stdin = options.Stdin
+	stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+	if runner.Stderr != nil {
+		cmd.Stderr = io.MultiWriter(stderr, runner.Stderr)
+	}
+	runErr := cmd.Run()
+	results, err := DecodeResults(stdout)
+	if err != nil {
+		return nil, err
+	}
+	// Day modules exit non-zero when a part fails, which is reported in results
+	if runErr != nil && len(results) == 0 {
+		return nil, fmt.Errorf("running day %d: %v\n%s", module.Day, runErr, stderr)
+	}
+	return results, nil
+}
+
+// Decodes results written as JSON lines by day modules
+func DecodeResults(r io.Reader) ([]aoc.Result, error) {
+	results := []aoc.Result{}
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		var result aoc.Result
+		if err := json.Unmarshal(line, &result); err != nil {
+			return nil, fmt.Errorf("decoding result %q: %v", line, err)
+		}
+		results = append(results, result)
+	}
+	return results, scanner.Err()
+}
@@ -0,0 +1,160 @@
+package runner
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/erikzak/adventofcode/2022/aoc/ledger"
+)
+
+// Tests parsing of day selections
+func TestParseDays(t *testing.T) {
+	tests := []struct {
+		spec string
+		want []int
+	}{
+		{"", nil},
+		{"16", []int{16}},
+		{"1-3", []int{1, 2, 3}},
+		{"10,1-2,2", []int{1, 2, 10}},
+	}
+	for _, test := range tests {
+		days, err := ParseDays(test.spec)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(days, test.want) {
+			t.Fatalf(`ParseDays(%q) = %v, want %v`, test.spec, days, test.want)
+		}
+	}
+	for _, spec := range []string{"0", "26", "5-3", "x", "1-"} {
+		if _, err := ParseDays(spec); err == nil {
+			t.Fatalf(`ParseDays(%q) did not fail`, spec)
+		}
+	}
+}
+
+// Writes go.mod and main source for a fake day module
+func writeModule(t *testing.T, root string, day string, goMod string, main string) {
+	dir := filepath.Join(root, "2022", day, "go")
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "go.mod"), []byte(goMod), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "day.go"), []byte(main), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// Tests that only modules passing their puzzle to aoc.Main are discovered
+func TestDiscover(t *testing.T) {
+	root := t.TempDir()
+	registered := "package main\n\nimport \"github.com/erikzak/adventofcode/2022/aoc\"\n\n" +
+		"func main() {\n\taoc.Main(puzzle)\n}\n"
+	renamed := "package main\n\nimport runner \"github.com/erikzak/adventofcode/2022/aoc\"\n\n" +
+		"func main() {\n\trunner.Main(puzzle)\n}\n"
+	// Mentions aoc.Main( without calling it
+	commented := "package main\n\n// Not yet passed to aoc.Main(puzzle)\nfunc main() {}\n"
+	writeModule(t, root, "01", "module github.com/erikzak/adventofcode/2022/1\n\ngo 1.19\n", "package main\n")
+	writeModule(t, root, "03", "module github.com/erikzak/adventofcode/2022/3\n\ngo 1.19\n\n"+
+		"require github.com/erikzak/adventofcode/2022/aoc v0.0.0\n", "package main\n")
+	writeModule(t, root, "16", "module github.com/erikzak/adventofcode/2022/16\n\ngo 1.19\n\n"+
+		"require github.com/erikzak/adventofcode/2022/aoc v0.0.0\n", registered)
+	writeModule(t, root, "02", "module github.com/erikzak/adventofcode/2022/2\n\ngo 1.19\n\n"+
+		"require (\n\tgithub.com/erikzak/adventofcode/2022/aoc v0.0.0\n)\n", registered)
+	writeModule(t, root, "04", "module github.com/erikzak/adventofcode/2022/4\n\ngo 1.19\n\n"+
+		"require github.com/erikzak/adventofcode/2022/aoc v0.0.0\n", renamed)
+	writeModule(t, root, "05", "module github.com/erikzak/adventofcode/2022/5\n\ngo 1.19\n\n"+
+		"require github.com/erikzak/adventofcode/2022/aoc v0.0.0\n", commented)
+
+	modules, err := Discover(root, 2022)
+	if err != nil {
+		t.Fatal(err)
+	}
+	days := []int{}
+	for _, module := range modules {
+		days = append(days, module.Day)
+	}
+	if !reflect.DeepEqual(days, []int{2, 4, 16}) {
+		t.Fatalf(`Discover() days = %v, want [2 4 16]`, days)
+	}
+	if _, err := Select(modules, []int{1}); err == nil {
+		t.Fatalf(`Select() of unregistered day did not fail`)
+	}
+
+	found, err := FindRoot(filepath.Join(root, "2022", "16", "go"), 2022)
+	if err != nil || found != root {
+		t.Fatalf(`FindRoot() = %v, %v, want %v`, found, err, root)
+	}
+}
+
+// Tests decoding of day module results and summary table
+func TestSummary(t *testing.T) {
+	output := `{"year":2022,"day":10,"part":1,"answer":"13140","duration":1500000}
+{"year":2022,"day":10,"part":2,"answer":"##..\n..##","duration":2000000}
+{"year":2022,"day":16,"part":1,"answer":"","duration":0,"error":"no route"}
+`
+	results, err := DecodeResults(strings.NewReader(output))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 3 || results[0].Duration != 1500*time.Microsecond {
+		t.Fatalf(`DecodeResults() = %v`, results)
+	}
+	summary := &bytes.Buffer{}
+	if err := WriteSummary(summary, results, nil); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"13140", "(2 lines, see below)", "error: no route", "Total", "\t..##"} {
+		if !strings.Contains(summary.String(), want) {
+			t.Fatalf("WriteSummary() missing %q:\n%s", want, summary)
+		}
+	}
+	if strings.Contains(summary.String(), "Check") {
+		t.Fatalf("WriteSummary() without ledger has Check column:\n%s", summary)
+	}
+
+	// Marked against ledger
+	answers, err := ledger.Open(t.TempDir(), 2022)
+	if err != nil {
+		t.Fatal(err)
+	}
+	answers.Confirm(10, 1, "13140")
+	answers.Confirm(16, 1, "1651")
+	summary.Reset()
+	if err := WriteSummary(summary, results, answers); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(summary.String(), "\n")
+	if !strings.HasSuffix(strings.TrimSpace(lines[1]), "✓") || !strings.HasSuffix(strings.TrimSpace(lines[3]), "✗") {
+		t.Fatalf("WriteSummary() with ledger not marked:\n%s", summary)
+	}
+}
+
+// Tests generating known answers test only for modules without one
+func TestWriteAnswersTest(t *testing.T) {
+	module := Module{Year: 2022, Day: 1, Dir: t.TempDir()}
+	for _, want := range []bool{true, false} {
+		written, err := WriteAnswersTest(module)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if written != want {
+			t.Fatalf(`WriteAnswersTest() = %v, want %v`, written, want)
+		}
+	}
+	content, err := os.ReadFile(filepath.Join(module.Dir, AnswersTestFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(content), "aoctest.KnownAnswers(t, puzzle)") {
+		t.Fatalf("generated test:\n%s", content)
+	}
+}
@@ -0,0 +1,71 @@
+package runner
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/erikzak/adventofcode/2022/aoc"
+	"github.com/erikzak/adventofcode/2022/aoc/ledger"
+)
+
+// Writes summary table of answers, wall time and allocations. Multi-line answers don't fit
+// the table, so they're written out below it. Answers are marked against the
+// answer ledger, if given
+func WriteSummary(w io.Writer, results []aoc.Result, answers *ledger.Ledger) error {
+	table := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	if answers != nil {
+		fmt.Fprintln(table, "Day\tPart\tAnswer\tTime\tAllocs\tCheck")
+	} else {
+		fmt.Fprintln(table, "Day\tPart\tAnswer\tTime\tAllocs")
+	}
+	var total time.Duration
+	var totalAllocs uint64
+	multiLine := []aoc.Result{}
+	for _, result := range results {
+		answer := result.Answer
+		if result.Error != "" {
+			answer = "error: " + firstLine(result.Error)
+		} else if strings.Contains(answer, "\n") {
+			multiLine = append(multiLine, result)
+			answer = fmt.Sprintf("(%d lines, see below)", strings.Count(answer, "\n")+1)
+		}
+		fmt.Fprintf(table, "%d\t%d\t%s\t%v\t%d", result.Day, result.Part, answer, roundDuration(result.Duration), result.Allocs)
+		if answers != nil {
+			fmt.Fprintf(table, "\t%v", answers.Check(result))
+		}
+		fmt.Fprintln(table)
+		total += result.Duration
+		totalAllocs += result.Allocs
+	}
+	fmt.Fprintf(table, "Total\t\t\t%v\t%d\n", roundDuration(total), totalAllocs)
+	if err := table.Flush(); err != nil {
+		return err
+	}
+	for _, result := range multiLine {
+		fmt.Fprintf(w, "\nDay %d part %d:\n", result.Day, result.Part)
+		for _, line := range strings.Split(result.Answer, "\n") {
+			fmt.Fprintf(w, "\t%s\n", line)
+		}
+	}
+	return nil
+}
+
+// Returns first line of possibly multi-line text
+func firstLine(text string) string {
+	line, _, _ := strings.Cut(text, "\n")
+	return line
+}
+
+// Rounds duration to a readable precision
+func roundDuration(d time.Duration) time.Duration {
+	switch {
+	case d >= time.Second:
+		return d.Round(time.Millisecond)
+	case d >= time.Millisecond:
+		return d.Round(time.Microsecond)
+	}
+	return d
+}
@@ -0,0 +1,46 @@
+package scaffold
+
+import (
+	"html"
+	"regexp"
+	"strings"
+)
+
+var (
+	articlePattern = regexp.MustCompile(`(?s)<article class="day-desc">(.*?)</article>`)
+	examplePattern = regexp.MustCompile(`(?s)<pre><code>(.*?)</code></pre>`)
+	// Example answers are emphasized code, like <code><em>24000</em></code>
+	answerPattern = regexp.MustCompile(`(?s)<code><em>(.*?)</em></code>`)
+	tagPattern    = regexp.MustCompile(`<[^>]*>`)
+)
+
+// Example input and answers pulled from a saved puzzle page
+type Page struct {
+	Example string
+	Answers [2]string // Empty for parts not on the page
+}
+
+// Parses a saved puzzle page. The example is the first code block of part 1,
+// and the answer of each part is the last emphasized code in its description,
+// which is where the puzzle text states the example result
+func ParsePage(page []byte) Page {
+	parsed := Page{}
+	articles := articlePattern.FindAllStringSubmatch(string(page), 2)
+	for part, article := range articles {
+		if part == 0 {
+			if match := examplePattern.FindStringSubmatch(article[1]); match != nil {
+				parsed.Example = text(match[1])
+			}
+		}
+		matches := answerPattern.FindAllStringSubmatch(article[1], -1)
+		if len(matches) > 0 {
+			parsed.Answers[part] = strings.TrimSpace(text(matches[len(matches)-1][1]))
+		}
+	}
+	return parsed
+}
+
+// Strips tags and unescapes entities
+func text(s string) string {
+	return html.UnescapeString(tagPattern.ReplaceAllString(s, ""))
+}
@@ -0,0 +1,178 @@
+// Generates the skeleton of a new day module from templates: go.mod, the
+// puzzle registered with the runner, and example, benchmark and fuzz tests.
+package scaffold
+
+import (
+	"bytes"
+	"embed"
+	"errors"
+	"fmt"
+	"go/format"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/erikzak/adventofcode/2022/aoc/runner"
+)
+
+//go:embed templates/*.tmpl
+var templateFiles embed.FS
+
+var templates = template.Must(template.New("").Funcs(template.FuncMap{
+	"inc": func(i int) int { return i + 1 },
+}).ParseFS(templateFiles, "templates/*.tmpl"))
+
+// Generated files, by template. Paths are relative to the day module
+var files = []struct {
+	template string
+	path     string
+}{
+	{"go.mod.tmpl", "go.mod"},
+	{"day.go.tmpl", "day%d.go"},
+	{"example_test.go.tmpl", "example_test.go"},
+	{"bench_test.go.tmpl", "bench_test.go"},
+	{"fuzz_test.go.tmpl", "fuzz_test.go"},
+}
+
+// Module path prefix of the day modules
+const repoModule = "github.com/erikzak/adventofcode"
+
+// Module holding the shared aoc module, relative to the repository root
+var aocDir = filepath.Join("2022", "aoc", "go")
+
+// What to scaffold
+type Options struct {
+	Year    int
+	Day     int
+	Example string    // Example input, written to test.txt in the day directory
+	Answers [2]string // Example answers of part 1 and 2, empty if not known
+}
+
+// Expected example answer of a part. Numeric answers are solved as int,
+// anything else as string
+type Answer struct {
+	Known   bool
+	Literal string // Go literal of the answer
+	Type    string
+	Zero    string // Go literal of the zero value of Type
+}
+
+// Returns answer for the given example answer, empty if not known
+func newAnswer(answer string) Answer {
+	answer = strings.TrimSpace(answer)
+	if answer == "" {
+		return Answer{Type: "int", Zero: "0"}
+	}
+	if _, err := strconv.Atoi(answer); err == nil {
+		return Answer{Known: true, Literal: answer, Type: "int", Zero: "0"}
+	}
+	return Answer{Known: true, Literal: strconv.Quote(answer), Type: "string", Zero: `""`}
+}
+
+// Example answers of both parts
+type Answers struct {
+	Part1 Answer
+	Part2 Answer
+}
+
+// Returns answers in part order
+func (answers Answers) List() []Answer {
+	return []Answer{answers.Part1, answers.Part2}
+}
+
+// Template data
+type data struct {
+	Year       int
+	Day        int
+	Module     string // Module path of the day
+	AocModule  string
+	AocReplace string // Relative path from the day module to the aoc module
+	Answers    Answers
+}
+
+// Returns module path of a day, e.g. github.com/erikzak/adventofcode/2022/16
+func ModulePath(year int, day int) string {
+	return fmt.Sprintf("%s/%d/%d", repoModule, year, day)
+}
+
+// Creates day module skeleton under root, plus example input in the day
+// directory. Existing files are never overwritten, so nothing is written if
+// any of them exists. Returns the new module and the written files
+func Create(root string, options Options) (runner.Module, []string, error) {
+	if options.Day < 1 || options.Day > 25 {
+		return runner.Module{}, nil, fmt.Errorf("invalid day: %d", options.Day)
+	}
+	dayDir := runner.DayDir(root, options.Year, options.Day)
+	module := runner.Module{Year: options.Year, Day: options.Day, Dir: filepath.Join(dayDir, "go")}
+	replace, err := filepath.Rel(module.Dir, filepath.Join(root, aocDir))
+	if err != nil {
+		return module, nil, err
+	}
+	values := data{
+		Year:       options.Year,
+		Day:        options.Day,
+		Module:     ModulePath(options.Year, options.Day),
+		AocModule:  runner.AocModule,
+		AocReplace: filepath.ToSlash(replace),
+		Answers:    Answers{newAnswer(options.Answers[0]), newAnswer(options.Answers[1])},
+	}
+
+	// Render everything first, so a failure leaves no half-made module
+	contents := map[string][]byte{}
+	paths := []string{}
+	for _, file := range files {
+		path := filepath.Join(module.Dir, file.path)
+		if strings.Contains(file.path, "%d") {
+			path = filepath.Join(module.Dir, fmt.Sprintf(file.path, options.Day))
+		}
+		content, err := render(file.template, values)
+		if err != nil {
+			return module, nil, err
+		}
+		contents[path] = content
+		paths = append(paths, path)
+	}
+	// Written empty without an example, ready to paste it in
+	examplePath := filepath.Join(dayDir, "test.txt")
+	example := options.Example
+	if example != "" && !strings.HasSuffix(example, "\n") {
+		example += "\n"
+	}
+	contents[examplePath] = []byte(example)
+	paths = append(paths, examplePath)
+	for _, path := range paths {
+		if _, err := os.Stat(path); err == nil {
+			return module, nil, fmt.Errorf("%s already exists", path)
+		} else if !errors.Is(err, os.ErrNotExist) {
+			return module, nil, err
+		}
+	}
+
+	if err := os.MkdirAll(module.Dir, 0o755); err != nil {
+		return module, nil, err
+	}
+	for _, path := range paths {
+		if err := os.WriteFile(path, contents[path], 0o644); err != nil {
+			return module, nil, err
+		}
+	}
+	return module, paths, nil
+}
+
+// Executes template with values. Go sources are gofmt'ed
+func render(name string, values data) ([]byte, error) {
+	var buffer bytes.Buffer
+	if err := templates.ExecuteTemplate(&buffer, name, values); err != nil {
+		return nil, err
+	}
+	if !strings.HasSuffix(name, ".go.tmpl") {
+		return buffer.Bytes(), nil
+	}
+	source, err := format.Source(buffer.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("%s: %v", name, err)
+	}
+	return source, nil
+}
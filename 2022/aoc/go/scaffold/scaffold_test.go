@@ -0,0 +1,99 @@
+package scaffold
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/erikzak/adventofcode/2022/aoc/runner"
+)
+
+// Trimmed down puzzle page with both parts solved
+const page = `<main>
+<article class="day-desc"><h2>--- Day 1: Calorie Counting ---</h2>
+<p>For example:</p>
+<pre><code>1000
+2000
+
+4000
+</code></pre>
+<p>In the example above, this is <code>24000</code> calories (<code><em>24000</em></code> in total).</p>
+</article>
+<p>Your puzzle answer was <code>70000</code>.</p>
+<article class="day-desc"><h2 id="part2">--- Part Two ---</h2>
+<p>Still <code><em>4 &amp; 2</em></code>, or rather <code><em>45000</em></code>.</p>
+</article>
+</main>`
+
+// Tests pulling example input and answers from a puzzle page
+func TestParsePage(t *testing.T) {
+	parsed := ParsePage([]byte(page))
+	if parsed.Example != "1000\n2000\n\n4000\n" {
+		t.Fatalf(`ParsePage().Example = %q`, parsed.Example)
+	}
+	if parsed.Answers != [2]string{"24000", "45000"} {
+		t.Fatalf(`ParsePage().Answers = %q, want [24000 45000]`, parsed.Answers)
+	}
+	// Saved before part 2 was unlocked
+	partOne := ParsePage([]byte(page[:strings.Index(page, "<p>Your")]))
+	if partOne.Answers != [2]string{"24000", ""} {
+		t.Fatalf(`ParsePage().Answers = %q, want [24000 ]`, partOne.Answers)
+	}
+}
+
+// Tests that a scaffolded module is registered with the runner, builds, and
+// is never overwritten
+func TestCreate(t *testing.T) {
+	root := t.TempDir()
+	options := Options{Year: 2022, Day: 7, Example: "1000\n2000", Answers: [2]string{"3000", "abc"}}
+	module, paths, err := Create(root, options)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(paths) != len(files)+1 {
+		t.Fatalf(`Create() wrote %d files, want %d`, len(paths), len(files)+1)
+	}
+	modules, err := runner.Discover(root, 2022)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(modules) != 1 || modules[0] != module {
+		t.Fatalf(`Discover() = %v, want [%v]`, modules, module)
+	}
+	example, err := os.ReadFile(filepath.Join(root, "2022", "07", "test.txt"))
+	if err != nil || string(example) != "1000\n2000\n" {
+		t.Fatalf(`test.txt = %q, %v`, example, err)
+	}
+	source, err := os.ReadFile(filepath.Join(module.Dir, "day7.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(source), "aoc.Puzzle[[]string, int, string]") {
+		t.Fatalf("day7.go does not register int and string answers:\n%s", source)
+	}
+	if _, _, err := Create(root, options); err == nil {
+		t.Fatal(`Create() of existing module did not fail`)
+	}
+
+	if testing.Short() {
+		return
+	}
+	// Point the replace directive at this aoc module and vet the result
+	aoc, err := filepath.Abs("..")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Join(root, "2022", "aoc"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink(aoc, filepath.Join(root, aocDir)); err != nil {
+		t.Skip(err)
+	}
+	cmd := exec.Command("go", "vet", ".")
+	cmd.Dir = module.Dir
+	if output, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("go vet: %v\n%s", err, output)
+	}
+}
@@ -0,0 +1,53 @@
+package search
+
+import "container/heap"
+
+// Frontier entry. Ties in priority are broken by insertion order, so searches
+// are deterministic
+type item[S comparable] struct {
+	state    S
+	priority int
+	order    int
+}
+
+// Min-heap of states by priority, implementing heap.Interface
+type frontier[S comparable] struct {
+	items  []item[S]
+	pushed int
+}
+
+func (f *frontier[S]) Len() int {
+	return len(f.items)
+}
+
+func (f *frontier[S]) Less(i, j int) bool {
+	if f.items[i].priority != f.items[j].priority {
+		return f.items[i].priority < f.items[j].priority
+	}
+	return f.items[i].order < f.items[j].order
+}
+
+func (f *frontier[S]) Swap(i, j int) {
+	f.items[i], f.items[j] = f.items[j], f.items[i]
+}
+
+func (f *frontier[S]) Push(x any) {
+	f.items = append(f.items, x.(item[S]))
+}
+
+func (f *frontier[S]) Pop() any {
+	last := f.items[len(f.items)-1]
+	f.items = f.items[:len(f.items)-1]
+	return last
+}
+
+// Adds state with priority
+func (f *frontier[S]) push(state S, priority int) {
+	heap.Push(f, item[S]{state: state, priority: priority, order: f.pushed})
+	f.pushed++
+}
+
+// Removes and returns state with lowest priority
+func (f *frontier[S]) pop() S {
+	return heap.Pop(f).(item[S]).state
+}
@@ -0,0 +1,207 @@
+// Generic graph search. States are any comparable type, and graphs only need
+// to list the edges out of a state, so grids, valve tunnels and voxels can
+// all be searched the same way.
+package search
+
+// Edge to a neighboring state, with the cost of moving there
+type Edge[S comparable] struct {
+	To   S
+	Cost int
+}
+
+// Search space. Returns edges out of state
+type Graph[S comparable] interface {
+	Neighbors(state S) []Edge[S]
+}
+
+// Adapts a neighbors function to the Graph interface
+type GraphFunc[S comparable] func(state S) []Edge[S]
+
+func (f GraphFunc[S]) Neighbors(state S) []Edge[S] {
+	return f(state)
+}
+
+// Returns edges of cost 1 to each of the given states, for unweighted graphs
+func Unweighted[S comparable](states []S) []Edge[S] {
+	edges := make([]Edge[S], len(states))
+	for i, state := range states {
+		edges[i] = Edge[S]{To: state, Cost: 1}
+	}
+	return edges
+}
+
+// Counters for comparing search effort
+type Stats struct {
+	Expanded    int // States taken off the frontier and expanded
+	Pushed      int // States added to the frontier, starts included
+	MaxFrontier int // Largest frontier size
+}
+
+// Outcome of a search. Holds cost and parent of every state reached, so
+// paths and costs can be looked up for any of them, not only the goal
+type Result[S comparable] struct {
+	Found bool // Goal reached
+	Goal  S    // Goal state, if found
+	Cost  int  // Cost to goal, -1 if not found
+	Stats Stats
+
+	costs   map[S]int
+	parents map[S]S
+}
+
+// Inits result for the given start states
+func newResult[S comparable](starts []S) *Result[S] {
+	result := Result[S]{Cost: -1, costs: map[S]int{}, parents: map[S]S{}}
+	for _, start := range starts {
+		result.costs[start] = 0
+	}
+	return &result
+}
+
+// Marks state as goal
+func (result *Result[S]) found(goal S) {
+	result.Found = true
+	result.Goal = goal
+	result.Cost = result.costs[goal]
+}
+
+// Returns lowest known cost from a start to state
+func (result *Result[S]) CostTo(state S) (int, bool) {
+	cost, ok := result.costs[state]
+	return cost, ok
+}
+
+// Checks if the search reached state
+func (result *Result[S]) Reached(state S) bool {
+	_, ok := result.costs[state]
+	return ok
+}
+
+// Returns all states reached by the search, in no particular order
+func (result *Result[S]) States() []S {
+	states := make([]S, 0, len(result.costs))
+	for state := range result.costs {
+		states = append(states, state)
+	}
+	return states
+}
+
+// Returns path from a start to the goal, both included. Nil if not found
+func (result *Result[S]) Path() []S {
+	if !result.Found {
+		return nil
+	}
+	path, _ := result.PathTo(result.Goal)
+	return path
+}
+
+// Returns path from a start to state, both included
+func (result *Result[S]) PathTo(state S) ([]S, bool) {
+	if !result.Reached(state) {
+		return nil, false
+	}
+	path := []S{state}
+	for {
+		parent, ok := result.parents[state]
+		if !ok {
+			break
+		}
+		path = append(path, parent)
+		state = parent
+	}
+	// Walked backwards from state, reverse into start to state order
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
+	}
+	return path, true
+}
+
+// Breadth-first search from starts until goal returns true. Edge costs are
+// ignored, cost is the number of steps. A nil goal explores every reachable
+// state, for looking up costs and paths afterwards
+func BFS[S comparable](graph Graph[S], goal func(S) bool, starts ...S) *Result[S] {
+	result := newResult(starts)
+	queue := append([]S{}, starts...)
+	result.Stats.Pushed = len(queue)
+	result.Stats.MaxFrontier = len(queue)
+	for len(queue) > 0 {
+		state := queue[0]
+		queue = queue[1:]
+		if goal != nil && goal(state) {
+			result.found(state)
+			return result
+		}
+		result.Stats.Expanded++
+		for _, edge := range graph.Neighbors(state) {
+			if result.Reached(edge.To) {
+				continue
+			}
+			result.costs[edge.To] = result.costs[state] + 1
+			result.parents[edge.To] = state
+			queue = append(queue, edge.To)
+			result.Stats.Pushed++
+		}
+		if len(queue) > result.Stats.MaxFrontier {
+			result.Stats.MaxFrontier = len(queue)
+		}
+	}
+	return result
+}
+
+// Dijkstra's lowest cost search from starts until goal returns true. Edge
+// costs must not be negative. A nil goal explores every reachable state
+func Dijkstra[S comparable](graph Graph[S], goal func(S) bool, starts ...S) *Result[S] {
+	return AStar(graph, goal, nil, starts...)
+}
+
+// A* search from starts until goal returns true, guided by heuristic estimate
+// of the remaining cost. The heuristic must never overestimate, and never
+// drop by more than the cost of an edge, or the result may not be optimal.
+// A nil heuristic makes this Dijkstra's search
+func AStar[S comparable](graph Graph[S], goal func(S) bool, heuristic func(S) int, starts ...S) *Result[S] {
+	result := newResult(starts)
+	estimate := func(state S) int {
+		if heuristic == nil {
+			return 0
+		}
+		return heuristic(state)
+	}
+	open := &frontier[S]{}
+	for _, start := range starts {
+		open.push(start, estimate(start))
+	}
+	result.Stats.Pushed = open.Len()
+	result.Stats.MaxFrontier = open.Len()
+	closed := map[S]struct{}{}
+	for open.Len() > 0 {
+		state := open.pop()
+		// Stale entries are left in the frontier when a cheaper path is found
+		if _, done := closed[state]; done {
+			continue
+		}
+		if goal != nil && goal(state) {
+			result.found(state)
+			return result
+		}
+		closed[state] = struct{}{}
+		result.Stats.Expanded++
+		cost := result.costs[state]
+		for _, edge := range graph.Neighbors(state) {
+			if _, done := closed[edge.To]; done {
+				continue
+			}
+			known, ok := result.costs[edge.To]
+			if ok && known <= cost+edge.Cost {
+				continue
+			}
+			result.costs[edge.To] = cost + edge.Cost
+			result.parents[edge.To] = state
+			open.push(edge.To, cost+edge.Cost+estimate(edge.To))
+			result.Stats.Pushed++
+		}
+		if open.Len() > result.Stats.MaxFrontier {
+			result.Stats.MaxFrontier = open.Len()
+		}
+	}
+	return result
+}
@@ -0,0 +1,130 @@
+package search
+
+import (
+	"reflect"
+	"testing"
+)
+
+// Weighted graph where the direct route A-B-D costs more than A-C-E-D
+var weighted = GraphFunc[string](func(state string) []Edge[string] {
+	edges := map[string][]Edge[string]{
+		"A": {{"B", 5}, {"C", 1}},
+		"B": {{"D", 5}},
+		"C": {{"E", 1}},
+		"E": {{"D", 1}},
+	}
+	return edges[state]
+})
+
+// Open 2D grid of given size, with a wall along x = 2 except at y = 0
+type testGrid struct {
+	size int
+}
+
+func (g testGrid) Neighbors(state [2]int) []Edge[[2]int] {
+	neighbors := [][2]int{}
+	for _, delta := range [][2]int{{0, -1}, {1, 0}, {0, 1}, {-1, 0}} {
+		next := [2]int{state[0] + delta[0], state[1] + delta[1]}
+		if next[0] < 0 || next[1] < 0 || next[0] >= g.size || next[1] >= g.size {
+			continue
+		}
+		if next[0] == 2 && next[1] != 0 {
+			continue
+		}
+		neighbors = append(neighbors, next)
+	}
+	return Unweighted(neighbors)
+}
+
+// Returns goal function matching the given state
+func is[S comparable](want S) func(S) bool {
+	return func(state S) bool {
+		return state == want
+	}
+}
+
+// Tests that BFS counts steps while Dijkstra counts edge costs
+func TestBFSAndDijkstra(t *testing.T) {
+	bfs := BFS[string](weighted, is("D"), "A")
+	if !bfs.Found || bfs.Cost != 2 {
+		t.Fatalf(`BFS() = %v, %v, want true, 2`, bfs.Found, bfs.Cost)
+	}
+	if path := bfs.Path(); !reflect.DeepEqual(path, []string{"A", "B", "D"}) {
+		t.Fatalf(`BFS().Path() = %v, want [A B D]`, path)
+	}
+	dijkstra := Dijkstra[string](weighted, is("D"), "A")
+	if !dijkstra.Found || dijkstra.Cost != 3 {
+		t.Fatalf(`Dijkstra() = %v, %v, want true, 3`, dijkstra.Found, dijkstra.Cost)
+	}
+	if path := dijkstra.Path(); !reflect.DeepEqual(path, []string{"A", "C", "E", "D"}) {
+		t.Fatalf(`Dijkstra().Path() = %v, want [A C E D]`, path)
+	}
+	if missing := Dijkstra[string](weighted, is("X"), "A"); missing.Found || missing.Cost != -1 || missing.Path() != nil {
+		t.Fatalf(`Dijkstra() of unreachable goal = %v, %v`, missing.Found, missing.Cost)
+	}
+}
+
+// Tests full exploration without goal
+func TestExploreAll(t *testing.T) {
+	result := BFS[string](weighted, nil, "A")
+	if result.Found || len(result.States()) != 5 {
+		t.Fatalf(`BFS() reached %v, want all 5 states`, result.States())
+	}
+	if cost, ok := result.CostTo("E"); !ok || cost != 2 {
+		t.Fatalf(`CostTo(E) = %v, %v, want 2, true`, cost, ok)
+	}
+	if path, _ := result.PathTo("E"); !reflect.DeepEqual(path, []string{"A", "C", "E"}) {
+		t.Fatalf(`PathTo(E) = %v, want [A C E]`, path)
+	}
+	if result.Reached("X") {
+		t.Fatalf(`Reached(X) = true`)
+	}
+}
+
+// Returns manhattan distance heuristic towards end
+func manhattan(end [2]int) func([2]int) int {
+	return func(state [2]int) int {
+		dx, dy := end[0]-state[0], end[1]-state[1]
+		if dx < 0 {
+			dx = -dx
+		}
+		if dy < 0 {
+			dy = -dy
+		}
+		return dx + dy
+	}
+}
+
+// Tests that A* finds the same cost as Dijkstra while expanding fewer states
+func TestAStar(t *testing.T) {
+	g := testGrid{size: 8}
+	start, end := [2]int{0, 7}, [2]int{7, 7}
+	dijkstra := Dijkstra[[2]int](g, is(end), start)
+	astar := AStar[[2]int](g, is(end), manhattan(end), start)
+	// Around the wall through the gap at the top
+	if dijkstra.Cost != 21 || astar.Cost != 21 {
+		t.Fatalf(`Dijkstra(), AStar() cost = %v, %v, want 21`, dijkstra.Cost, astar.Cost)
+	}
+	if len(astar.Path()) != 22 {
+		t.Fatalf(`AStar().Path() has %d states, want 22`, len(astar.Path()))
+	}
+	// Nothing in the way, the heuristic leads straight to the goal
+	start, end = [2]int{3, 7}, [2]int{7, 0}
+	dijkstra = Dijkstra[[2]int](g, is(end), start)
+	astar = AStar[[2]int](g, is(end), manhattan(end), start)
+	if dijkstra.Cost != 11 || astar.Cost != 11 {
+		t.Fatalf(`Dijkstra(), AStar() cost = %v, %v, want 11`, dijkstra.Cost, astar.Cost)
+	}
+	if astar.Stats.Expanded >= dijkstra.Stats.Expanded {
+		t.Fatalf(`AStar() expanded %d states, Dijkstra() %d`, astar.Stats.Expanded, dijkstra.Stats.Expanded)
+	}
+}
+
+// Tests searching from several starts at once
+func TestMultipleStarts(t *testing.T) {
+	g := testGrid{size: 8}
+	result := BFS[[2]int](g, is([2]int{7, 7}), [2]int{0, 7}, [2]int{3, 7})
+	if result.Cost != 4 || result.Path()[0] != [2]int{3, 7} {
+		t.Fatalf(`BFS() = %v from %v, want 4 from [3 7]`, result.Cost, result.Path()[0])
+	}
+}
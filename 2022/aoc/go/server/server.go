@@ -0,0 +1,223 @@
+// HTTP service exposing the day solvers, for notebooks and web UIs. Puzzle
+// input is posted as the request body and answers come back as JSON results,
+// with durations in nanoseconds:
+//
+//	GET  /v1/days                  registered days
+//	POST /v1/{year}/{day}/{part}   solve part of day, ?timeout=10s optional
+//
+// Days are solved by the runner, each request in its own day process, with a
+// limit on requests solved at once.
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/erikzak/adventofcode/2022/aoc"
+	"github.com/erikzak/adventofcode/2022/aoc/runner"
+)
+
+// Solves parts of day modules. Satisfied by *runner.Runner
+type Solver interface {
+	Run(ctx context.Context, module runner.Module, options runner.Options) ([]aoc.Result, error)
+}
+
+// Limits of the service
+type Options struct {
+	Timeout     time.Duration // Time limit of requests not asking for one, default 30s
+	MaxTimeout  time.Duration // Longest time limit requests can ask for, default 5m
+	Concurrency int           // Most requests solved at once, default the number of CPUs
+	MaxInput    int64         // Largest puzzle input in bytes, default 4 MiB
+}
+
+// Time left for the day process to report a timed out part, before it is
+// killed
+const timeoutGrace = 5 * time.Second
+
+// Serves the solvers of registered day modules
+type Server struct {
+	options Options
+	solver  Solver
+	modules []runner.Module
+	slots   chan struct{} // Taken by requests being solved
+	grace   time.Duration
+}
+
+func New(modules []runner.Module, solver Solver, options Options) *Server {
+	if options.Timeout <= 0 {
+		options.Timeout = 30 * time.Second
+	}
+	if options.MaxTimeout <= 0 {
+		options.MaxTimeout = 5 * time.Minute
+	}
+	if options.MaxTimeout < options.Timeout {
+		options.MaxTimeout = options.Timeout
+	}
+	if options.Concurrency < 1 {
+		options.Concurrency = runtime.NumCPU()
+	}
+	if options.MaxInput < 1 {
+		options.MaxInput = 4 << 20
+	}
+	return &Server{options: options, solver: solver, modules: modules, slots: make(chan struct{}, options.Concurrency), grace: timeoutGrace}
+}
+
+// Registered day, as listed by GET /v1/days
+type Day struct {
+	Year int `json:"year"`
+	Day  int `json:"day"`
+}
+
+// Body of error responses
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+func (server *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	path := strings.Trim(r.URL.Path, "/")
+	if path == "v1/days" {
+		if r.Method != http.MethodGet {
+			writeMethodNotAllowed(w, http.MethodGet)
+			return
+		}
+		days := make([]Day, len(server.modules))
+		for i, module := range server.modules {
+			days[i] = Day{Year: module.Year, Day: module.Day}
+		}
+		writeJSON(w, http.StatusOK, days)
+		return
+	}
+	fields := strings.Split(path, "/")
+	if len(fields) != 4 || fields[0] != "v1" {
+		writeError(w, http.StatusNotFound, "not found, want /v1/days or /v1/{year}/{day}/{part}")
+		return
+	}
+	if r.Method != http.MethodPost {
+		writeMethodNotAllowed(w, http.MethodPost)
+		return
+	}
+	numbers := [3]int{}
+	for i, field := range fields[1:] {
+		n, err := strconv.Atoi(field)
+		if err != nil {
+			writeError(w, http.StatusNotFound, fmt.Sprintf("invalid year, day or part %q", field))
+			return
+		}
+		numbers[i] = n
+	}
+	server.solve(w, r, numbers[0], numbers[1], numbers[2])
+}
+
+// Solves part of day with the request body as input
+func (server *Server) solve(w http.ResponseWriter, r *http.Request, year int, day int, part int) {
+	module, ok := server.module(year, day)
+	if !ok {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("day %d of %d is not registered", day, year))
+		return
+	}
+	if part != 1 && part != 2 {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("invalid part: %d", part))
+		return
+	}
+	timeout, err := server.timeout(r.URL.Query().Get("timeout"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	input, err := io.ReadAll(http.MaxBytesReader(w, r.Body, server.options.MaxInput))
+	if err != nil {
+		writeError(w, http.StatusRequestEntityTooLarge, fmt.Sprintf("input larger than %d bytes", server.options.MaxInput))
+		return
+	}
+	if len(input) == 0 {
+		writeError(w, http.StatusBadRequest, "no input, post puzzle input as the request body")
+		return
+	}
+
+	// Waiting for a free slot counts towards the time limit
+	ctx, cancel := context.WithTimeout(r.Context(), timeout)
+	defer cancel()
+	select {
+	case server.slots <- struct{}{}:
+		defer func() { <-server.slots }()
+	case <-ctx.Done():
+		writeError(w, http.StatusServiceUnavailable, fmt.Sprintf("all %d solvers busy, try again later", cap(server.slots)))
+		return
+	}
+	remaining := timeout
+	if deadline, ok := ctx.Deadline(); ok {
+		remaining = time.Until(deadline)
+	}
+	// The day process stops the part at the time limit and reports it. The
+	// process is only killed if it fails to
+	runCtx, cancelRun := context.WithTimeout(r.Context(), remaining+server.grace)
+	defer cancelRun()
+	results, err := server.solver.Run(runCtx, module, runner.Options{
+		Part: part, Input: aoc.StdinPath, Stdin: bytes.NewReader(input), Timeout: remaining,
+	})
+	switch {
+	case errors.Is(runCtx.Err(), context.DeadlineExceeded):
+		writeError(w, http.StatusGatewayTimeout, fmt.Sprintf("%s after %v", aoc.TimeoutError, timeout))
+	case err != nil:
+		writeError(w, http.StatusInternalServerError, err.Error())
+	case len(results) != 1:
+		writeError(w, http.StatusInternalServerError, fmt.Sprintf("day %d returned %d results, want 1", day, len(results)))
+	case strings.HasPrefix(results[0].Error, aoc.TimeoutError):
+		results[0].Error = fmt.Sprintf("%s after %v", aoc.TimeoutError, timeout)
+		writeJSON(w, http.StatusGatewayTimeout, results[0])
+	case results[0].Error != "":
+		// Most likely malformed input
+		writeJSON(w, http.StatusUnprocessableEntity, results[0])
+	default:
+		writeJSON(w, http.StatusOK, results[0])
+	}
+}
+
+// Returns registered module of day
+func (server *Server) module(year int, day int) (runner.Module, bool) {
+	for _, module := range server.modules {
+		if module.Year == year && module.Day == day {
+			return module, true
+		}
+	}
+	return runner.Module{}, false
+}
+
+// Returns time limit asked for, or the default if empty
+func (server *Server) timeout(value string) (time.Duration, error) {
+	if value == "" {
+		return server.options.Timeout, nil
+	}
+	timeout, err := time.ParseDuration(value)
+	if err != nil || timeout <= 0 {
+		return 0, fmt.Errorf("invalid timeout %q, want a duration like 10s", value)
+	}
+	if timeout > server.options.MaxTimeout {
+		return 0, fmt.Errorf("timeout %v is longer than the limit of %v", timeout, server.options.MaxTimeout)
+	}
+	return timeout, nil
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body)
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, errorResponse{message})
+}
+
+func writeMethodNotAllowed(w http.ResponseWriter, allowed string) {
+	w.Header().Set("Allow", allowed)
+	writeError(w, http.StatusMethodNotAllowed, fmt.Sprintf("method not allowed, want %s", allowed))
+}
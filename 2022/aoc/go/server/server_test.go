@@ -0,0 +1,190 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/erikzak/adventofcode/2022/aoc"
+	"github.com/erikzak/adventofcode/2022/aoc/runner"
+)
+
+// Stand-in for the runner. Answers with the length of the input, fails on
+// "panic" and searches until the part's time limit on "search". Requests
+// with "block" wait until release is closed
+type fakeSolver struct {
+	release chan struct{}
+}
+
+func (solver fakeSolver) Run(ctx context.Context, module runner.Module, options runner.Options) ([]aoc.Result, error) {
+	input, err := io.ReadAll(options.Stdin)
+	if err != nil {
+		return nil, err
+	}
+	result := aoc.Result{Year: module.Year, Day: module.Day, Part: options.Part, Duration: time.Millisecond}
+	switch string(input) {
+	case "panic":
+		result.Error = "day 1 part 1: malformed input"
+	case "search":
+		time.Sleep(options.Timeout)
+		result.Error = fmt.Sprintf("%s after %v", aoc.TimeoutError, options.Timeout)
+	case "hang":
+		<-ctx.Done()
+		return nil, ctx.Err()
+	case "block":
+		<-solver.release
+		fallthrough
+	default:
+		result.Answer = fmt.Sprint(len(input))
+	}
+	return []aoc.Result{result}, nil
+}
+
+// Returns test server for days 1 and 16 of 2022, giving up on hung day
+// processes quickly
+func newTestServer(t *testing.T, options Options) (*httptest.Server, fakeSolver) {
+	solver := fakeSolver{release: make(chan struct{})}
+	modules := []runner.Module{{Year: 2022, Day: 1}, {Year: 2022, Day: 16}}
+	handler := New(modules, solver, options)
+	handler.grace = 10 * time.Millisecond
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	return server, solver
+}
+
+// Posts input to path, returning status and decoded body
+func post(t *testing.T, server *httptest.Server, path string, input string, body any) int {
+	t.Helper()
+	response, err := server.Client().Post(server.URL+path, "text/plain", strings.NewReader(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer response.Body.Close()
+	if got := response.Header.Get("Content-Type"); got != "application/json" {
+		t.Fatalf(`POST %s Content-Type = %q, want application/json`, path, got)
+	}
+	if err := json.NewDecoder(response.Body).Decode(body); err != nil {
+		t.Fatal(err)
+	}
+	return response.StatusCode
+}
+
+// Tests listing registered days
+func TestDays(t *testing.T) {
+	server, _ := newTestServer(t, Options{})
+	response, err := server.Client().Get(server.URL + "/v1/days")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer response.Body.Close()
+	days := []Day{}
+	if err := json.NewDecoder(response.Body).Decode(&days); err != nil {
+		t.Fatal(err)
+	}
+	if len(days) != 2 || days[1] != (Day{2022, 16}) {
+		t.Fatalf(`GET /v1/days = %v, want days 1 and 16`, days)
+	}
+	var failed errorResponse
+	if status := post(t, server, "/v1/days", "", &failed); status != http.StatusMethodNotAllowed {
+		t.Fatalf(`POST /v1/days = %d, want %d`, status, http.StatusMethodNotAllowed)
+	}
+}
+
+// Tests solving parts, with status codes of failures
+func TestSolve(t *testing.T) {
+	server, _ := newTestServer(t, Options{MaxInput: 10})
+	var result aoc.Result
+	if status := post(t, server, "/v1/2022/16/2", "abcd", &result); status != http.StatusOK {
+		t.Fatalf(`POST /v1/2022/16/2 = %d, want %d`, status, http.StatusOK)
+	}
+	if result.Answer != "4" || result.Day != 16 || result.Part != 2 || result.Duration != time.Millisecond {
+		t.Fatalf(`POST /v1/2022/16/2 = %+v, want answer 4 to day 16 part 2`, result)
+	}
+	result = aoc.Result{}
+	if status := post(t, server, "/v1/2022/1/1", "panic", &result); status != http.StatusUnprocessableEntity {
+		t.Fatalf(`POST malformed input = %d, want %d`, status, http.StatusUnprocessableEntity)
+	}
+	if !strings.Contains(result.Error, "malformed input") {
+		t.Fatalf(`POST malformed input = %+v, want the error`, result)
+	}
+	tests := []struct {
+		path   string
+		input  string
+		status int
+	}{
+		{"/v1/2022/2/1", "abcd", http.StatusNotFound},
+		{"/v1/2022/1/3", "abcd", http.StatusNotFound},
+		{"/v1/2022/x/1", "abcd", http.StatusNotFound},
+		{"/v2/2022/1/1", "abcd", http.StatusNotFound},
+		{"/v1/2022/1/1", "", http.StatusBadRequest},
+		{"/v1/2022/1/1", "abcdefghijk", http.StatusRequestEntityTooLarge},
+		{"/v1/2022/1/1?timeout=soon", "abcd", http.StatusBadRequest},
+		{"/v1/2022/1/1?timeout=1h", "abcd", http.StatusBadRequest},
+	}
+	for _, test := range tests {
+		var failed errorResponse
+		if status := post(t, server, test.path, test.input, &failed); status != test.status || failed.Error == "" {
+			t.Fatalf(`POST %s with %q = %d %q, want %d`, test.path, test.input, status, failed.Error, test.status)
+		}
+	}
+}
+
+// Tests that requests are stopped at the time limit they ask for, and that
+// hung day processes are given up on
+func TestTimeout(t *testing.T) {
+	server, _ := newTestServer(t, Options{Timeout: time.Minute})
+	var result aoc.Result
+	if status := post(t, server, "/v1/2022/16/1?timeout=20ms", "search", &result); status != http.StatusGatewayTimeout {
+		t.Fatalf(`POST search = %d, want %d`, status, http.StatusGatewayTimeout)
+	}
+	if result.Error != "timed out after 20ms" {
+		t.Fatalf(`POST search error = %q, want timed out after 20ms`, result.Error)
+	}
+	var failed errorResponse
+	if status := post(t, server, "/v1/2022/16/1?timeout=10ms", "hang", &failed); status != http.StatusGatewayTimeout {
+		t.Fatalf(`POST hang = %d, want %d`, status, http.StatusGatewayTimeout)
+	}
+}
+
+// Tests that requests over the concurrency limit wait for a free solver, and
+// give up at their time limit
+func TestConcurrency(t *testing.T) {
+	server, solver := newTestServer(t, Options{Concurrency: 1})
+	done := make(chan int)
+	go func() {
+		// Fatal can't be called outside the test goroutine, so no post
+		response, err := server.Client().Post(server.URL+"/v1/2022/1/1", "text/plain", strings.NewReader("block"))
+		if err != nil {
+			done <- 0
+			return
+		}
+		response.Body.Close()
+		done <- response.StatusCode
+	}()
+	// Wait for the blocking request to take the only solver
+	for i := 0; ; i++ {
+		var failed errorResponse
+		status := post(t, server, "/v1/2022/1/1?timeout=10ms", "abcd", &failed)
+		if status == http.StatusServiceUnavailable {
+			break
+		}
+		if i == 100 {
+			t.Fatalf(`POST while busy = %d, want %d`, status, http.StatusServiceUnavailable)
+		}
+		time.Sleep(time.Millisecond)
+	}
+	close(solver.release)
+	if status := <-done; status != http.StatusOK {
+		t.Fatalf(`POST block = %d, want %d`, status, http.StatusOK)
+	}
+	var result aoc.Result
+	if status := post(t, server, "/v1/2022/1/1", "abcd", &result); status != http.StatusOK {
+		t.Fatalf(`POST after release = %d, want %d`, status, http.StatusOK)
+	}
+}
@@ -0,0 +1,282 @@
+package visual
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/gif"
+	"image/png"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/erikzak/adventofcode/2022/aoc/grid"
+)
+
+// Colors of glyphs in exported images
+type Palette struct {
+	Background color.Color // Blank cells, drawn as space or dot
+	Foreground color.Color // Glyphs without a color of their own
+	Glyphs     map[rune]color.Color
+}
+
+// Colors for the glyphs used by the simulation days, on the website's dark
+// blue
+var DefaultPalette = Palette{
+	Background: rgb(0x0f0f23),
+	Foreground: rgb(0xcccccc),
+	Glyphs: map[rune]color.Color{
+		'#': rgb(0x9e9e9e), // Rock, visited positions, void
+		'o': rgb(0xe0c068), // Sand at rest
+		'~': rgb(0xffd700), // Falling sand
+		'@': rgb(0xffff66), // Falling rock, scanned node
+		'+': rgb(0x666666), // Walls and floor
+		'-': rgb(0x666666),
+		'|': rgb(0x666666),
+		'S': rgb(0x00cc00), // Sensor
+		'B': rgb(0x3399ff), // Beacon
+		'H': rgb(0xff4040), // Rope head
+		'T': rgb(0xff9f40), // Rope tail
+		's': rgb(0x40ff40), // Rope start
+		'^': rgb(0x00cc00), // Tree visible from outside
+		'*': rgb(0xffff66), // Path, best scenic spot
+		':': rgb(0x3399ff), // Explored square, outside air
+		'E': rgb(0xff4040), // Hill top
+	},
+}
+
+func init() {
+	for i := 1; i <= 9; i++ {
+		DefaultPalette.Glyphs[rune('0'+i)] = rgb(0xff9f40) // Rope knots
+	}
+}
+
+// Returns opaque color of hex value 0xrrggbb
+func rgb(hex uint32) color.RGBA {
+	return color.RGBA{uint8(hex >> 16), uint8(hex >> 8), uint8(hex), 0xff}
+}
+
+// Returns copy of palette with glyph colors from spec like "#=808080,o=ffcc00".
+// Glyphs "bg" and "fg" set the background and foreground
+func (palette Palette) With(spec string) (Palette, error) {
+	result := Palette{Background: palette.Background, Foreground: palette.Foreground, Glyphs: map[rune]color.Color{}}
+	for glyph, c := range palette.Glyphs {
+		result.Glyphs[glyph] = c
+	}
+	if strings.TrimSpace(spec) == "" {
+		return result, nil
+	}
+	for _, entry := range strings.Split(spec, ",") {
+		glyph, hex, found := strings.Cut(entry, "=")
+		value, err := strconv.ParseUint(strings.TrimPrefix(hex, "#"), 16, 32)
+		if !found || err != nil || len(strings.TrimPrefix(hex, "#")) != 6 {
+			return palette, fmt.Errorf("invalid palette entry %q, want glyph=rrggbb", entry)
+		}
+		c := rgb(uint32(value))
+		switch {
+		case glyph == "bg":
+			result.Background = c
+		case glyph == "fg":
+			result.Foreground = c
+		case len([]rune(glyph)) == 1:
+			result.Glyphs[[]rune(glyph)[0]] = c
+		default:
+			return palette, fmt.Errorf("invalid palette glyph %q", glyph)
+		}
+	}
+	return result, nil
+}
+
+// Returns color of glyph
+func (palette Palette) color(glyph rune) color.Color {
+	if c, ok := palette.Glyphs[glyph]; ok {
+		return c
+	}
+	if glyph == ' ' || glyph == '.' {
+		return palette.Background
+	}
+	return palette.Foreground
+}
+
+// Returns the palette as a GIF color palette, background first
+func (palette Palette) gifPalette() color.Palette {
+	glyphs := make([]rune, 0, len(palette.Glyphs))
+	for glyph := range palette.Glyphs {
+		glyphs = append(glyphs, glyph)
+	}
+	sort.Slice(glyphs, func(i, j int) bool {
+		return glyphs[i] < glyphs[j]
+	})
+	colors := color.Palette{palette.Background, palette.Foreground}
+	for _, glyph := range glyphs {
+		colors = append(colors, palette.Glyphs[glyph])
+	}
+	// GIF palettes hold at most 256 colors, the rest are matched to the closest
+	if len(colors) > 256 {
+		colors = colors[:256]
+	}
+	return colors
+}
+
+// Largest scene side in cells exported whole. Larger scenes follow the focus
+// with a view of this size, unless a view size is set
+const maxCells = 512
+
+// How frames are turned into images
+type ExportOptions struct {
+	Palette   Palette
+	CellSize  int // Pixels per cell side, default 4
+	Delay     int // Delay between GIF frames in 100ths of a second, default 5
+	Every     int // Record every nth step, default 1
+	MaxFrames int // Most GIF frames kept, default 500. Every is doubled to stay below
+	Width     int // View size in cells, following the focus. 0 for whole scenes
+	Height    int
+}
+
+// Records simulation steps as images, for an animated GIF of the steps and a
+// PNG of the final state
+type Recorder struct {
+	options  ExportOptions
+	colors   color.Palette
+	indexes  map[rune]uint8 // Color index by glyph, filled as glyphs are seen
+	viewport viewport
+	steps    int
+	frames   []recorded
+	last     Framer
+}
+
+// Recorded frame, drawn in the cells of its own view
+type recorded struct {
+	image *image.Paletted
+	up    bool
+}
+
+func NewRecorder(options ExportOptions) *Recorder {
+	if options.Palette.Background == nil {
+		options.Palette = DefaultPalette
+	}
+	if options.CellSize < 1 {
+		options.CellSize = 4
+	}
+	if options.Delay < 1 {
+		options.Delay = 5
+	}
+	if options.Every < 1 {
+		options.Every = 1
+	}
+	if options.MaxFrames < 2 {
+		options.MaxFrames = 500
+	}
+	return &Recorder{options: options, colors: options.Palette.gifPalette(), indexes: map[rune]uint8{}}
+}
+
+// Records simulation step, drawing every nth step
+func (recorder *Recorder) capture(scene Framer) {
+	recorder.last = scene
+	recorder.steps++
+	if (recorder.steps-1)%recorder.options.Every != 0 {
+		return
+	}
+	frame := scene.Frame()
+	recorder.frames = append(recorder.frames, recorded{recorder.draw(frame, recorder.view(frame)), frame.Up})
+	// Keep every other frame and halve the frame rate when full
+	if len(recorder.frames) >= recorder.options.MaxFrames {
+		kept := recorder.frames[:0]
+		for i := 0; i < len(recorder.frames); i += 2 {
+			kept = append(kept, recorder.frames[i])
+		}
+		recorder.frames = kept
+		recorder.options.Every *= 2
+	}
+}
+
+// Returns part of frame to draw
+func (recorder *Recorder) view(frame Frame) grid.Rect {
+	size := grid.Point{recorder.options.Width, recorder.options.Height}
+	for axis := range size {
+		if size[axis] < 1 {
+			size[axis] = maxCells
+		}
+	}
+	return recorder.viewport.follow(frame, size)
+}
+
+// Draws view of frame as an image, a cell per point
+func (recorder *Recorder) draw(frame Frame, view grid.Rect) *image.Paletted {
+	cell := recorder.options.CellSize
+	img := image.NewPaletted(image.Rect(0, 0, view.Width()*cell, view.Height()*cell), recorder.colors)
+	for row := 0; row < view.Height(); row++ {
+		y := view.Min[1] + row
+		if frame.Up {
+			y = view.Max[1] - row
+		}
+		for column := 0; column < view.Width(); column++ {
+			index := recorder.index(frame.Glyph(grid.Point{view.Min[0] + column, y}))
+			for dy := 0; dy < cell; dy++ {
+				offset := img.PixOffset(column*cell, row*cell+dy)
+				for dx := 0; dx < cell; dx++ {
+					img.Pix[offset+dx] = index
+				}
+			}
+		}
+	}
+	return img
+}
+
+// Returns palette index of glyph color
+func (recorder *Recorder) index(glyph rune) uint8 {
+	index, ok := recorder.indexes[glyph]
+	if !ok {
+		index = uint8(recorder.colors.Index(recorder.options.Palette.color(glyph)))
+		recorder.indexes[glyph] = index
+	}
+	return index
+}
+
+// Number of frames recorded
+func (recorder *Recorder) Frames() int {
+	return len(recorder.frames)
+}
+
+// Writes recorded frames as an animated GIF. Frames are drawn on a canvas
+// fitting the largest of them, at the top, or at the bottom for scenes
+// growing upwards
+func (recorder *Recorder) WriteGIF(w io.Writer) error {
+	if len(recorder.frames) == 0 {
+		return fmt.Errorf("no frames recorded")
+	}
+	canvas := image.Rectangle{}
+	for _, frame := range recorder.frames {
+		canvas = canvas.Union(frame.image.Bounds())
+	}
+	animation := gif.GIF{Config: image.Config{ColorModel: recorder.colors, Width: canvas.Dx(), Height: canvas.Dy()}}
+	for i, frame := range recorder.frames {
+		img := image.NewPaletted(canvas, recorder.colors)
+		offset := image.Point{}
+		if frame.up {
+			offset.Y = canvas.Dy() - frame.image.Bounds().Dy()
+		}
+		for y := 0; y < frame.image.Bounds().Dy(); y++ {
+			copy(img.Pix[img.PixOffset(offset.X, offset.Y+y):], frame.image.Pix[frame.image.PixOffset(0, y):frame.image.PixOffset(frame.image.Bounds().Dx(), y)])
+		}
+		delay := recorder.options.Delay
+		// Hold the final state a while before looping
+		if i == len(recorder.frames)-1 {
+			delay *= 40
+		}
+		animation.Image = append(animation.Image, img)
+		animation.Delay = append(animation.Delay, delay)
+	}
+	return gif.EncodeAll(w, &animation)
+}
+
+// Writes the last recorded step as a PNG, whole unless larger than maxCells
+// or a view size is set
+func (recorder *Recorder) WritePNG(w io.Writer) error {
+	if recorder.last == nil {
+		return fmt.Errorf("no frames recorded")
+	}
+	frame := recorder.last.Frame()
+	return png.Encode(w, recorder.draw(frame, recorder.view(frame)))
+}
@@ -0,0 +1,162 @@
+package visual
+
+import (
+	"bytes"
+	"flag"
+	"image"
+	"image/gif"
+	"image/png"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/erikzak/adventofcode/2022/aoc/grid"
+)
+
+var update = flag.Bool("update", false, "rewrite golden images in testdata")
+
+// Grain of sand falling onto a floor, 6 wide and 4 high with y growing down
+type grain struct {
+	y int
+}
+
+func (g *grain) Frame() Frame {
+	return Frame{
+		Bounds: grid.Rect{Max: grid.Point{5, 3}},
+		Glyph: func(p grid.Point) rune {
+			switch {
+			case p[1] == 3:
+				return '#'
+			case p == grid.Point{2, g.y}:
+				return 'o'
+			}
+			return '.'
+		},
+		Focus: grid.Point{2, g.y},
+	}
+}
+
+// Records the grain falling from the top to the floor
+func recordGrain(t *testing.T, options ExportOptions) *Recorder {
+	recorder := NewRecorder(options)
+	animation := NewRecording(recorder)
+	scene := &grain{}
+	for y := 0; y < 3; y++ {
+		scene.y = y
+		animation.Step(scene)
+	}
+	return recorder
+}
+
+// Compares encoded image with golden file in testdata, pixel by pixel.
+// Rewrites the golden file with -update
+func checkGolden(t *testing.T, name string, encoded []byte, decode func(data []byte) ([]image.Image, []int, error)) {
+	t.Helper()
+	path := filepath.Join("testdata", name)
+	if *update {
+		if err := os.WriteFile(path, encoded, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	golden, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, wantDelays, err := decode(golden)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, gotDelays, err := decode(encoded)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != len(want) {
+		t.Fatalf(`%s has %d frames, want %d`, name, len(got), len(want))
+	}
+	for i := range want {
+		if gotDelays[i] != wantDelays[i] {
+			t.Fatalf(`%s frame %d delay = %d, want %d`, name, i, gotDelays[i], wantDelays[i])
+		}
+		if got[i].Bounds() != want[i].Bounds() {
+			t.Fatalf(`%s frame %d bounds = %v, want %v`, name, i, got[i].Bounds(), want[i].Bounds())
+		}
+		bounds := want[i].Bounds()
+		for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+			for x := bounds.Min.X; x < bounds.Max.X; x++ {
+				r1, g1, b1, a1 := got[i].At(x, y).RGBA()
+				r2, g2, b2, a2 := want[i].At(x, y).RGBA()
+				if r1 != r2 || g1 != g2 || b1 != b2 || a1 != a2 {
+					t.Fatalf(`%s frame %d pixel (%d, %d) = %v, want %v`, name, i, x, y, got[i].At(x, y), want[i].At(x, y))
+				}
+			}
+		}
+	}
+}
+
+func decodeGIF(data []byte) ([]image.Image, []int, error) {
+	animation, err := gif.DecodeAll(bytes.NewReader(data))
+	if err != nil {
+		return nil, nil, err
+	}
+	images := make([]image.Image, len(animation.Image))
+	for i, img := range animation.Image {
+		images[i] = img
+	}
+	return images, animation.Delay, nil
+}
+
+func decodePNG(data []byte) ([]image.Image, []int, error) {
+	img, err := png.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, nil, err
+	}
+	return []image.Image{img}, []int{0}, nil
+}
+
+// Tests exporting steps as an animated GIF against golden image
+func TestWriteGIF(t *testing.T) {
+	recorder := recordGrain(t, ExportOptions{CellSize: 3, Delay: 10})
+	buffer := &bytes.Buffer{}
+	if err := recorder.WriteGIF(buffer); err != nil {
+		t.Fatal(err)
+	}
+	checkGolden(t, "grain.gif", buffer.Bytes(), decodeGIF)
+}
+
+// Tests exporting final state as PNG against golden image, with a custom
+// palette
+func TestWritePNG(t *testing.T) {
+	palette, err := DefaultPalette.With("o=ff0000,bg=000000")
+	if err != nil {
+		t.Fatal(err)
+	}
+	recorder := recordGrain(t, ExportOptions{Palette: palette, CellSize: 2})
+	buffer := &bytes.Buffer{}
+	if err := recorder.WritePNG(buffer); err != nil {
+		t.Fatal(err)
+	}
+	checkGolden(t, "grain.png", buffer.Bytes(), decodePNG)
+	for _, spec := range []string{"o", "o=red", "oo=ff0000", "o=fff"} {
+		if _, err := DefaultPalette.With(spec); err == nil {
+			t.Fatalf(`With(%q) did not fail`, spec)
+		}
+	}
+}
+
+// Tests that long simulations are thinned out to stay below MaxFrames, and
+// that views follow the focus
+func TestRecorderLimits(t *testing.T) {
+	recorder := NewRecorder(ExportOptions{MaxFrames: 10, Width: 3, Height: 2})
+	animation := NewRecording(recorder)
+	scene := &grain{}
+	for i := 0; i < 100; i++ {
+		scene.y = i % 3
+		animation.Step(scene)
+	}
+	if frames := recorder.Frames(); frames >= 10 || frames < 5 {
+		t.Fatalf(`Frames() = %d, want 5 to 9`, frames)
+	}
+	if bounds := recorder.frames[0].image.Bounds(); bounds.Dx() != 3*4 || bounds.Dy() != 2*4 {
+		t.Fatalf(`frame bounds = %v, want 3x2 cells of 4 pixels`, bounds)
+	}
+}
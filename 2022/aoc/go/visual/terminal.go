@@ -0,0 +1,84 @@
+package visual
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// Controlling terminal. Used instead of stdin and stdout, which may be
+// puzzle input and piped results
+const ttyPath = "/dev/tty"
+
+// Animation on the controlling terminal, with keys read unbuffered
+type Terminal struct {
+	*Animation
+	tty   *os.File
+	saved string // stty settings to restore
+}
+
+// Opens the controlling terminal for animation at the given speed, in steps
+// per second. Close must be called to restore the terminal
+func OpenTerminal(speed int) (*Terminal, error) {
+	tty, err := os.OpenFile(ttyPath, os.O_RDWR, 0)
+	if err != nil {
+		return nil, fmt.Errorf("visualization needs a terminal: %v", err)
+	}
+	saved, err := stty(tty, "-g")
+	if err != nil {
+		tty.Close()
+		return nil, err
+	}
+	// Keys are read as they are pressed, without echo
+	if _, err := stty(tty, "-icanon", "-echo", "min", "1"); err != nil {
+		tty.Close()
+		return nil, err
+	}
+	width, height := 80, 24
+	// Size is reported as 0 0 by terminals that don't know it
+	if size, err := stty(tty, "size"); err == nil {
+		var rows, columns int
+		if _, err := fmt.Sscan(size, &rows, &columns); err == nil && rows > 0 && columns > 0 {
+			height, width = rows, columns
+		}
+	}
+	keys := make(chan byte, 16)
+	go readKeys(tty, keys)
+	// Hide cursor while playing
+	fmt.Fprint(tty, "\x1b[?25l")
+	return &Terminal{Animation: New(tty, keys, width, height, speed), tty: tty, saved: saved}, nil
+}
+
+// Reads key presses until the terminal is closed
+func readKeys(tty *os.File, keys chan<- byte) {
+	defer close(keys)
+	buffer := make([]byte, 1)
+	for {
+		if _, err := tty.Read(buffer); err != nil {
+			return
+		}
+		keys <- buffer[0]
+	}
+}
+
+// Restores terminal settings and shows the cursor again
+func (terminal *Terminal) Close() error {
+	fmt.Fprint(terminal.tty, "\x1b[?25h\n")
+	_, err := stty(terminal.tty, terminal.saved)
+	if closeErr := terminal.tty.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+// Runs stty on the terminal. Returns its output
+func stty(tty *os.File, args ...string) (string, error) {
+	cmd := exec.Command("stty", args...)
+	cmd.Stdin = tty
+	output, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("stty %s: %v", strings.Join(args, " "), err)
+	}
+	return strings.TrimSpace(string(output)), nil
+}
@@ -0,0 +1,289 @@
+// Frame-based terminal animation for the simulation days. Days implement
+// Framer and call Step on their animation as the simulation moves, and the
+// animation decides which steps to draw, following the scene's focus point.
+// Play/pause, stepping and speed are controlled from the keyboard.
+package visual
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/erikzak/adventofcode/2022/aoc/grid"
+)
+
+// Snapshot of a simulation. Only the part in view is drawn, so scenes can be
+// much larger than the terminal
+type Frame struct {
+	Title  string
+	Bounds grid.Rect               // Extent of the scene
+	Glyph  func(p grid.Point) rune // Character to draw at each point
+	Up     bool                    // Y grows upwards, drawn bottom row last
+	Focus  grid.Point              // Point kept in view, like the falling rock
+}
+
+// Scene that can be drawn. Implemented by each day's simulation state
+type Framer interface {
+	Frame() Frame
+}
+
+// Returns frame of lines of text, with focus on the given column and row
+func TextFrame(title string, lines []string, focus grid.Point) Frame {
+	width := 1
+	for _, line := range lines {
+		if len(line) > width {
+			width = len(line)
+		}
+	}
+	height := len(lines)
+	if height == 0 {
+		height = 1
+	}
+	return Frame{
+		Title:  title,
+		Bounds: grid.Rect{Max: grid.Point{width - 1, height - 1}},
+		Glyph: func(p grid.Point) rune {
+			if p[1] >= len(lines) || p[0] >= len(lines[p[1]]) {
+				return ' '
+			}
+			return rune(lines[p[1]][p[0]])
+		},
+		Focus: focus,
+	}
+}
+
+// Most frames drawn per second. Faster speeds skip steps instead
+const maxFPS = 60
+
+// Keyboard controls
+const (
+	keyPause  = ' '
+	keyStep   = 'n'
+	keyFaster = '+'
+	keySlower = '-'
+	keyQuit   = 'q'
+)
+
+const help = "[space] play/pause  [n] step  [+/-] speed  [q] quit"
+
+// Plays the steps of a simulation as frames on a terminal
+type Animation struct {
+	out    io.Writer   // Nil when only recording
+	keys   <-chan byte // Nil without keyboard controls
+	width  int
+	height int
+	sleep  func(d time.Duration)
+
+	speed    int // Steps per second
+	steps    int
+	paused   bool
+	stopped  bool
+	cleared  bool
+	scene    Framer
+	viewport viewport
+	recorder *Recorder // Nil unless recording
+}
+
+// Returns animation drawing to out, in a terminal of the given size. Keys are
+// read from keys, which may be nil to play without controls
+func New(out io.Writer, keys <-chan byte, width int, height int, speed int) *Animation {
+	if speed < 1 {
+		speed = 1
+	}
+	return &Animation{out: out, keys: keys, width: width, height: height, speed: speed, sleep: time.Sleep}
+}
+
+// Records a simulation step, drawing it if due. Blocks while paused. Does
+// nothing on a nil animation, so days can call it unconditionally
+func (animation *Animation) Step(scene Framer) {
+	if animation == nil {
+		return
+	}
+	// Recording goes on after quitting, which only skips drawing
+	if animation.recorder != nil {
+		animation.recorder.capture(scene)
+	}
+	if animation.stopped || animation.out == nil {
+		return
+	}
+	animation.steps++
+	animation.scene = scene
+	animation.readKeys()
+	if animation.stopped {
+		return
+	}
+	if animation.paused {
+		animation.draw("paused")
+		animation.waitKey(false)
+		return
+	}
+	if animation.steps%animation.stride() != 0 {
+		return
+	}
+	animation.draw("")
+	animation.sleep(time.Duration(animation.stride()) * time.Second / time.Duration(animation.speed))
+}
+
+// Draws the last step and waits for a key, if there is a keyboard. Used
+// when a simulation is done, so its final state can be looked at
+func (animation *Animation) Hold(status string) {
+	if animation == nil || animation.stopped || animation.out == nil || animation.scene == nil {
+		return
+	}
+	animation.draw(status + ", press any key")
+	animation.waitKey(true)
+}
+
+// Returns animation that only records steps, without drawing them
+func NewRecording(recorder *Recorder) *Animation {
+	return &Animation{recorder: recorder}
+}
+
+// Records steps from now on with recorder, in addition to drawing them.
+// A nil recorder stops recording
+func (animation *Animation) Record(recorder *Recorder) {
+	animation.recorder = recorder
+}
+
+// Number of steps per drawn frame at the current speed
+func (animation *Animation) stride() int {
+	return (animation.speed + maxFPS - 1) / maxFPS
+}
+
+// Handles keys pressed since the last step, without blocking. Keys after a
+// pause are left for the paused animation
+func (animation *Animation) readKeys() {
+	for !animation.paused {
+		select {
+		case key, ok := <-animation.keys:
+			if !ok {
+				animation.keys = nil
+				return
+			}
+			animation.handleKey(key)
+		default:
+			return
+		}
+	}
+}
+
+// Blocks until a key moves the animation on. If anyKey is set, any key does
+// and only quit has any other effect. Otherwise only play, step and quit do
+func (animation *Animation) waitKey(anyKey bool) {
+	for animation.keys != nil {
+		key, ok := <-animation.keys
+		if !ok {
+			animation.keys = nil
+			break
+		}
+		if anyKey {
+			animation.stopped = key == keyQuit
+			return
+		}
+		animation.handleKey(key)
+		if key == keyPause || key == keyStep || key == keyQuit {
+			return
+		}
+		animation.draw("paused")
+	}
+	// Nothing can unpause without a keyboard
+	animation.paused = false
+}
+
+// Applies keyboard control
+func (animation *Animation) handleKey(key byte) {
+	switch key {
+	case keyPause:
+		animation.paused = !animation.paused
+	case keyStep:
+		animation.paused = true
+	case keyFaster, '=':
+		animation.speed *= 2
+	case keySlower, '_':
+		if animation.speed > 1 {
+			animation.speed /= 2
+		}
+	case keyQuit:
+		animation.stopped = true
+	}
+}
+
+// Draws the current scene, with title above and status below
+func (animation *Animation) draw(status string) {
+	frame := animation.scene.Frame()
+	// Title and status take a line each
+	view := animation.viewport.follow(frame, grid.Point{animation.width, animation.height - 2})
+	builder := strings.Builder{}
+	if !animation.cleared {
+		// Clear once, later frames overwrite in place to avoid flicker
+		builder.WriteString("\x1b[2J")
+		animation.cleared = true
+	}
+	builder.WriteString("\x1b[H")
+	writeLine(&builder, frame.Title)
+	render := grid.Render
+	if frame.Up {
+		render = grid.RenderUp
+	}
+	for _, row := range strings.SplitAfter(render(view, frame.Glyph), "\n") {
+		if row != "" {
+			writeLine(&builder, strings.TrimSuffix(row, "\n"))
+		}
+	}
+	if status == "" {
+		status = help
+	}
+	fmt.Fprintf(&builder, "step %d  %d/s  %s\x1b[K\x1b[J", animation.steps, animation.speed, status)
+	io.WriteString(animation.out, builder.String())
+}
+
+// Writes line, clearing what is left of the previous frame on it
+func writeLine(builder *strings.Builder, line string) {
+	builder.WriteString(line)
+	builder.WriteString("\x1b[K\n")
+}
+
+// Part of a scene in view
+type viewport struct {
+	view  grid.Rect
+	valid bool
+}
+
+// Moves view of the given size to keep the frame focus in view. The view
+// only moves when the focus gets close to its edge, and is kept within the
+// scene bounds. Scenes smaller than the view are shown whole
+func (viewport *viewport) follow(frame Frame, size grid.Point) grid.Rect {
+	if size[0] < 1 {
+		size[0] = 1
+	}
+	if size[1] < 1 {
+		size[1] = 1
+	}
+	if !viewport.valid {
+		viewport.view = grid.Rect{Min: frame.Focus, Max: frame.Focus}
+		viewport.valid = true
+	}
+	for axis := 0; axis < 2; axis++ {
+		lo, hi := frame.Bounds.Min[axis], frame.Bounds.Max[axis]
+		if hi-lo+1 <= size[axis] {
+			viewport.view.Min[axis], viewport.view.Max[axis] = lo, hi
+			continue
+		}
+		start := viewport.view.Min[axis]
+		margin := size[axis] / 4
+		focus := frame.Focus[axis]
+		if focus < start+margin {
+			start = focus - margin
+		} else if focus > start+size[axis]-1-margin {
+			start = focus - size[axis] + 1 + margin
+		}
+		if start < lo {
+			start = lo
+		} else if start > hi-size[axis]+1 {
+			start = hi - size[axis] + 1
+		}
+		viewport.view.Min[axis], viewport.view.Max[axis] = start, start+size[axis]-1
+	}
+	return viewport.view
+}
@@ -0,0 +1,129 @@
+package visual
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/erikzak/adventofcode/2022/aoc/grid"
+)
+
+// Dot moving right along a line 100 wide
+type dot struct {
+	x int
+}
+
+func (d *dot) Frame() Frame {
+	return Frame{
+		Title:  "dot",
+		Bounds: grid.Rect{Max: grid.Point{99, 0}},
+		Glyph: func(p grid.Point) rune {
+			if p[0] == d.x {
+				return 'o'
+			}
+			return '.'
+		},
+		Focus: grid.Point{d.x, 0},
+	}
+}
+
+// Returns animation on a 20x4 terminal that never sleeps, and its output
+func newTestAnimation(keys <-chan byte, speed int) (*Animation, *bytes.Buffer, *time.Duration) {
+	out := &bytes.Buffer{}
+	animation := New(out, keys, 20, 4, speed)
+	slept := new(time.Duration)
+	animation.sleep = func(d time.Duration) {
+		*slept += d
+	}
+	return animation, out, slept
+}
+
+// Returns the frame rows last drawn to out
+func lastRows(out *bytes.Buffer) []string {
+	frames := strings.Split(out.String(), "\x1b[H")
+	rows := []string{}
+	for _, line := range strings.Split(frames[len(frames)-1], "\n") {
+		rows = append(rows, strings.TrimSuffix(line, "\x1b[K"))
+	}
+	return rows
+}
+
+// Tests that the view follows the focus and stays within the scene
+func TestFollow(t *testing.T) {
+	animation, out, _ := newTestAnimation(nil, 1)
+	scene := &dot{}
+	for x := 0; x < 100; x++ {
+		scene.x = x
+		animation.Step(scene)
+		row := lastRows(out)[1]
+		if len(row) != 20 || !strings.Contains(row, "o") {
+			t.Fatalf(`Step() at x = %d drew %q, want the dot in 20 columns`, x, row)
+		}
+	}
+	if animation.viewport.view.Max[0] != 99 {
+		t.Fatalf(`view = %v, want it to end at the scene edge`, animation.viewport.view)
+	}
+	// Small scenes are drawn whole
+	animation.Step(fixed{TextFrame("text", []string{"abc", "de"}, grid.Point{1, 1})})
+	if rows := lastRows(out); rows[1] != "abc" || rows[2] != "de " {
+		t.Fatalf(`Step() of text drew %q`, rows[1:3])
+	}
+}
+
+// Framer of a fixed frame
+type fixed struct {
+	frame Frame
+}
+
+func (scene fixed) Frame() Frame {
+	return scene.frame
+}
+
+// Tests that fast speeds skip steps instead of drawing faster than maxFPS
+func TestSpeed(t *testing.T) {
+	animation, out, slept := newTestAnimation(nil, 6*maxFPS)
+	for i := 0; i < 6*maxFPS; i++ {
+		animation.Step(&dot{x: i % 100})
+	}
+	if frames := strings.Count(out.String(), "\x1b[H"); frames != maxFPS {
+		t.Fatalf(`Step() drew %d frames, want %d`, frames, maxFPS)
+	}
+	if *slept < time.Second-time.Millisecond || *slept > time.Second {
+		t.Fatalf(`Step() slept %v, want 1s`, *slept)
+	}
+}
+
+// Tests pausing, stepping, speed control and quitting from the keyboard
+func TestControls(t *testing.T) {
+	keys := make(chan byte, 16)
+	animation, out, _ := newTestAnimation(keys, 10)
+	scene := &dot{}
+	// Pause, speed up while paused, step once, then play
+	for _, key := range []byte{' ', '+', 'n', ' '} {
+		keys <- key
+	}
+	animation.Step(scene)
+	if !strings.Contains(out.String(), "paused") || animation.speed != 20 {
+		t.Fatalf(`Step() after pause drew %q at speed %d`, lastRows(out), animation.speed)
+	}
+	if !animation.paused {
+		t.Fatal(`Step() after step key is not paused`)
+	}
+	animation.Step(scene)
+	if animation.paused {
+		t.Fatal(`Step() after play key is paused`)
+	}
+	keys <- 'q'
+	animation.Step(scene)
+	drawn := out.Len()
+	animation.Step(scene)
+	animation.Hold("done")
+	if !animation.stopped || out.Len() != drawn {
+		t.Fatal(`Step() after quit kept drawing`)
+	}
+	// Nil animations do nothing, for days solved without visualization
+	var none *Animation
+	none.Step(scene)
+	none.Hold("done")
+}